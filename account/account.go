@@ -0,0 +1,425 @@
+// Package account provides a stateful layer on top of the API for sending
+// and receiving IOTA tokens: it keeps track of used key indexes, conditional
+// deposit addresses and pending transfers in a Store and emits events about
+// account activity through an event machine.
+package account
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/tracing"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrEmptyRecipients gets returned when Send() is called without any recipients.
+	ErrEmptyRecipients = errors.New("at least one recipient must be supplied")
+	// ErrAccountNotRunning gets returned when an operation is executed on a non started account.
+	ErrAccountNotRunning = errors.New("account is not running")
+	// ErrInvalidAccountSettings gets returned when the settings for an account are inconsistent.
+	ErrInvalidAccountSettings = errors.New("invalid account settings")
+)
+
+// SeedProvider is a provider of the account's seed. It allows implementations
+// to fetch the seed from a secure location instead of keeping it in memory.
+type SeedProvider interface {
+	// Seed returns the seed of the account.
+	Seed() (Trytes, error)
+}
+
+// NewInMemorySeedProvider creates a SeedProvider returning the given seed.
+func NewInMemorySeedProvider(seed Trytes) SeedProvider {
+	return inMemorySeedProvider(seed)
+}
+
+type inMemorySeedProvider Trytes
+
+func (prov inMemorySeedProvider) Seed() (Trytes, error) { return Trytes(prov), nil }
+
+// Settings defines the settings with which an account is created.
+type Settings struct {
+	// The API object used to communicate with the network.
+	API *api.API
+	// The store in which the account's state is persisted.
+	Store store.Store
+	// The provider of the account's seed.
+	SeedProv SeedProvider
+	// The minimum weight magnitude used for Proof-of-Work.
+	MWM uint64
+	// The depth used for tip-selection.
+	Depth uint64
+	// The security level used for generating new addresses.
+	SecurityLevel SecurityLevel
+	// The event machine to which the account emits its events.
+	EventMachine event.EventMachine
+	// The source of time used by the account and its plugins.
+	Clock Clock
+	// The plugins which are started and shut down with the account.
+	Plugins []Plugin
+	// An optional spending policy enforced on every Send() call.
+	Policy *SpendingPolicy
+	// An optional tracer receiving spans about account operations.
+	Tracer tracing.Tracer
+}
+
+// DefaultSettings returns Settings initialized with default values:
+// MWM 14, depth 3, security level 2 and an in-memory store and event machine.
+func DefaultSettings() *Settings {
+	clock := SystemClock()
+	return &Settings{
+		MWM:           14,
+		Depth:         3,
+		SecurityLevel: SecurityLevelMedium,
+		Store:         store.NewInMemoryStore(clock),
+		EventMachine:  event.NewEventMachine(),
+		Clock:         clock,
+	}
+}
+
+// Recipient is a transfer to a recipient address.
+type Recipient = bundle.Transfer
+
+// Plugin is a component which adds custom behavior to an account, such as
+// polling for transfers, promoting pending bundles, analytics or compliance
+// checks. Plugins are registered via the account's Settings and share the
+// account's provider, store and event machine. Start() is called when the
+// account starts and Shutdown() when it shuts down; both are called at most
+// once per account run.
+type Plugin interface {
+	// Name returns the name of the plugin.
+	Name() string
+	// Start starts the plugin for the given account.
+	Start(acc *Account) error
+	// Shutdown shuts the plugin down.
+	Shutdown() error
+}
+
+// Account is a stateful object managing the funds of a seed.
+//
+// All methods of an Account are safe for concurrent use. State mutating
+// operations (Send(), NewDepositAddress()) are serialized internally: they
+// execute one at a time in the order in which they acquire the account's
+// operation lock, so two simultaneous Send() calls never select the same
+// inputs and two simultaneous NewDepositAddress() calls never derive the
+// same key index. Read-only methods may run concurrently to each other
+// and to a mutating operation.
+type Account struct {
+	id    string
+	setts *Settings
+
+	// guards the running flag
+	mu      sync.RWMutex
+	running bool
+
+	// serializes state mutating operations
+	opMu sync.Mutex
+
+	// records recent errors and event times for introspection
+	track *tracker
+}
+
+// NewAccount creates a new account with the given settings.
+func NewAccount(setts *Settings) (*Account, error) {
+	if setts == nil {
+		setts = DefaultSettings()
+	}
+	if setts.API == nil || setts.Store == nil || setts.SeedProv == nil {
+		return nil, errors.Wrap(ErrInvalidAccountSettings, "API, Store and SeedProv must be set")
+	}
+	if setts.EventMachine == nil {
+		setts.EventMachine = event.NewEventMachine()
+	}
+	if setts.Clock == nil {
+		setts.Clock = SystemClock()
+	}
+	seed, err := setts.SeedProv.Seed()
+	if err != nil {
+		return nil, err
+	}
+	id, err := accountID(seed, setts.SecurityLevel)
+	if err != nil {
+		return nil, err
+	}
+	acc := &Account{id: id, setts: setts}
+	acc.trackEvents()
+	return acc, nil
+}
+
+// accountID derives the account's id from the address with index 0 of the given seed.
+func accountID(seed Trytes, secLvl SecurityLevel) (string, error) {
+	addr, err := address.GenerateAddress(seed, 0, secLvl)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(addr))), nil
+}
+
+// ID returns the account's identifier, derived from the seed.
+func (acc *Account) ID() string {
+	return acc.id
+}
+
+// Settings returns the settings of the account.
+func (acc *Account) Settings() *Settings {
+	return acc.setts
+}
+
+// Start starts the account and all its registered plugins, after which the
+// account can be used to send and receive transfers. If a plugin fails to
+// start, already started plugins are shut down again and the error is returned.
+func (acc *Account) Start() error {
+	if _, err := acc.setts.Store.LoadAccount(acc.id); err != nil {
+		return err
+	}
+	acc.mu.Lock()
+	acc.running = true
+	acc.mu.Unlock()
+	if err := acc.resumeInFlightTransfers(); err != nil {
+		return err
+	}
+	for i, plugin := range acc.setts.Plugins {
+		if err := plugin.Start(acc); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				acc.setts.Plugins[j].Shutdown()
+			}
+			acc.mu.Lock()
+			acc.running = false
+			acc.mu.Unlock()
+			return errors.Wrapf(err, "unable to start plugin %s", plugin.Name())
+		}
+	}
+	return nil
+}
+
+// Shutdown shuts the account and all its registered plugins down,
+// emitting an EventShutdown event.
+func (acc *Account) Shutdown() error {
+	acc.mu.Lock()
+	if !acc.running {
+		acc.mu.Unlock()
+		return ErrAccountNotRunning
+	}
+	acc.running = false
+	acc.mu.Unlock()
+	var firstErr error
+	for _, plugin := range acc.setts.Plugins {
+		if err := plugin.Shutdown(); err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "unable to shutdown plugin %s", plugin.Name())
+		}
+	}
+	acc.setts.EventMachine.Emit(acc.id, event.EventShutdown)
+	return firstErr
+}
+
+// isRunning tells whether the account is currently started.
+func (acc *Account) isRunning() bool {
+	acc.mu.RLock()
+	defer acc.mu.RUnlock()
+	return acc.running
+}
+
+// IsNew tells whether the account is new, i.e. has no state in the store yet.
+func (acc *Account) IsNew() (bool, error) {
+	state, err := acc.setts.Store.LoadAccount(acc.id)
+	if err != nil {
+		return false, err
+	}
+	return state.IsNew(), nil
+}
+
+// NewDepositAddress generates a new conditional deposit address fulfilling
+// the given conditions and persists it in the store for monitoring.
+func (acc *Account) NewDepositAddress(conditions *deposit.Conditions) (*deposit.Conditions, error) {
+	if !acc.isRunning() {
+		return nil, ErrAccountNotRunning
+	}
+	acc.opMu.Lock()
+	defer acc.opMu.Unlock()
+	_, span := tracing.OrNoop(acc.setts.Tracer).StartSpan(context.Background(), "account.new_deposit_address",
+		tracing.String("account.id", acc.id))
+	defer span.End()
+	seed, err := acc.setts.SeedProv.Seed()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	index, err := acc.setts.Store.ReadIndex(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := address.GenerateAddress(seed, index, acc.setts.SecurityLevel)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.setts.Store.WriteIndex(acc.id, index+1); err != nil {
+		return nil, err
+	}
+	cda := &deposit.Conditions{
+		Address:        addr,
+		TimeoutAt:      conditions.TimeoutAt,
+		MultiUse:       conditions.MultiUse,
+		ExpectedAmount: conditions.ExpectedAmount,
+	}
+	if err := acc.setts.Store.AddDepositAddress(acc.id, index, cda); err != nil {
+		return nil, err
+	}
+	return cda, nil
+}
+
+// Send sends the given amounts to the given recipients, persisting the
+// resulting bundle as a pending transfer until it is confirmed.
+// Simultaneous Send() calls are executed strictly one after another.
+func (acc *Account) Send(recipients ...Recipient) (bundle.Bundle, error) {
+	if !acc.isRunning() {
+		return nil, ErrAccountNotRunning
+	}
+	if len(recipients) == 0 {
+		return nil, ErrEmptyRecipients
+	}
+	acc.opMu.Lock()
+	defer acc.opMu.Unlock()
+	_, span := tracing.OrNoop(acc.setts.Tracer).StartSpan(context.Background(), "account.send",
+		tracing.String("account.id", acc.id),
+		tracing.Int64("transfers.count", int64(len(recipients))))
+	defer span.End()
+	seed, err := acc.setts.SeedProv.Seed()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	transfers := make(bundle.Transfers, len(recipients))
+	var totalValue uint64
+	for i, recipient := range recipients {
+		transfers[i] = recipient
+		totalValue += recipient.Value
+	}
+	span.SetAttributes(tracing.Int64("transfers.total_value", int64(totalValue)))
+
+	if acc.setts.Policy != nil {
+		if err := acc.setts.Policy.enforce(transfers, acc.setts.Clock.Now()); err != nil {
+			return nil, err
+		}
+	}
+	// once enforce succeeded, the reserved budget must be given back on every
+	// failure path, otherwise it stays spent without a bundle ever being sent
+	releasePolicy := func() {
+		if acc.setts.Policy != nil {
+			acc.setts.Policy.release(totalValue, acc.setts.Clock.Now())
+		}
+	}
+
+	acc.setts.EventMachine.Emit(transfers, event.EventSendingTransfer)
+
+	// stage 1: prepare the bundle and persist it before doing anything irreversible
+	preparedTrytes, err := acc.setts.API.PrepareTransfers(seed, transfers, api.PrepareTransfersOptions{
+		Security: acc.setts.SecurityLevel,
+	})
+	if err != nil {
+		releasePolicy()
+		span.RecordError(err)
+		acc.setts.EventMachine.Emit(err, event.EventError)
+		return nil, err
+	}
+	span.SetAttributes(tracing.Int64("bundle.size", int64(len(preparedTrytes))))
+	bundleHash, err := bundleHashOfTrytes(preparedTrytes)
+	if err != nil {
+		releasePolicy()
+		return nil, err
+	}
+	if err := acc.setts.Store.AddInFlightTransfer(acc.id, bundleHash, &store.InFlightTransfer{
+		Trytes:    preparedTrytes,
+		CreatedAt: acc.setts.Clock.Now().Unix(),
+	}); err != nil {
+		releasePolicy()
+		return nil, err
+	}
+
+	bndl, err := acc.completeSend(bundleHash, preparedTrytes, false)
+	if err != nil {
+		releasePolicy()
+		span.RecordError(err)
+		acc.setts.EventMachine.Emit(err, event.EventError)
+		return nil, err
+	}
+	return bndl, nil
+}
+
+// completeSend runs the remaining stages of the send pipeline for a persisted
+// in-flight transfer: attaching (unless already attached), broadcasting and
+// finally registering the bundle as a pending transfer.
+func (acc *Account) completeSend(bundleHash Hash, trytes []Trytes, attached bool) (bundle.Bundle, error) {
+	if !attached {
+		tips, err := acc.setts.API.GetTransactionsToApprove(acc.setts.Depth)
+		if err != nil {
+			return nil, err
+		}
+		trytes, err = acc.setts.API.AttachToTangle(tips.TrunkTransaction, tips.BranchTransaction, acc.setts.MWM, trytes)
+		if err != nil {
+			return nil, err
+		}
+		// persist the attachment progress so a shutdown before the broadcast
+		// does not lose the already done Proof-of-Work
+		if err := acc.setts.Store.AddInFlightTransfer(acc.id, bundleHash, &store.InFlightTransfer{
+			Trytes:    trytes,
+			Attached:  true,
+			CreatedAt: acc.setts.Clock.Now().Unix(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := acc.setts.API.StoreAndBroadcast(trytes); err != nil {
+		return nil, err
+	}
+	bndl, err := transaction.AsTransactionObjects(trytes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tailTxHash := bundle.TailTransactionHash(bndl)
+	if err := acc.setts.Store.AddPendingTransfer(acc.id, tailTxHash, trytes); err != nil {
+		return nil, err
+	}
+	if err := acc.setts.Store.RemoveInFlightTransfer(acc.id, bundleHash); err != nil {
+		return nil, err
+	}
+	return bndl, nil
+}
+
+// resumeInFlightTransfers rolls forward transfers whose send pipeline was
+// interrupted by a shutdown or crash during a previous run.
+func (acc *Account) resumeInFlightTransfers() error {
+	inFlight, err := acc.setts.Store.GetInFlightTransfers(acc.id)
+	if err != nil {
+		return err
+	}
+	for bundleHash, transfer := range inFlight {
+		if _, err := acc.completeSend(bundleHash, transfer.Trytes, transfer.Attached); err != nil {
+			acc.setts.EventMachine.Emit(errors.Wrapf(err, "unable to resume in-flight transfer %s", bundleHash), event.EventError)
+		}
+	}
+	return nil
+}
+
+// bundleHashOfTrytes extracts the bundle hash from the given bundle trytes.
+func bundleHashOfTrytes(trytes []Trytes) (Hash, error) {
+	tx, err := transaction.AsTransactionObject(trytes[0])
+	if err != nil {
+		return "", err
+	}
+	return tx.Bundle, nil
+}
@@ -0,0 +1,187 @@
+package account_test
+
+import (
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/api"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Account", func() {
+
+	Context("NewAccount()", func() {
+
+		It("should return an error when required settings are missing", func() {
+			_, err := account.NewAccount(&account.Settings{})
+			Expect(errors.Cause(err)).To(Equal(account.ErrInvalidAccountSettings))
+		})
+
+		It("should derive a stable id from the seed", func() {
+			env := newTestEnv()
+			env2 := newTestEnv()
+			Expect(env.acc.ID()).To(Equal(env2.acc.ID()))
+			Expect(env.acc.ID()).ToNot(BeEmpty())
+		})
+	})
+
+	Context("lifecycle", func() {
+
+		It("should reject operations while the account is not running", func() {
+			env := newTestEnv()
+			Expect(env.acc.Shutdown()).ToNot(HaveOccurred())
+			_, err := env.acc.Send(account.Recipient{Address: testRecipient})
+			Expect(err).To(Equal(account.ErrAccountNotRunning))
+			_, err = env.acc.NewDepositAddress(&deposit.Conditions{})
+			Expect(err).To(Equal(account.ErrAccountNotRunning))
+			Expect(env.acc.Shutdown()).To(Equal(account.ErrAccountNotRunning))
+		})
+
+		It("should report a fresh account as new", func() {
+			env := newTestEnv()
+			isNew, err := env.acc.IsNew()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isNew).To(BeTrue())
+
+			_, err = env.acc.NewDepositAddress(&deposit.Conditions{})
+			Expect(err).ToNot(HaveOccurred())
+			isNew, err = env.acc.IsNew()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(isNew).To(BeFalse())
+		})
+
+		It("should emit a shutdown event on Shutdown()", func() {
+			env := newTestEnv()
+			var emitted bool
+			env.setts.EventMachine.RegisterListener(func(data interface{}) {
+				emitted = true
+			}, event.EventShutdown)
+			Expect(env.acc.Shutdown()).ToNot(HaveOccurred())
+			Expect(emitted).To(BeTrue())
+		})
+	})
+
+	Context("NewDepositAddress()", func() {
+
+		It("should derive a new address per call and persist its conditions", func() {
+			env := newTestEnv()
+			timeout := env.clock.Now().Add(time.Hour)
+			cda, err := env.acc.NewDepositAddress(&deposit.Conditions{TimeoutAt: &timeout})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cda.Address).To(HaveLen(81))
+			Expect(cda.TimeoutAt.Equal(timeout)).To(BeTrue())
+
+			cda2, err := env.acc.NewDepositAddress(&deposit.Conditions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cda2.Address).ToNot(Equal(cda.Address))
+
+			index, err := env.store.ReadIndex(env.acc.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(index).To(Equal(uint64(2)))
+
+			cdas, err := env.store.GetDepositAddresses(env.acc.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cdas).To(HaveLen(2))
+		})
+	})
+
+	Context("Send()", func() {
+
+		It("should return an error without recipients", func() {
+			env := newTestEnv()
+			_, err := env.acc.Send()
+			Expect(err).To(Equal(account.ErrEmptyRecipients))
+		})
+
+		It("should run the full send pipeline for a zero value transfer", func() {
+			env := newTestEnv()
+			var sending bool
+			env.setts.EventMachine.RegisterListener(func(data interface{}) {
+				sending = true
+			}, event.EventSendingTransfer)
+
+			bndl, err := env.acc.Send(account.Recipient{Address: testRecipient})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bndl).ToNot(BeEmpty())
+			Expect(sending).To(BeTrue())
+			Expect(env.prov.sentCommands()).To(Equal([]api.IRICommand{
+				api.GetTransactionsToApproveCmd, api.StoreTransactionsCmd, api.BroadcastTransactionsCmd,
+			}))
+
+			// the transfer is now monitored as pending and no in-flight stage remains
+			pending, err := env.store.GetPendingTransfers(env.acc.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pending).To(HaveLen(1))
+			inFlight, err := env.store.GetInFlightTransfers(env.acc.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(inFlight).To(BeEmpty())
+		})
+
+		It("should emit an error event when the node rejects the transfer", func() {
+			env := newTestEnv()
+			var emittedErr error
+			env.setts.EventMachine.RegisterListener(func(data interface{}) {
+				emittedErr = data.(error)
+			}, event.EventError)
+
+			env.prov.setSendErr(errors.New("node unavailable"))
+			_, err := env.acc.Send(account.Recipient{Address: testRecipient})
+			Expect(err).To(HaveOccurred())
+			Expect(emittedErr).To(HaveOccurred())
+		})
+	})
+
+	Context("plugins", func() {
+
+		It("should start and shut down registered plugins with the account", func() {
+			plugin := &recordingPlugin{name: "recorder"}
+			env := newTestEnv(func(setts *account.Settings) {
+				setts.Plugins = []account.Plugin{plugin}
+			})
+			Expect(plugin.started).To(BeTrue())
+			Expect(env.acc.Shutdown()).ToNot(HaveOccurred())
+			Expect(plugin.shutdown).To(BeTrue())
+		})
+
+		It("should roll back started plugins when a later plugin fails to start", func() {
+			first := &recordingPlugin{name: "first"}
+			failing := &recordingPlugin{name: "failing", startErr: errors.New("boom")}
+			env := newTestEnvUnstarted(func(setts *account.Settings) {
+				setts.Plugins = []account.Plugin{first, failing}
+			})
+			Expect(env.acc.Start()).To(HaveOccurred())
+			Expect(first.started).To(BeTrue())
+			Expect(first.shutdown).To(BeTrue())
+			_, err := env.acc.Send(account.Recipient{Address: testRecipient})
+			Expect(err).To(Equal(account.ErrAccountNotRunning))
+		})
+	})
+})
+
+// recordingPlugin records its lifecycle calls.
+type recordingPlugin struct {
+	name     string
+	startErr error
+	started  bool
+	shutdown bool
+}
+
+func (rp *recordingPlugin) Name() string { return rp.name }
+
+func (rp *recordingPlugin) Start(acc *account.Account) error {
+	if rp.startErr != nil {
+		return rp.startErr
+	}
+	rp.started = true
+	return nil
+}
+
+func (rp *recordingPlugin) Shutdown() error {
+	rp.shutdown = true
+	return nil
+}
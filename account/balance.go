@@ -0,0 +1,110 @@
+package account
+
+import (
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// TotalBalance returns the sum of the balances residing on all
+// deposit addresses of the account, including unconfirmed deposits
+// reserved by unexpired conditional deposit addresses.
+func (acc *Account) TotalBalance() (uint64, error) {
+	if !acc.isRunning() {
+		return 0, ErrAccountNotRunning
+	}
+	addresses, err := acc.depositAddressList()
+	if err != nil {
+		return 0, err
+	}
+	return acc.sumBalances(addresses)
+}
+
+// AvailableBalance returns the balance which can be spent safely right now:
+// the total balance minus funds residing on addresses which are used as
+// inputs by still pending outgoing transfers and minus funds residing on
+// unexpired conditional deposit addresses, which are reserved until their
+// conditions run out. Exchanges doing withdrawals should always use this
+// method instead of TotalBalance() to avoid double-spending inputs.
+func (acc *Account) AvailableBalance() (uint64, error) {
+	if !acc.isRunning() {
+		return 0, ErrAccountNotRunning
+	}
+	reserved, err := acc.reservedAddresses()
+	if err != nil {
+		return 0, err
+	}
+	addresses, err := acc.depositAddressList()
+	if err != nil {
+		return 0, err
+	}
+	available := make(Hashes, 0, len(addresses))
+	for _, addr := range addresses {
+		if _, ok := reserved[addr]; ok {
+			continue
+		}
+		available = append(available, addr)
+	}
+	return acc.sumBalances(available)
+}
+
+// reservedAddresses returns the set of addresses whose funds are currently
+// not spendable: input addresses of pending outgoing transfers and
+// unexpired conditional deposit addresses.
+func (acc *Account) reservedAddresses() (map[string]struct{}, error) {
+	reserved := map[string]struct{}{}
+
+	pendingTransfers, err := acc.setts.Store.GetPendingTransfers(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	for _, pendingTransfer := range pendingTransfers {
+		txs, err := transaction.AsTransactionObjects(pendingTransfer.Bundle, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range txs {
+			if txs[i].Value < 0 {
+				reserved[txs[i].Address] = struct{}{}
+			}
+		}
+	}
+
+	depositAddresses, err := acc.setts.Store.GetDepositAddresses(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	now := acc.setts.Clock.Now()
+	for _, cda := range depositAddresses {
+		if cda.TimeoutAt != nil && !cda.Expired(now) {
+			reserved[cda.Address] = struct{}{}
+		}
+	}
+	return reserved, nil
+}
+
+func (acc *Account) depositAddressList() (Hashes, error) {
+	depositAddresses, err := acc.setts.Store.GetDepositAddresses(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(Hashes, 0, len(depositAddresses))
+	for _, cda := range depositAddresses {
+		addresses = append(addresses, cda.Address)
+	}
+	return addresses, nil
+}
+
+func (acc *Account) sumBalances(addresses Hashes) (uint64, error) {
+	if len(addresses) == 0 {
+		return 0, nil
+	}
+	balances, err := acc.setts.API.GetBalances(addresses, 100)
+	if err != nil {
+		return 0, err
+	}
+	var total uint64
+	for _, balance := range balances.Balances {
+		total += balance
+	}
+	return total, nil
+}
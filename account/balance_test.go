@@ -0,0 +1,68 @@
+package account_test
+
+import (
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("balances", func() {
+
+	It("should reject balance queries while the account is not running", func() {
+		env := newTestEnv()
+		Expect(env.acc.Shutdown()).ToNot(HaveOccurred())
+		_, err := env.acc.TotalBalance()
+		Expect(err).To(Equal(account.ErrAccountNotRunning))
+		_, err = env.acc.AvailableBalance()
+		Expect(err).To(Equal(account.ErrAccountNotRunning))
+	})
+
+	It("should report zero balances for an account without deposit addresses", func() {
+		env := newTestEnv()
+		total, err := env.acc.TotalBalance()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(total).To(BeZero())
+		available, err := env.acc.AvailableBalance()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(available).To(BeZero())
+	})
+
+	It("should subtract reserved funds from the available balance", func() {
+		env := newTestEnv()
+		env.prov.balancePerAddress = 50
+
+		// index 0: a regular deposit address, spendable right away
+		_, err := env.acc.NewDepositAddress(&deposit.Conditions{})
+		Expect(err).ToNot(HaveOccurred())
+		// index 1: an unexpired conditional deposit address, reserved until its timeout
+		timeout := env.clock.Now().Add(time.Hour)
+		_, err = env.acc.NewDepositAddress(&deposit.Conditions{TimeoutAt: &timeout})
+		Expect(err).ToNot(HaveOccurred())
+
+		total, err := env.acc.TotalBalance()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(total).To(Equal(uint64(100)))
+		available, err := env.acc.AvailableBalance()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(available).To(Equal(uint64(50)))
+
+		// spending reserves the used input address while the transfer is pending;
+		// input gathering selects the address with index 0
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient, Value: 50})
+		Expect(err).ToNot(HaveOccurred())
+
+		available, err = env.acc.AvailableBalance()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(available).To(BeZero())
+
+		// once the conditional deposit address expires, its funds become spendable
+		env.clock.Advance(2 * time.Hour)
+		available, err = env.acc.AvailableBalance()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(available).To(Equal(uint64(50)))
+	})
+})
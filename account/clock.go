@@ -0,0 +1,27 @@
+package account
+
+import (
+	"time"
+)
+
+// Clock is the source of time for the account subsystem. All components which
+// read wall time (conditional deposit address expiry, poll intervals, reattach
+// thresholds) do so through the account's Clock, so tests can fast-forward
+// time deterministically and deployments can inject an NTP-disciplined source.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the given duration to elapse and then
+	// sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock returns a Clock backed by the system's wall clock.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
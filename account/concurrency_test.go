@@ -0,0 +1,110 @@
+package account_test
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// These specs exercise the documented concurrency guarantees of an account.
+// They are meant to be run with the race detector enabled.
+var _ = Describe("concurrent account usage", func() {
+
+	It("should serialize concurrent NewDepositAddress() calls to unique key indexes", func() {
+		env := newTestEnv()
+		const callers = 16
+
+		addrs := make([]string, callers)
+		var wg sync.WaitGroup
+		wg.Add(callers)
+		for i := 0; i < callers; i++ {
+			go func(i int) {
+				defer wg.Done()
+				defer GinkgoRecover()
+				cda, err := env.acc.NewDepositAddress(&deposit.Conditions{})
+				Expect(err).ToNot(HaveOccurred())
+				addrs[i] = string(cda.Address)
+			}(i)
+		}
+		wg.Wait()
+
+		seen := map[string]struct{}{}
+		for _, addr := range addrs {
+			seen[addr] = struct{}{}
+		}
+		Expect(seen).To(HaveLen(callers))
+
+		index, err := env.store.ReadIndex(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(uint64(callers)))
+	})
+
+	It("should allow sends, deposit addresses and balance reads to run concurrently", func() {
+		env := newTestEnv()
+		const callers = 4
+
+		var wg sync.WaitGroup
+		wg.Add(callers * 3)
+		for i := 0; i < callers; i++ {
+			// distinct tags keep the concurrently sent bundles distinguishable
+			tag := Trytes(strings.Repeat(string(rune('A'+i)), 27))
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				_, err := env.acc.Send(account.Recipient{Address: testRecipient, Tag: tag})
+				Expect(err).ToNot(HaveOccurred())
+			}()
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				_, err := env.acc.NewDepositAddress(&deposit.Conditions{})
+				Expect(err).ToNot(HaveOccurred())
+			}()
+			go func() {
+				defer wg.Done()
+				defer GinkgoRecover()
+				_, err := env.acc.AvailableBalance()
+				Expect(err).ToNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		// every send ran to completion exactly once
+		pending, err := env.store.GetPendingTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(HaveLen(callers))
+	})
+
+	It("should keep running state consistent under concurrent shutdowns", func() {
+		env := newTestEnv()
+		const callers = 8
+
+		errs := make([]error, callers)
+		var wg sync.WaitGroup
+		wg.Add(callers)
+		for i := 0; i < callers; i++ {
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = env.acc.Shutdown()
+			}(i)
+		}
+		wg.Wait()
+
+		// exactly one shutdown succeeds, all others see a stopped account
+		var succeeded int
+		for _, err := range errs {
+			if err == nil {
+				succeeded++
+			} else {
+				Expect(err).To(Equal(account.ErrAccountNotRunning))
+			}
+		}
+		Expect(succeeded).To(Equal(1))
+	})
+})
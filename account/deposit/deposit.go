@@ -0,0 +1,26 @@
+// Package deposit provides conditional deposit addresses (CDAs):
+// deposit addresses tied to conditions such as a timeout or an expected amount.
+package deposit
+
+import (
+	"time"
+
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Conditions defines the conditions of a conditional deposit address.
+type Conditions struct {
+	// The address to which the conditions apply.
+	Address Hash `json:"address"`
+	// The point in time after which the deposit address should no longer be used.
+	TimeoutAt *time.Time `json:"timeout_at,omitempty"`
+	// Whether the deposit address may receive more than one deposit.
+	MultiUse bool `json:"multi_use,omitempty"`
+	// The amount which must be sent to the deposit address.
+	ExpectedAmount *uint64 `json:"expected_amount,omitempty"`
+}
+
+// Expired tells whether the conditions are expired in respect to the given point in time.
+func (c *Conditions) Expired(now time.Time) bool {
+	return c.TimeoutAt != nil && !now.Before(*c.TimeoutAt)
+}
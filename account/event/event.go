@@ -0,0 +1,93 @@
+// Package event provides the event machine used by accounts to emit
+// and listen to events such as incoming deposits or confirmed transfers.
+package event
+
+import (
+	"sync"
+)
+
+// Event is a type of event emitted by an account or one of its plugins.
+type Event int32
+
+const (
+	// EventSendingTransfer is emitted when a transfer is being sent off.
+	EventSendingTransfer Event = iota
+	// EventTransferConfirmed is emitted when an own transfer got confirmed.
+	EventTransferConfirmed
+	// EventReceivingDeposit is emitted when a deposit transfer is seen but not yet confirmed.
+	EventReceivingDeposit
+	// EventReceivedDeposit is emitted when a deposit transfer got confirmed.
+	EventReceivedDeposit
+	// EventReceivedMessage is emitted when a zero-value transfer to a monitored address got confirmed.
+	EventReceivedMessage
+	// EventPromotion is emitted when a pending transfer got promoted.
+	EventPromotion
+	// EventReattachment is emitted when a pending transfer got reattached.
+	EventReattachment
+	// EventDepositAddressExpired is emitted when a conditional deposit address
+	// expired without holding any balance and is no longer watched.
+	EventDepositAddressExpired
+	// EventDepositAddressExpiredWithBalance is emitted when a conditional deposit
+	// address expired while still holding balance which needs to be swept.
+	EventDepositAddressExpiredWithBalance
+	// EventError is emitted when an internal error occurs.
+	EventError
+	// EventShutdown is emitted when the account shuts down.
+	EventShutdown
+)
+
+// ListenerCallback is a function which is called with the event's data when the event is emitted.
+type ListenerCallback func(data interface{})
+
+// EventMachine defines an object which registers listeners and emits events to them.
+type EventMachine interface {
+	// Emit emits the given event with the given data to all registered listeners.
+	Emit(data interface{}, event Event)
+	// RegisterListener registers the given callback for the given event and returns the listener's id.
+	RegisterListener(cb ListenerCallback, event Event) uint64
+	// UnregisterListener removes the listener with the given id.
+	UnregisterListener(id uint64)
+}
+
+// NewEventMachine creates a new EventMachine which dispatches events to listeners in-memory.
+func NewEventMachine() EventMachine {
+	return &eventMachine{listeners: map[Event]map[uint64]ListenerCallback{}}
+}
+
+type eventMachine struct {
+	mu        sync.RWMutex
+	listeners map[Event]map[uint64]ListenerCallback
+	nextID    uint64
+}
+
+func (em *eventMachine) Emit(data interface{}, event Event) {
+	em.mu.RLock()
+	cbs := make([]ListenerCallback, 0, len(em.listeners[event]))
+	for _, cb := range em.listeners[event] {
+		cbs = append(cbs, cb)
+	}
+	em.mu.RUnlock()
+	for _, cb := range cbs {
+		cb(data)
+	}
+}
+
+func (em *eventMachine) RegisterListener(cb ListenerCallback, event Event) uint64 {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if em.listeners[event] == nil {
+		em.listeners[event] = map[uint64]ListenerCallback{}
+	}
+	id := em.nextID
+	em.nextID++
+	em.listeners[event][id] = cb
+	return id
+}
+
+func (em *eventMachine) UnregisterListener(id uint64) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	for _, cbs := range em.listeners {
+		delete(cbs, id)
+	}
+}
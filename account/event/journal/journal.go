@@ -0,0 +1,116 @@
+// Package journal provides an event machine decorator which persists all
+// emitted events to an append-only journal in the account's store and can
+// replay them to consumers which were offline.
+package journal
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/store"
+)
+
+// Entry is a replayed journal entry handed to replay consumers.
+type Entry struct {
+	// The sequence number of the entry.
+	Sequence uint64
+	// The type of the event.
+	Event event.Event
+	// The JSON serialized event data as it was journaled.
+	Data json.RawMessage
+	// The time at which the event was originally emitted.
+	EmittedAt time.Time
+}
+
+// EventMachine is an event.EventMachine which additionally journals every
+// emitted event to the account's store before dispatching it to listeners.
+type EventMachine struct {
+	inner     event.EventMachine
+	store     store.Store
+	accountID string
+	clock     store.Clock
+}
+
+// Wrap decorates the given event machine so that every emitted event is
+// first appended to the account's journal in the store. The optional clock
+// is used to timestamp journal entries, so it should be the account's Clock;
+// it defaults to the system clock.
+func Wrap(inner event.EventMachine, st store.Store, accountID string, clock ...store.Clock) *EventMachine {
+	jem := &EventMachine{inner: inner, store: st, accountID: accountID, clock: store.SystemClock()}
+	if len(clock) > 0 && clock[0] != nil {
+		jem.clock = clock[0]
+	}
+	return jem
+}
+
+// Emit journals the given event and then dispatches it to the listeners
+// of the wrapped event machine. Events whose data can not be serialized to
+// JSON are journaled with their string representation.
+func (jem *EventMachine) Emit(data interface{}, ev event.Event) {
+	serialized, err := json.Marshal(data)
+	if err != nil {
+		if stringer, ok := data.(interface{ String() string }); ok {
+			serialized, _ = json.Marshal(stringer.String())
+		} else if asErr, ok := data.(error); ok {
+			serialized, _ = json.Marshal(asErr.Error())
+		}
+	}
+	jem.store.AppendJournalEntry(jem.accountID, &store.JournalEntry{
+		Event:     int32(ev),
+		Data:      serialized,
+		EmittedAt: jem.clock.Now().Unix(),
+	})
+	jem.inner.Emit(data, ev)
+}
+
+// RegisterListener registers the given callback on the wrapped event machine.
+func (jem *EventMachine) RegisterListener(cb event.ListenerCallback, ev event.Event) uint64 {
+	return jem.inner.RegisterListener(cb, ev)
+}
+
+// UnregisterListener removes the listener with the given id from the wrapped event machine.
+func (jem *EventMachine) UnregisterListener(id uint64) {
+	jem.inner.UnregisterListener(id)
+}
+
+// ReplayEvents calls the given consumer with every journaled entry whose
+// sequence number is at least fromSequence, in emission order, so downstream
+// consumers which were offline can catch up and audits can reconstruct the
+// exact event history.
+func (jem *EventMachine) ReplayEvents(fromSequence uint64, consume func(Entry)) error {
+	entries, err := jem.store.GetJournalEntries(jem.accountID, fromSequence)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		consume(Entry{
+			Sequence:  entry.Sequence,
+			Event:     event.Event(entry.Event),
+			Data:      json.RawMessage(entry.Data),
+			EmittedAt: time.Unix(entry.EmittedAt, 0),
+		})
+	}
+	return nil
+}
+
+// ReplayEventsSince is like ReplayEvents but selects entries by their
+// emission time instead of their sequence number.
+func (jem *EventMachine) ReplayEventsSince(since time.Time, consume func(Entry)) error {
+	entries, err := jem.store.GetJournalEntries(jem.accountID, 0)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if time.Unix(entry.EmittedAt, 0).Before(since) {
+			continue
+		}
+		consume(Entry{
+			Sequence:  entry.Sequence,
+			Event:     event.Event(entry.Event),
+			Data:      json.RawMessage(entry.Data),
+			EmittedAt: time.Unix(entry.EmittedAt, 0),
+		})
+	}
+	return nil
+}
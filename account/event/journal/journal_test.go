@@ -0,0 +1,114 @@
+package journal_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/event/journal"
+	"github.com/iotaledger/iota.go/account/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// unserializableError is an error whose channel field makes json.Marshal fail.
+type unserializableError struct {
+	Ch chan struct{}
+}
+
+func (ue *unserializableError) Error() string { return "node unavailable" }
+
+// steppingClock is a Clock advanced manually by the test.
+type steppingClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (sc *steppingClock) Now() time.Time {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.now
+}
+
+func (sc *steppingClock) advance(d time.Duration) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.now = sc.now.Add(d)
+}
+
+var _ = Describe("EventMachine", func() {
+
+	const accountID = "account"
+
+	var (
+		clock *steppingClock
+		st    store.Store
+		jem   *journal.EventMachine
+	)
+
+	BeforeEach(func() {
+		clock = &steppingClock{now: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)}
+		st = store.NewInMemoryStore(clock)
+		jem = journal.Wrap(event.NewEventMachine(), st, accountID, clock)
+	})
+
+	It("should journal emitted events and still dispatch them to listeners", func() {
+		var received interface{}
+		jem.RegisterListener(func(data interface{}) {
+			received = data
+		}, event.EventPromotion)
+
+		jem.Emit("tail", event.EventPromotion)
+		Expect(received).To(Equal("tail"))
+
+		entries, err := st.GetJournalEntries(accountID, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Event).To(Equal(int32(event.EventPromotion)))
+		Expect(string(entries[0].Data)).To(Equal(`"tail"`))
+		Expect(entries[0].EmittedAt).To(Equal(clock.Now().Unix()))
+	})
+
+	It("should journal non serializable event data through its error message", func() {
+		jem.Emit(&unserializableError{Ch: make(chan struct{})}, event.EventError)
+
+		entries, err := st.GetJournalEntries(accountID, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(string(entries[0].Data)).To(Equal(`"node unavailable"`))
+	})
+
+	It("should replay entries from a given sequence number onwards", func() {
+		jem.Emit("a", event.EventPromotion)
+		jem.Emit("b", event.EventReattachment)
+		jem.Emit("c", event.EventPromotion)
+
+		var replayed []journal.Entry
+		Expect(jem.ReplayEvents(1, func(entry journal.Entry) {
+			replayed = append(replayed, entry)
+		})).ToNot(HaveOccurred())
+
+		Expect(replayed).To(HaveLen(2))
+		Expect(replayed[0].Sequence).To(Equal(uint64(1)))
+		Expect(replayed[0].Event).To(Equal(event.EventReattachment))
+		Expect(string(replayed[0].Data)).To(Equal(`"b"`))
+		Expect(replayed[1].Sequence).To(Equal(uint64(2)))
+	})
+
+	It("should replay entries by emission time", func() {
+		jem.Emit("old", event.EventPromotion)
+		clock.advance(time.Hour)
+		cutoff := clock.Now()
+		jem.Emit("new", event.EventPromotion)
+
+		var replayed []journal.Entry
+		Expect(jem.ReplayEventsSince(cutoff, func(entry journal.Entry) {
+			replayed = append(replayed, entry)
+		})).ToNot(HaveOccurred())
+
+		Expect(replayed).To(HaveLen(1))
+		Expect(string(replayed[0].Data)).To(Equal(`"new"`))
+		Expect(replayed[0].EmittedAt.Equal(cutoff)).To(BeTrue())
+	})
+})
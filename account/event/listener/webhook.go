@@ -0,0 +1,290 @@
+// Package listener provides adapters which forward account events to
+// external consumers, such as webhook endpoints.
+package listener
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/store"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNonOKWebhookResponse gets returned when a webhook endpoint answers a delivery
+// with a non 2xx status code.
+var ErrNonOKWebhookResponse = errors.New("non ok status code in webhook response")
+
+// SignatureHeader is the HTTP header in which the hex-encoded
+// HMAC-SHA256 signature of the webhook payload is transported.
+const SignatureHeader = "X-IOTA-Account-Signature"
+
+// WebhookPayload is the JSON body POSTed to webhook endpoints.
+type WebhookPayload struct {
+	// The id of the account which emitted the event.
+	AccountID string `json:"account_id"`
+	// The type of the emitted event.
+	Event event.Event `json:"event"`
+	// The event data.
+	Data interface{} `json:"data"`
+	// The unix timestamp in seconds at which the event was emitted.
+	EmittedAt int64 `json:"emitted_at"`
+}
+
+// WebhookListenerOptions configure a WebhookListener.
+type WebhookListenerOptions struct {
+	// The webhook URLs to which events are POSTed.
+	URLs []string
+	// The key with which payloads are signed via HMAC-SHA256.
+	// If empty, payloads are not signed.
+	SecretKey []byte
+	// The events to forward. If empty, all events are forwarded.
+	Events []event.Event
+	// The maximum amount of delivery attempts per URL before the
+	// payload is dead-lettered to the store. Defaults to 3.
+	MaxAttempts uint64
+	// The wait time between delivery attempts. Defaults to 5 seconds.
+	RetryInterval time.Duration
+	// The HTTP client used for deliveries. Defaults to http.DefaultClient.
+	Client *http.Client
+	// The time source used to timestamp payloads and wait between delivery
+	// attempts, so it should be the account's Clock. Defaults to the system clock.
+	Clock account.Clock
+}
+
+// allEvents is the range of events forwarded when no explicit set is configured.
+var allEvents = []event.Event{
+	event.EventSendingTransfer, event.EventTransferConfirmed,
+	event.EventReceivingDeposit, event.EventReceivedDeposit, event.EventReceivedMessage,
+	event.EventPromotion, event.EventReattachment,
+	event.EventDepositAddressExpired, event.EventDepositAddressExpiredWithBalance,
+	event.EventError, event.EventShutdown,
+}
+
+// WebhookListener forwards account events as signed JSON payloads to
+// configured webhook URLs. Payloads are queued on emission and delivered by a
+// background worker, so a slow or unreachable endpoint never blocks the
+// emitting code or other listeners. Deliveries are retried and payloads which
+// could not be delivered within the configured attempts are dead-lettered to
+// the account's store for later redelivery via RedeliverDeadLetters().
+type WebhookListener struct {
+	accountID   string
+	store       store.Store
+	opts        WebhookListenerOptions
+	listenerIDs []uint64
+	em          event.EventMachine
+
+	mu      sync.Mutex
+	queue   []*queuedDelivery
+	pending chan struct{}
+
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// queuedDelivery is a payload waiting to be POSTed to a single URL.
+type queuedDelivery struct {
+	url     string
+	payload []byte
+}
+
+// NewWebhookListener creates a new WebhookListener and registers it for the
+// configured events on the given event machine.
+func NewWebhookListener(em event.EventMachine, accountID string, st store.Store, opts WebhookListenerOptions) *WebhookListener {
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.RetryInterval == 0 {
+		opts.RetryInterval = 5 * time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Clock == nil {
+		opts.Clock = account.SystemClock()
+	}
+	events := opts.Events
+	if len(events) == 0 {
+		events = allEvents
+	}
+	wl := &WebhookListener{
+		accountID: accountID, store: st, opts: opts, em: em,
+		pending:  make(chan struct{}, 1),
+		shutdown: make(chan struct{}),
+	}
+	for _, ev := range events {
+		func(ev event.Event) {
+			id := em.RegisterListener(func(data interface{}) {
+				wl.deliver(ev, data)
+			}, ev)
+			wl.listenerIDs = append(wl.listenerIDs, id)
+		}(ev)
+	}
+	wl.wg.Add(1)
+	go wl.deliverLoop()
+	return wl
+}
+
+// Close unregisters the listener from the event machine and stops the
+// delivery worker. Payloads still queued or in retry are dead-lettered to
+// the store so they can be redelivered later.
+func (wl *WebhookListener) Close() {
+	for _, id := range wl.listenerIDs {
+		wl.em.UnregisterListener(id)
+	}
+	close(wl.shutdown)
+	wl.wg.Wait()
+	wl.mu.Lock()
+	remaining := wl.queue
+	wl.queue = nil
+	wl.mu.Unlock()
+	for _, delivery := range remaining {
+		wl.deadLetter(delivery.url, delivery.payload, 0)
+	}
+}
+
+func (wl *WebhookListener) deliver(ev event.Event, data interface{}) {
+	payload, err := json.Marshal(WebhookPayload{
+		AccountID: wl.accountID,
+		Event:     ev,
+		Data:      data,
+		EmittedAt: wl.opts.Clock.Now().Unix(),
+	})
+	if err != nil {
+		// non marshalable event data (e.g. an error) is delivered as its string representation
+		payload, err = json.Marshal(WebhookPayload{
+			AccountID: wl.accountID,
+			Event:     ev,
+			Data:      toString(data),
+			EmittedAt: wl.opts.Clock.Now().Unix(),
+		})
+		if err != nil {
+			return
+		}
+	}
+	wl.mu.Lock()
+	for _, url := range wl.opts.URLs {
+		wl.queue = append(wl.queue, &queuedDelivery{url: url, payload: payload})
+	}
+	wl.mu.Unlock()
+	select {
+	case wl.pending <- struct{}{}:
+	default:
+	}
+}
+
+// deliverLoop drains the delivery queue in the background, so emitting code
+// never waits on HTTP requests or retry intervals.
+func (wl *WebhookListener) deliverLoop() {
+	defer wl.wg.Done()
+	for {
+		select {
+		case <-wl.pending:
+		case <-wl.shutdown:
+			return
+		}
+		for {
+			wl.mu.Lock()
+			if len(wl.queue) == 0 {
+				wl.mu.Unlock()
+				break
+			}
+			next := wl.queue[0]
+			wl.queue = wl.queue[1:]
+			wl.mu.Unlock()
+			wl.deliverTo(next.url, next.payload)
+		}
+	}
+}
+
+func (wl *WebhookListener) deliverTo(url string, payload []byte) {
+	var attempts uint64
+	for attempts = 0; attempts < wl.opts.MaxAttempts; attempts++ {
+		if attempts > 0 {
+			select {
+			case <-wl.opts.Clock.After(wl.opts.RetryInterval):
+			case <-wl.shutdown:
+				wl.deadLetter(url, payload, attempts)
+				return
+			}
+		}
+		if wl.post(url, payload) == nil {
+			return
+		}
+	}
+	// delivery failed terminally, dead-letter the payload to the store
+	wl.deadLetter(url, payload, attempts)
+}
+
+func (wl *WebhookListener) deadLetter(url string, payload []byte, attempts uint64) {
+	wl.store.AddDeadLetter(wl.accountID, &store.DeadLetter{
+		Target:      url,
+		Payload:     payload,
+		Attempts:    attempts,
+		LastAttempt: wl.opts.Clock.Now().Unix(),
+	})
+}
+
+func (wl *WebhookListener) post(url string, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(wl.opts.SecretKey) > 0 {
+		req.Header.Set(SignatureHeader, Sign(wl.opts.SecretKey, payload))
+	}
+	res, err := wl.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return ErrNonOKWebhookResponse
+	}
+	return nil
+}
+
+// RedeliverDeadLetters tries to deliver all dead-lettered payloads of the
+// account again, removing successfully delivered ones from the store.
+func (wl *WebhookListener) RedeliverDeadLetters() error {
+	letters, err := wl.store.GetDeadLetters(wl.accountID)
+	if err != nil {
+		return err
+	}
+	for index, letter := range letters {
+		if wl.post(letter.Target, letter.Payload) != nil {
+			continue
+		}
+		if err := wl.store.RemoveDeadLetter(wl.accountID, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sign computes the hex-encoded HMAC-SHA256 signature of the given payload.
+// Webhook consumers can use it to verify the authenticity of deliveries.
+func Sign(key []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func toString(data interface{}) string {
+	if err, ok := data.(error); ok {
+		return err.Error()
+	}
+	if str, ok := data.(interface{ String() string }); ok {
+		return str.String()
+	}
+	return ""
+}
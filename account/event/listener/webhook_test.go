@@ -0,0 +1,209 @@
+package listener_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/event/listener"
+	"github.com/iotaledger/iota.go/account/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// immediateClock is a Clock whose After() fires immediately,
+// so retry waits don't slow down the suite.
+type immediateClock struct{ now time.Time }
+
+func (ic *immediateClock) Now() time.Time { return ic.now }
+
+func (ic *immediateClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- ic.now.Add(d)
+	return ch
+}
+
+// webhookEndpoint is a test HTTP endpoint recording received deliveries.
+type webhookEndpoint struct {
+	mu         sync.Mutex
+	statusCode int
+	bodies     [][]byte
+	signatures []string
+}
+
+func (we *webhookEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	we.mu.Lock()
+	we.bodies = append(we.bodies, body)
+	we.signatures = append(we.signatures, r.Header.Get(listener.SignatureHeader))
+	status := we.statusCode
+	we.mu.Unlock()
+	w.WriteHeader(status)
+}
+
+func (we *webhookEndpoint) setStatusCode(code int) {
+	we.mu.Lock()
+	defer we.mu.Unlock()
+	we.statusCode = code
+}
+
+func (we *webhookEndpoint) deliveries() [][]byte {
+	we.mu.Lock()
+	defer we.mu.Unlock()
+	return append([][]byte{}, we.bodies...)
+}
+
+var _ = Describe("WebhookListener", func() {
+
+	const accountID = "account"
+	var secretKey = []byte("webhook-secret")
+
+	var (
+		endpoint *webhookEndpoint
+		server   *httptest.Server
+		clock    *immediateClock
+		st       store.Store
+		em       event.EventMachine
+	)
+
+	BeforeEach(func() {
+		endpoint = &webhookEndpoint{statusCode: http.StatusOK}
+		server = httptest.NewServer(endpoint)
+		clock = &immediateClock{now: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)}
+		st = store.NewInMemoryStore()
+		em = event.NewEventMachine()
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newListener := func(opts listener.WebhookListenerOptions) *listener.WebhookListener {
+		opts.URLs = []string{server.URL}
+		opts.Clock = clock
+		return listener.NewWebhookListener(em, accountID, st, opts)
+	}
+
+	It("should deliver emitted events as signed payloads", func() {
+		wl := newListener(listener.WebhookListenerOptions{
+			SecretKey: secretKey,
+			Events:    []event.Event{event.EventPromotion},
+		})
+		defer wl.Close()
+
+		em.Emit("tail", event.EventPromotion)
+
+		Eventually(endpoint.deliveries).Should(HaveLen(1))
+		deliveries := endpoint.deliveries()
+
+		var payload listener.WebhookPayload
+		Expect(json.Unmarshal(deliveries[0], &payload)).ToNot(HaveOccurred())
+		Expect(payload.AccountID).To(Equal(accountID))
+		Expect(payload.Event).To(Equal(event.EventPromotion))
+		Expect(payload.Data).To(Equal("tail"))
+		Expect(payload.EmittedAt).To(Equal(clock.Now().Unix()))
+
+		// the signature must verify against the payload as it was transported
+		Expect(endpoint.signatures[0]).To(Equal(listener.Sign(secretKey, deliveries[0])))
+	})
+
+	It("should not deliver events it is not configured for", func() {
+		wl := newListener(listener.WebhookListenerOptions{
+			Events: []event.Event{event.EventPromotion},
+		})
+		defer wl.Close()
+
+		em.Emit("tail", event.EventReattachment)
+		Consistently(endpoint.deliveries).Should(BeEmpty())
+	})
+
+	It("should stop delivering after Close()", func() {
+		wl := newListener(listener.WebhookListenerOptions{
+			Events: []event.Event{event.EventPromotion},
+		})
+		wl.Close()
+
+		em.Emit("tail", event.EventPromotion)
+		Consistently(endpoint.deliveries).Should(BeEmpty())
+	})
+
+	It("should not block event emission on a stalled endpoint", func() {
+		release := make(chan struct{})
+		stalled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer stalled.Close()
+
+		wl := listener.NewWebhookListener(em, accountID, st, listener.WebhookListenerOptions{
+			URLs:   []string{stalled.URL},
+			Clock:  clock,
+			Events: []event.Event{event.EventPromotion},
+		})
+		defer wl.Close()
+		defer close(release)
+
+		emitted := make(chan struct{})
+		go func() {
+			em.Emit("tail", event.EventPromotion)
+			close(emitted)
+		}()
+		Eventually(emitted).Should(BeClosed())
+	})
+
+	It("should retry failed deliveries and dead-letter them after the last attempt", func() {
+		endpoint.setStatusCode(http.StatusInternalServerError)
+		wl := newListener(listener.WebhookListenerOptions{
+			Events:      []event.Event{event.EventPromotion},
+			MaxAttempts: 2,
+		})
+		defer wl.Close()
+
+		em.Emit("tail", event.EventPromotion)
+		Eventually(endpoint.deliveries).Should(HaveLen(2))
+
+		Eventually(func() int {
+			letters, err := st.GetDeadLetters(accountID)
+			Expect(err).ToNot(HaveOccurred())
+			return len(letters)
+		}).Should(Equal(1))
+		letters, err := st.GetDeadLetters(accountID)
+		Expect(err).ToNot(HaveOccurred())
+		for _, letter := range letters {
+			Expect(letter.Target).To(Equal(server.URL))
+			Expect(letter.Attempts).To(Equal(uint64(2)))
+			Expect(letter.LastAttempt).To(Equal(clock.Now().Unix()))
+		}
+	})
+
+	It("should redeliver dead letters and remove delivered ones", func() {
+		endpoint.setStatusCode(http.StatusInternalServerError)
+		wl := newListener(listener.WebhookListenerOptions{
+			Events:      []event.Event{event.EventPromotion},
+			MaxAttempts: 1,
+		})
+		defer wl.Close()
+
+		em.Emit("tail", event.EventPromotion)
+		Eventually(func() int {
+			letters, err := st.GetDeadLetters(accountID)
+			Expect(err).ToNot(HaveOccurred())
+			return len(letters)
+		}).Should(Equal(1))
+
+		// the endpoint recovered, the redelivery must succeed and clean up
+		endpoint.setStatusCode(http.StatusOK)
+		Expect(wl.RedeliverDeadLetters()).ToNot(HaveOccurred())
+		letters, err := st.GetDeadLetters(accountID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(letters).To(BeEmpty())
+
+		deliveries := endpoint.deliveries()
+		Expect(deliveries).To(HaveLen(2))
+		Expect(deliveries[1]).To(Equal(deliveries[0]))
+	})
+})
@@ -0,0 +1,189 @@
+package account_test
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var (
+	testSeed      = Trytes(strings.Repeat("N", 81))
+	testRecipient = Trytes(strings.Repeat("R", 81))
+	testTrunk     = Trytes(strings.Repeat("T", 81))
+	testBranch    = Trytes(strings.Repeat("B", 81))
+)
+
+// fakeClock is a Clock which only moves when the test advances it.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+// After fires immediately, so components waiting on retry or poll
+// intervals proceed without real delays.
+func (fc *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- fc.Now().Add(d)
+	return ch
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = fc.now.Add(d)
+}
+
+// fakeProvider answers the IRI commands issued during the account tests.
+type fakeProvider struct {
+	mu sync.Mutex
+	// the balance reported for every address of a getBalances call
+	balancePerAddress uint64
+	// if set, commands fail with this error
+	sendErr error
+	// if set together with sendErr, only this command fails
+	failCmd api.IRICommand
+	// the names of the commands sent, in order
+	commands []api.IRICommand
+}
+
+func (fp *fakeProvider) SetSettings(settings interface{}) error { return nil }
+
+func (fp *fakeProvider) Send(cmd interface{}, out interface{}) error {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	var name api.IRICommand
+	switch cmd.(type) {
+	case *api.GetTransactionsToApproveCommand:
+		name = api.GetTransactionsToApproveCmd
+	case *api.StoreTransactionsCommand:
+		name = api.StoreTransactionsCmd
+	case *api.BroadcastTransactionsCommand:
+		name = api.BroadcastTransactionsCmd
+	case *api.GetBalancesCommand:
+		name = api.GetBalancesCmd
+	case *api.FindTransactionsCommand:
+		name = api.FindTransactionsCmd
+	case *api.WereAddressesSpentFromCommand:
+		name = api.WereAddressesSpentFromCmd
+	default:
+		return errors.Errorf("fake provider: unexpected command %T", cmd)
+	}
+	fp.commands = append(fp.commands, name)
+	if fp.sendErr != nil && (fp.failCmd == "" || fp.failCmd == name) {
+		return fp.sendErr
+	}
+	switch c := cmd.(type) {
+	case *api.GetTransactionsToApproveCommand:
+		rsp := out.(*api.GetTransactionsToApproveResponse)
+		rsp.TrunkTransaction = testTrunk
+		rsp.BranchTransaction = testBranch
+	case *api.GetBalancesCommand:
+		rsp := out.(*api.GetBalancesResponse)
+		rsp.Balances = make([]string, len(c.Addresses))
+		for i := range rsp.Balances {
+			rsp.Balances[i] = strconv.FormatUint(fp.balancePerAddress, 10)
+		}
+	case *api.FindTransactionsCommand:
+		// no transactions: every address looks unused
+		out.(*api.FindTransactionsResponse).Hashes = Hashes{}
+	case *api.WereAddressesSpentFromCommand:
+		rsp := out.(*api.WereAddressesSpentFromResponse)
+		rsp.States = make([]bool, len(c.Addresses))
+	}
+	return nil
+}
+
+func (fp *fakeProvider) setSendErr(err error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.sendErr = err
+}
+
+// failOnly lets only the given command fail with the given error.
+func (fp *fakeProvider) failOnly(cmd api.IRICommand, err error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	fp.sendErr = err
+	fp.failCmd = cmd
+}
+
+func (fp *fakeProvider) sentCommands() []api.IRICommand {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return append([]api.IRICommand{}, fp.commands...)
+}
+
+// testEnv bundles an account with the fakes backing it.
+type testEnv struct {
+	acc   *account.Account
+	prov  *fakeProvider
+	store store.Store
+	clock *fakeClock
+	setts *account.Settings
+}
+
+// newTestEnv creates a started account backed by a fake provider, an
+// in-memory store and a fake clock. The local Proof-of-Work function is
+// stubbed out, so sends complete instantly.
+func newTestEnv(tweaks ...func(setts *account.Settings)) *testEnv {
+	env := newTestEnvUnstarted(tweaks...)
+	Expect(env.acc.Start()).ToNot(HaveOccurred())
+	return env
+}
+
+// newTestEnvUnstarted is newTestEnv without starting the account.
+func newTestEnvUnstarted(tweaks ...func(setts *account.Settings)) *testEnv {
+	prov := &fakeProvider{}
+	a, err := api.ComposeAPI(api.HTTPClientSettings{
+		LocalProofOfWorkFunc: func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+			return strings.Repeat("9", NonceTrinarySize/3), nil
+		},
+	}, func(settings interface{}) (api.Provider, error) {
+		return prov, nil
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	clock := newFakeClock()
+	env := &testEnv{
+		prov:  prov,
+		store: store.NewInMemoryStore(clock),
+		clock: clock,
+	}
+	env.setts = &account.Settings{
+		API:           a,
+		Store:         env.store,
+		SeedProv:      account.NewInMemorySeedProvider(testSeed),
+		MWM:           1,
+		Depth:         3,
+		SecurityLevel: SecurityLevelLow,
+		EventMachine:  event.NewEventMachine(),
+		Clock:         clock,
+	}
+	for _, tweak := range tweaks {
+		tweak(env.setts)
+	}
+	env.acc, err = account.NewAccount(env.setts)
+	Expect(err).ToNot(HaveOccurred())
+	return env
+}
@@ -0,0 +1,174 @@
+package account
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// PendingTransferInfo describes a pending transfer of the account.
+type PendingTransferInfo struct {
+	// The hash of the origin tail transaction of the transfer.
+	OriginTailTxHash Hash `json:"origin_tail_tx_hash"`
+	// The amount of attachments (origin plus reattachments) of the transfer.
+	Attachments int `json:"attachments"`
+	// The amount of promotions done for the transfer.
+	Promotions uint64 `json:"promotions"`
+	// The age of the transfer since its origin attachment.
+	Age time.Duration `json:"age"`
+}
+
+// ErrorInfo describes an error which occurred within the account or one of its plugins.
+type ErrorInfo struct {
+	// The error which occurred.
+	Error error `json:"error"`
+	// The time at which the error occurred.
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Snapshot is a point-in-time view of the account's internal state,
+// meant for monitoring and debugging.
+type Snapshot struct {
+	// The id of the account.
+	ID string `json:"id"`
+	// Whether the account is currently running.
+	Running bool `json:"running"`
+	// The next key index used for deriving deposit addresses.
+	KeyIndex uint64 `json:"key_index"`
+	// The amount of deposit addresses being watched.
+	WatchedDepositAddresses int `json:"watched_deposit_addresses"`
+	// The pending transfers of the account with their ages.
+	PendingTransfers []PendingTransferInfo `json:"pending_transfers"`
+	// The amount of dead-lettered event payloads in the store.
+	DeadLetters int `json:"dead_letters"`
+	// The most recent errors emitted by the account, newest last.
+	LastErrors []ErrorInfo `json:"last_errors"`
+	// The time at which each event type was last emitted.
+	LastEventTimes map[event.Event]time.Time `json:"last_event_times"`
+}
+
+// maxTrackedErrors is the amount of recent errors kept for introspection.
+const maxTrackedErrors = 10
+
+// tracker records recent errors and last event times for introspection.
+type tracker struct {
+	mu             sync.Mutex
+	lastErrors     []ErrorInfo
+	lastEventTimes map[event.Event]time.Time
+}
+
+// trackEvents registers internal listeners recording error and event
+// timestamps for Snapshot().
+func (acc *Account) trackEvents() {
+	acc.track = &tracker{lastEventTimes: map[event.Event]time.Time{}}
+	for ev := event.EventSendingTransfer; ev <= event.EventShutdown; ev++ {
+		func(ev event.Event) {
+			acc.setts.EventMachine.RegisterListener(func(data interface{}) {
+				acc.track.record(ev, data, acc.setts.Clock.Now())
+			}, ev)
+		}(ev)
+	}
+}
+
+func (t *tracker) record(ev event.Event, data interface{}, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastEventTimes[ev] = now
+	if ev != event.EventError {
+		return
+	}
+	err, ok := data.(error)
+	if !ok {
+		return
+	}
+	t.lastErrors = append(t.lastErrors, ErrorInfo{Error: err, OccurredAt: now})
+	if len(t.lastErrors) > maxTrackedErrors {
+		t.lastErrors = t.lastErrors[len(t.lastErrors)-maxTrackedErrors:]
+	}
+}
+
+// Snapshot returns a point-in-time view of the account's internal state:
+// pending transfers with their ages, the amount of watched deposit addresses,
+// recent errors and the last time each event type was emitted.
+func (acc *Account) Snapshot() (*Snapshot, error) {
+	state, err := acc.setts.Store.LoadAccount(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	depositAddresses, err := acc.setts.Store.GetDepositAddresses(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	pendingTransfers, err := acc.setts.Store.GetPendingTransfers(acc.id)
+	if err != nil {
+		return nil, err
+	}
+	deadLetters, err := acc.setts.Store.GetDeadLetters(acc.id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := acc.setts.Clock.Now()
+	snapshot := &Snapshot{
+		ID:                      acc.id,
+		Running:                 acc.isRunning(),
+		KeyIndex:                state.KeyIndex,
+		WatchedDepositAddresses: len(depositAddresses),
+		PendingTransfers:        make([]PendingTransferInfo, 0, len(pendingTransfers)),
+		DeadLetters:             len(deadLetters),
+		LastEventTimes:          map[event.Event]time.Time{},
+	}
+	for originTailTxHash, pendingTransfer := range pendingTransfers {
+		info := PendingTransferInfo{
+			OriginTailTxHash: originTailTxHash,
+			Attachments:      len(pendingTransfer.Tails),
+			Promotions:       pendingTransfer.Promotions,
+		}
+		if txs, err := transaction.AsTransactionObjects(pendingTransfer.Bundle, nil); err == nil {
+			info.Age = now.Sub(time.Unix(int64(txs[0].Timestamp), 0))
+		}
+		snapshot.PendingTransfers = append(snapshot.PendingTransfers, info)
+	}
+
+	acc.track.mu.Lock()
+	snapshot.LastErrors = append(snapshot.LastErrors, acc.track.lastErrors...)
+	for ev, at := range acc.track.lastEventTimes {
+		snapshot.LastEventTimes[ev] = at
+	}
+	acc.track.mu.Unlock()
+	return snapshot, nil
+}
+
+// WriteMetrics writes the account's current metrics to the given writer in
+// the Prometheus text exposition format, so the account can be wired into a
+// Prometheus scrape endpoint without a dependency on a client library.
+func (acc *Account) WriteMetrics(w io.Writer) error {
+	snapshot, err := acc.Snapshot()
+	if err != nil {
+		return err
+	}
+	running := 0
+	if snapshot.Running {
+		running = 1
+	}
+	labels := fmt.Sprintf(`{account=%q}`, snapshot.ID)
+	_, err = fmt.Fprintf(w,
+		"account_running%s %d\n"+
+			"account_key_index%s %d\n"+
+			"account_watched_deposit_addresses%s %d\n"+
+			"account_pending_transfers%s %d\n"+
+			"account_dead_letters%s %d\n"+
+			"account_recent_errors%s %d\n",
+		labels, running,
+		labels, snapshot.KeyIndex,
+		labels, snapshot.WatchedDepositAddresses,
+		labels, len(snapshot.PendingTransfers),
+		labels, snapshot.DeadLetters,
+		labels, len(snapshot.LastErrors))
+	return err
+}
@@ -0,0 +1,65 @@
+package account_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("introspection", func() {
+
+	It("should snapshot the account's state, recent errors and event times", func() {
+		env := newTestEnv()
+
+		_, err := env.acc.NewDepositAddress(&deposit.Conditions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient})
+		Expect(err).ToNot(HaveOccurred())
+		env.setts.EventMachine.Emit(errors.New("node unavailable"), event.EventError)
+
+		snapshot, err := env.acc.Snapshot()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.ID).To(Equal(env.acc.ID()))
+		Expect(snapshot.Running).To(BeTrue())
+		Expect(snapshot.KeyIndex).To(Equal(uint64(1)))
+		Expect(snapshot.WatchedDepositAddresses).To(Equal(1))
+		Expect(snapshot.PendingTransfers).To(HaveLen(1))
+		Expect(snapshot.PendingTransfers[0].Attachments).To(Equal(1))
+		Expect(snapshot.DeadLetters).To(BeZero())
+		Expect(snapshot.LastErrors).To(HaveLen(1))
+		Expect(snapshot.LastErrors[0].Error.Error()).To(Equal("node unavailable"))
+		Expect(snapshot.LastEventTimes).To(HaveKey(event.EventSendingTransfer))
+	})
+
+	It("should only keep the most recent errors", func() {
+		env := newTestEnv()
+		for i := 0; i < 15; i++ {
+			env.setts.EventMachine.Emit(errors.Errorf("error %d", i), event.EventError)
+		}
+		snapshot, err := env.acc.Snapshot()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.LastErrors).To(HaveLen(10))
+		Expect(snapshot.LastErrors[0].Error.Error()).To(Equal("error 5"))
+		Expect(snapshot.LastErrors[9].Error.Error()).To(Equal("error 14"))
+	})
+
+	It("should write metrics in the Prometheus text format", func() {
+		env := newTestEnv()
+		_, err := env.acc.NewDepositAddress(&deposit.Conditions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		var out bytes.Buffer
+		Expect(env.acc.WriteMetrics(&out)).ToNot(HaveOccurred())
+		labels := fmt.Sprintf("{account=%q}", env.acc.ID())
+		Expect(out.String()).To(ContainSubstring("account_running" + labels + " 1\n"))
+		Expect(out.String()).To(ContainSubstring("account_key_index" + labels + " 1\n"))
+		Expect(out.String()).To(ContainSubstring("account_watched_deposit_addresses" + labels + " 1\n"))
+	})
+})
@@ -0,0 +1,199 @@
+// Package manager provides a Manager which runs many accounts over a shared
+// Provider, Proof-of-Work configuration and Store, with per-account lifecycle
+// control and aggregated events.
+package manager
+
+import (
+	"sync"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrAccountNotManaged gets returned when an operation addresses an account unknown to the manager.
+	ErrAccountNotManaged = errors.New("account is not managed by this manager")
+	// ErrAccountAlreadyManaged gets returned when an account with the same id is added twice.
+	ErrAccountAlreadyManaged = errors.New("account is already managed by this manager")
+)
+
+// AccountEvent is the envelope in which the manager re-emits events
+// of its managed accounts on the aggregated event machine.
+type AccountEvent struct {
+	// The id of the account which emitted the event.
+	AccountID string
+	// The original event type.
+	Event event.Event
+	// The original event data.
+	Data interface{}
+}
+
+// Manager runs multiple accounts over shared settings. All accounts created
+// through the manager use the manager's API, Store and Proof-of-Work settings
+// but are otherwise isolated from each other: each account keeps its own state
+// (keyed by its id) and its own event machine, whose events are additionally
+// re-emitted as AccountEvents on the manager's aggregated event machine.
+type Manager struct {
+	shared *account.Settings
+	em     event.EventMachine
+
+	mu       sync.Mutex
+	accounts map[string]*managedAccount
+}
+
+type managedAccount struct {
+	acc     *account.Account
+	running bool
+}
+
+// NewManager creates a new Manager using the given settings as the template
+// for all accounts added to it. The EventMachine of the given settings is
+// used as the aggregated event machine.
+func NewManager(shared *account.Settings) *Manager {
+	if shared.EventMachine == nil {
+		shared.EventMachine = event.NewEventMachine()
+	}
+	return &Manager{
+		shared:   shared,
+		em:       shared.EventMachine,
+		accounts: map[string]*managedAccount{},
+	}
+}
+
+// EventMachine returns the aggregated event machine on which the manager
+// re-emits all events of its accounts as AccountEvents.
+func (m *Manager) EventMachine() event.EventMachine {
+	return m.em
+}
+
+// AddAccount creates a new account for the given seed provider, registers it
+// with the manager and returns the account's id. The account is not started.
+// The optionally given plugins are registered on the new account; plugin
+// instances must not be shared between accounts.
+func (m *Manager) AddAccount(seedProv account.SeedProvider, plugins ...account.Plugin) (string, error) {
+	setts := *m.shared
+	setts.SeedProv = seedProv
+	setts.EventMachine = event.NewEventMachine()
+	setts.Plugins = plugins
+	acc, err := account.NewAccount(&setts)
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.accounts[acc.ID()]; ok {
+		return "", ErrAccountAlreadyManaged
+	}
+	m.accounts[acc.ID()] = &managedAccount{acc: acc}
+	m.forwardEvents(acc)
+	return acc.ID(), nil
+}
+
+// forwardEvents re-emits all events of the given account on the aggregated event machine.
+func (m *Manager) forwardEvents(acc *account.Account) {
+	accEM := acc.Settings().EventMachine
+	for ev := event.EventSendingTransfer; ev <= event.EventShutdown; ev++ {
+		func(ev event.Event) {
+			accEM.RegisterListener(func(data interface{}) {
+				m.em.Emit(AccountEvent{AccountID: acc.ID(), Event: ev, Data: data}, ev)
+			}, ev)
+		}(ev)
+	}
+}
+
+// Account returns the managed account with the given id.
+func (m *Manager) Account(id string) (*account.Account, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	managed, ok := m.accounts[id]
+	if !ok {
+		return nil, ErrAccountNotManaged
+	}
+	return managed.acc, nil
+}
+
+// IDs returns the ids of all managed accounts.
+func (m *Manager) IDs() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]string, 0, len(m.accounts))
+	for id := range m.accounts {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// StartAccount starts the managed account with the given id.
+func (m *Manager) StartAccount(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	managed, ok := m.accounts[id]
+	if !ok {
+		return ErrAccountNotManaged
+	}
+	if managed.running {
+		return nil
+	}
+	if err := managed.acc.Start(); err != nil {
+		return err
+	}
+	managed.running = true
+	return nil
+}
+
+// StopAccount shuts the managed account with the given id down
+// but keeps it registered, so it can be started again.
+func (m *Manager) StopAccount(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	managed, ok := m.accounts[id]
+	if !ok {
+		return ErrAccountNotManaged
+	}
+	if !managed.running {
+		return nil
+	}
+	if err := managed.acc.Shutdown(); err != nil {
+		return err
+	}
+	managed.running = false
+	return nil
+}
+
+// RemoveAccount stops the managed account with the given id and removes it
+// from the manager. The account's state in the store is left untouched.
+func (m *Manager) RemoveAccount(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	managed, ok := m.accounts[id]
+	if !ok {
+		return ErrAccountNotManaged
+	}
+	if managed.running {
+		if err := managed.acc.Shutdown(); err != nil {
+			return err
+		}
+	}
+	delete(m.accounts, id)
+	return nil
+}
+
+// Shutdown stops all running accounts managed by this manager.
+func (m *Manager) Shutdown() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var firstErr error
+	for _, managed := range m.accounts {
+		if !managed.running {
+			continue
+		}
+		if err := managed.acc.Shutdown(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		managed.running = false
+	}
+	return firstErr
+}
@@ -0,0 +1,133 @@
+package manager_test
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/manager"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var (
+	seedA = account.NewInMemorySeedProvider(Trytes(strings.Repeat("A", 81)))
+	seedB = account.NewInMemorySeedProvider(Trytes(strings.Repeat("B", 81)))
+)
+
+// nullProvider fails every command; the manager specs never hit the network.
+type nullProvider struct{}
+
+func (nullProvider) SetSettings(settings interface{}) error { return nil }
+
+func (nullProvider) Send(cmd interface{}, out interface{}) error {
+	return errors.Errorf("null provider: unexpected command %T", cmd)
+}
+
+func newManager() *manager.Manager {
+	a, err := api.ComposeAPI(api.HTTPClientSettings{}, func(settings interface{}) (api.Provider, error) {
+		return nullProvider{}, nil
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return manager.NewManager(&account.Settings{
+		API:           a,
+		Store:         store.NewInMemoryStore(),
+		MWM:           1,
+		Depth:         3,
+		SecurityLevel: SecurityLevelLow,
+	})
+}
+
+var _ = Describe("Manager", func() {
+
+	It("should manage accounts by their id and reject duplicates", func() {
+		mngr := newManager()
+		idA, err := mngr.AddAccount(seedA)
+		Expect(err).ToNot(HaveOccurred())
+		idB, err := mngr.AddAccount(seedB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(idA).ToNot(Equal(idB))
+		Expect(mngr.IDs()).To(ConsistOf(idA, idB))
+
+		acc, err := mngr.Account(idA)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(acc.ID()).To(Equal(idA))
+
+		_, err = mngr.AddAccount(seedA)
+		Expect(err).To(Equal(manager.ErrAccountAlreadyManaged))
+	})
+
+	It("should return ErrAccountNotManaged for unknown account ids", func() {
+		mngr := newManager()
+		_, err := mngr.Account("unknown")
+		Expect(err).To(Equal(manager.ErrAccountNotManaged))
+		Expect(mngr.StartAccount("unknown")).To(Equal(manager.ErrAccountNotManaged))
+		Expect(mngr.StopAccount("unknown")).To(Equal(manager.ErrAccountNotManaged))
+		Expect(mngr.RemoveAccount("unknown")).To(Equal(manager.ErrAccountNotManaged))
+	})
+
+	It("should control the lifecycle of managed accounts idempotently", func() {
+		mngr := newManager()
+		id, err := mngr.AddAccount(seedA)
+		Expect(err).ToNot(HaveOccurred())
+		acc, err := mngr.Account(id)
+		Expect(err).ToNot(HaveOccurred())
+
+		// the account is registered but not started yet
+		_, err = mngr.Account(id)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(acc.Shutdown()).To(Equal(account.ErrAccountNotRunning))
+
+		Expect(mngr.StartAccount(id)).ToNot(HaveOccurred())
+		Expect(mngr.StartAccount(id)).ToNot(HaveOccurred())
+		Expect(mngr.StopAccount(id)).ToNot(HaveOccurred())
+		Expect(mngr.StopAccount(id)).ToNot(HaveOccurred())
+		Expect(acc.Shutdown()).To(Equal(account.ErrAccountNotRunning))
+	})
+
+	It("should re-emit account events as AccountEvents on the aggregated event machine", func() {
+		mngr := newManager()
+		id, err := mngr.AddAccount(seedA)
+		Expect(err).ToNot(HaveOccurred())
+		acc, err := mngr.Account(id)
+		Expect(err).ToNot(HaveOccurred())
+
+		var aggregated manager.AccountEvent
+		mngr.EventMachine().RegisterListener(func(data interface{}) {
+			aggregated = data.(manager.AccountEvent)
+		}, event.EventPromotion)
+
+		acc.Settings().EventMachine.Emit("tail", event.EventPromotion)
+		Expect(aggregated.AccountID).To(Equal(id))
+		Expect(aggregated.Event).To(Equal(event.EventPromotion))
+		Expect(aggregated.Data).To(Equal("tail"))
+	})
+
+	It("should stop removed and shut down accounts", func() {
+		mngr := newManager()
+		idA, err := mngr.AddAccount(seedA)
+		Expect(err).ToNot(HaveOccurred())
+		idB, err := mngr.AddAccount(seedB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mngr.StartAccount(idA)).ToNot(HaveOccurred())
+		Expect(mngr.StartAccount(idB)).ToNot(HaveOccurred())
+
+		accA, err := mngr.Account(idA)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mngr.RemoveAccount(idA)).ToNot(HaveOccurred())
+		_, err = mngr.Account(idA)
+		Expect(err).To(Equal(manager.ErrAccountNotManaged))
+		Expect(accA.Shutdown()).To(Equal(account.ErrAccountNotRunning))
+
+		accB, err := mngr.Account(idB)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(mngr.Shutdown()).ToNot(HaveOccurred())
+		Expect(accB.Shutdown()).To(Equal(account.ErrAccountNotRunning))
+	})
+})
@@ -0,0 +1,116 @@
+// Package cdachecker provides a plugin which manages the lifecycle of the
+// account's conditional deposit addresses: expired addresses are removed from
+// the set of watched addresses and expired addresses still holding balance
+// are surfaced as events requiring sweeping.
+package cdachecker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// ExpiredDeposit is the event data emitted with
+// EventDepositAddressExpiredWithBalance.
+type ExpiredDeposit struct {
+	// The conditions of the expired deposit address.
+	Conditions *deposit.Conditions
+	// The balance residing on the expired deposit address.
+	Balance uint64
+}
+
+// CDAChecker is a plugin which periodically checks the account's conditional
+// deposit addresses for expiry. Expired addresses without balance are removed
+// from the store and reported via EventDepositAddressExpired; expired
+// addresses which still hold balance are kept in the store and reported via
+// EventDepositAddressExpiredWithBalance until they are swept.
+type CDAChecker struct {
+	acc      *account.Account
+	interval time.Duration
+
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewCDAChecker creates a new CDAChecker checking in the given interval.
+func NewCDAChecker(interval time.Duration) *CDAChecker {
+	return &CDAChecker{interval: interval, shutdown: make(chan struct{})}
+}
+
+// Name returns the name of the plugin.
+func (c *CDAChecker) Name() string {
+	return "cda-checker"
+}
+
+// Start starts the checker's background loop for the given account.
+func (c *CDAChecker) Start(acc *account.Account) error {
+	c.acc = acc
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		clock := c.acc.Settings().Clock
+		for {
+			select {
+			case <-clock.After(c.interval):
+			case <-c.shutdown:
+				return
+			}
+			if err := c.check(); err != nil {
+				c.acc.Settings().EventMachine.Emit(err, event.EventError)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the checker's background loop.
+func (c *CDAChecker) Shutdown() error {
+	close(c.shutdown)
+	c.wg.Wait()
+	return nil
+}
+
+func (c *CDAChecker) check() error {
+	setts := c.acc.Settings()
+	depositAddresses, err := setts.Store.GetDepositAddresses(c.acc.ID())
+	if err != nil {
+		return err
+	}
+
+	now := setts.Clock.Now()
+	for index, cda := range depositAddresses {
+		if !cda.Expired(now) {
+			continue
+		}
+		if err := c.checkExpired(index, cda); err != nil {
+			setts.EventMachine.Emit(err, event.EventError)
+		}
+	}
+	return nil
+}
+
+// checkExpired handles a single expired deposit address: it is removed from
+// the store if it holds no balance, otherwise a sweep-required event is emitted.
+func (c *CDAChecker) checkExpired(index uint64, cda *deposit.Conditions) error {
+	setts := c.acc.Settings()
+	balances, err := setts.API.GetBalances(Hashes{cda.Address}, 100)
+	if err != nil {
+		return err
+	}
+	if len(balances.Balances) > 0 && balances.Balances[0] > 0 {
+		setts.EventMachine.Emit(ExpiredDeposit{
+			Conditions: cda,
+			Balance:    balances.Balances[0],
+		}, event.EventDepositAddressExpiredWithBalance)
+		return nil
+	}
+	if err := setts.Store.RemoveDepositAddress(c.acc.ID(), index); err != nil {
+		return err
+	}
+	setts.EventMachine.Emit(cda, event.EventDepositAddressExpired)
+	return nil
+}
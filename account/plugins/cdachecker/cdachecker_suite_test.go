@@ -0,0 +1,13 @@
+package cdachecker_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCDAChecker(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CDAChecker Suite")
+}
@@ -0,0 +1,135 @@
+package cdachecker_test
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/plugins/cdachecker"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var (
+	testSeed     = Trytes(strings.Repeat("N", 81))
+	addrExpired  = Trytes(strings.Repeat("E", 81))
+	addrUnswept  = Trytes(strings.Repeat("U", 81))
+	addrWatching = Trytes(strings.Repeat("W", 81))
+)
+
+// tickClock is a Clock whose After() only fires when the test ticks it.
+type tickClock struct {
+	now   time.Time
+	ticks chan time.Time
+}
+
+func newTickClock() *tickClock {
+	return &tickClock{
+		now:   time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+		ticks: make(chan time.Time),
+	}
+}
+
+func (tc *tickClock) Now() time.Time { return tc.now }
+
+func (tc *tickClock) After(d time.Duration) <-chan time.Time { return tc.ticks }
+
+// tick lets the next pending After() fire.
+func (tc *tickClock) tick() { tc.ticks <- tc.now }
+
+// balanceProvider answers getBalances calls from a fixed balance table.
+type balanceProvider struct {
+	mu       sync.Mutex
+	balances map[Trytes]uint64
+}
+
+func (bp *balanceProvider) SetSettings(settings interface{}) error { return nil }
+
+func (bp *balanceProvider) Send(cmd interface{}, out interface{}) error {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+	c, ok := cmd.(*api.GetBalancesCommand)
+	if !ok {
+		return errors.Errorf("balance provider: unexpected command %T", cmd)
+	}
+	rsp := out.(*api.GetBalancesResponse)
+	rsp.Balances = make([]string, len(c.Addresses))
+	for i, addr := range c.Addresses {
+		rsp.Balances[i] = strconv.FormatUint(bp.balances[addr], 10)
+	}
+	return nil
+}
+
+var _ = Describe("CDAChecker", func() {
+
+	It("should remove expired deposit addresses and flag unswept balances", func() {
+		prov := &balanceProvider{balances: map[Trytes]uint64{addrUnswept: 50}}
+		a, err := api.ComposeAPI(api.HTTPClientSettings{}, func(settings interface{}) (api.Provider, error) {
+			return prov, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		clock := newTickClock()
+		st := store.NewInMemoryStore()
+		em := event.NewEventMachine()
+		checker := cdachecker.NewCDAChecker(time.Minute)
+		acc, err := account.NewAccount(&account.Settings{
+			API:           a,
+			Store:         st,
+			SeedProv:      account.NewInMemorySeedProvider(testSeed),
+			MWM:           1,
+			Depth:         3,
+			SecurityLevel: SecurityLevelLow,
+			EventMachine:  em,
+			Clock:         clock,
+			Plugins:       []account.Plugin{checker},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		past := clock.Now().Add(-time.Hour)
+		future := clock.Now().Add(time.Hour)
+		Expect(st.AddDepositAddress(acc.ID(), 0, &deposit.Conditions{Address: addrExpired, TimeoutAt: &past})).ToNot(HaveOccurred())
+		Expect(st.AddDepositAddress(acc.ID(), 1, &deposit.Conditions{Address: addrUnswept, TimeoutAt: &past})).ToNot(HaveOccurred())
+		Expect(st.AddDepositAddress(acc.ID(), 2, &deposit.Conditions{Address: addrWatching, TimeoutAt: &future})).ToNot(HaveOccurred())
+
+		expired := make(chan interface{}, 1)
+		em.RegisterListener(func(data interface{}) {
+			expired <- data
+		}, event.EventDepositAddressExpired)
+		unswept := make(chan interface{}, 1)
+		em.RegisterListener(func(data interface{}) {
+			unswept <- data
+		}, event.EventDepositAddressExpiredWithBalance)
+
+		Expect(acc.Start()).ToNot(HaveOccurred())
+		defer acc.Shutdown()
+		clock.tick()
+
+		var expiredData interface{}
+		Eventually(expired).Should(Receive(&expiredData))
+		Expect(expiredData.(*deposit.Conditions).Address).To(Equal(addrExpired))
+
+		var unsweptData interface{}
+		Eventually(unswept).Should(Receive(&unsweptData))
+		expiredDeposit := unsweptData.(cdachecker.ExpiredDeposit)
+		Expect(expiredDeposit.Conditions.Address).To(Equal(addrUnswept))
+		Expect(expiredDeposit.Balance).To(Equal(uint64(50)))
+
+		// the empty expired address is gone, the unswept and unexpired ones stay watched
+		cdas, err := st.GetDepositAddresses(acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cdas).ToNot(HaveKey(uint64(0)))
+		Expect(cdas).To(HaveKey(uint64(1)))
+		Expect(cdas).To(HaveKey(uint64(2)))
+	})
+})
@@ -0,0 +1,205 @@
+// Package poller provides a plugin which periodically checks the account's
+// deposit addresses for incoming transfers and feeds the account's event
+// machine with deposit events.
+package poller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// ReceiveFilter is a predicate deciding whether a bundle received on the given
+// conditional deposit address should be reported as a deposit event.
+type ReceiveFilter func(bndl bundle.Bundle, cda *deposit.Conditions) bool
+
+// FilterByTag returns a ReceiveFilter which only lets bundles pass
+// whose tail transaction carries one of the given (padded) tags.
+func FilterByTag(tags ...Trytes) ReceiveFilter {
+	padded := make([]Trytes, len(tags))
+	for i, tag := range tags {
+		padded[i] = bundle.PadTag(tag)
+	}
+	return func(bndl bundle.Bundle, cda *deposit.Conditions) bool {
+		tailTx := bundle.TailTransactionHash(bndl)
+		for i := range bndl {
+			if bndl[i].Hash != tailTx {
+				continue
+			}
+			for _, tag := range padded {
+				if bndl[i].Tag == tag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// FilterUnexpired returns a ReceiveFilter which only lets bundles pass
+// whose deposit address conditions are not expired at the time of polling.
+func FilterUnexpired(clock account.Clock) ReceiveFilter {
+	return func(bndl bundle.Bundle, cda *deposit.Conditions) bool {
+		return !cda.Expired(clock.Now())
+	}
+}
+
+// TransferPoller is a plugin which polls the account's deposit addresses
+// in a configurable interval for incoming transfers and emits
+// EventReceivingDeposit/EventReceivedDeposit events.
+// An external near-real-time feed (for example a ZMQ address subscription)
+// can call Poke() to trigger an immediate poll instead of waiting
+// for the next interval tick.
+type TransferPoller struct {
+	acc      *account.Account
+	interval time.Duration
+	filters  []ReceiveFilter
+
+	poke     chan struct{}
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+
+	mu        sync.Mutex
+	receiving map[string]struct{}
+	received  map[string]struct{}
+}
+
+// NewTransferPoller creates a new TransferPoller polling in the given interval.
+// All supplied filters must pass for a bundle to be reported.
+func NewTransferPoller(interval time.Duration, filters ...ReceiveFilter) *TransferPoller {
+	return &TransferPoller{
+		interval:  interval,
+		filters:   filters,
+		poke:      make(chan struct{}, 1),
+		shutdown:  make(chan struct{}),
+		receiving: map[string]struct{}{},
+		received:  map[string]struct{}{},
+	}
+}
+
+// Name returns the name of the plugin.
+func (tp *TransferPoller) Name() string {
+	return "transfer-poller"
+}
+
+// Start starts the poller's background loop for the given account.
+func (tp *TransferPoller) Start(acc *account.Account) error {
+	tp.acc = acc
+	tp.wg.Add(1)
+	go func() {
+		defer tp.wg.Done()
+		clock := tp.acc.Settings().Clock
+		for {
+			select {
+			case <-clock.After(tp.interval):
+			case <-tp.poke:
+			case <-tp.shutdown:
+				return
+			}
+			if err := tp.poll(); err != nil {
+				tp.acc.Settings().EventMachine.Emit(err, event.EventError)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the poller's background loop.
+func (tp *TransferPoller) Shutdown() error {
+	close(tp.shutdown)
+	tp.wg.Wait()
+	return nil
+}
+
+// Poke triggers an immediate poll, e.g. upon a ZMQ address event.
+// It never blocks; if a poll is already queued the call is a no-op.
+func (tp *TransferPoller) Poke() {
+	select {
+	case tp.poke <- struct{}{}:
+	default:
+	}
+}
+
+func (tp *TransferPoller) poll() error {
+	setts := tp.acc.Settings()
+	depositAddresses, err := setts.Store.GetDepositAddresses(tp.acc.ID())
+	if err != nil {
+		return err
+	}
+	if len(depositAddresses) == 0 {
+		return nil
+	}
+
+	cdasByAddress := make(map[string]*deposit.Conditions, len(depositAddresses))
+	addresses := make(Hashes, 0, len(depositAddresses))
+	for _, cda := range depositAddresses {
+		cdasByAddress[cda.Address] = cda
+		addresses = append(addresses, cda.Address)
+	}
+
+	bndls, err := setts.API.GetBundlesFromAddresses(addresses, true)
+	if err != nil {
+		return err
+	}
+
+	for _, bndl := range bndls {
+		cda := depositBundleTarget(bndl, cdasByAddress)
+		if cda == nil {
+			continue
+		}
+		if !tp.passesFilters(bndl, cda) {
+			continue
+		}
+		tp.emitDepositEvent(bndl)
+	}
+	return nil
+}
+
+// depositBundleTarget returns the conditions of the watched deposit address which
+// receives funds within the given bundle, or nil if the bundle is not a deposit.
+func depositBundleTarget(bndl bundle.Bundle, cdasByAddress map[string]*deposit.Conditions) *deposit.Conditions {
+	for i := range bndl {
+		if bndl[i].Value <= 0 {
+			continue
+		}
+		if cda, ok := cdasByAddress[bndl[i].Address]; ok {
+			return cda
+		}
+	}
+	return nil
+}
+
+func (tp *TransferPoller) passesFilters(bndl bundle.Bundle, cda *deposit.Conditions) bool {
+	for _, filter := range tp.filters {
+		if !filter(bndl, cda) {
+			return false
+		}
+	}
+	return true
+}
+
+func (tp *TransferPoller) emitDepositEvent(bndl bundle.Bundle) {
+	tailTxHash := bundle.TailTransactionHash(bndl)
+	confirmed := bndl[0].Persistence != nil && *bndl[0].Persistence
+
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	if confirmed {
+		if _, ok := tp.received[tailTxHash]; ok {
+			return
+		}
+		tp.received[tailTxHash] = struct{}{}
+		tp.acc.Settings().EventMachine.Emit(bndl, event.EventReceivedDeposit)
+		return
+	}
+	if _, ok := tp.receiving[tailTxHash]; ok {
+		return
+	}
+	tp.receiving[tailTxHash] = struct{}{}
+	tp.acc.Settings().EventMachine.Emit(bndl, event.EventReceivingDeposit)
+}
@@ -0,0 +1,179 @@
+package poller_test
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/deposit"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/plugins/poller"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var (
+	testSeed    = Trytes(strings.Repeat("N", 81))
+	depositAddr = Trytes(strings.Repeat("D", 81))
+	otherHash   = Trytes(strings.Repeat("O", 81))
+)
+
+// depositTx builds a single transaction deposit bundle to the watched address.
+func depositTx(tag Trytes) *transaction.Transaction {
+	return &transaction.Transaction{
+		SignatureMessageFragment: Trytes(strings.Repeat("9", SignatureMessageFragmentTrinarySize/3)),
+		Address:                  depositAddr,
+		Value:                    100,
+		ObsoleteTag:              bundle.PadTag(tag),
+		Timestamp:                1538394000,
+		CurrentIndex:             0,
+		LastIndex:                0,
+		Bundle:                   Trytes(strings.Repeat("B", 81)),
+		TrunkTransaction:         Trytes(strings.Repeat("T", 81)),
+		BranchTransaction:        Trytes(strings.Repeat("R", 81)),
+		Tag:                      bundle.PadTag(tag),
+		AttachmentTimestamp:      1538394000000,
+		Nonce:                    Trytes(strings.Repeat("9", NonceTrinarySize/3)),
+	}
+}
+
+// tangleProvider serves a single deposit bundle with a controllable
+// confirmation state.
+type tangleProvider struct {
+	mu        sync.Mutex
+	tx        *transaction.Transaction
+	txHash    Hash
+	confirmed bool
+}
+
+func (tp *tangleProvider) SetSettings(settings interface{}) error { return nil }
+
+func (tp *tangleProvider) setConfirmed(confirmed bool) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.confirmed = confirmed
+}
+
+func (tp *tangleProvider) Send(cmd interface{}, out interface{}) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	switch cmd.(type) {
+	case *api.FindTransactionsCommand:
+		out.(*api.FindTransactionsResponse).Hashes = Hashes{tp.txHash}
+	case *api.GetTrytesCommand:
+		out.(*api.GetTrytesResponse).Trytes = []Trytes{transaction.MustTransactionToTrytes(tp.tx)}
+	case *api.GetNodeInfoCommand:
+		out.(*api.GetNodeInfoResponse).LatestSolidSubtangleMilestone = otherHash
+	case *api.GetInclusionStatesCommand:
+		out.(*api.GetInclusionStatesResponse).States = []bool{tp.confirmed}
+	default:
+		return errors.Errorf("tangle provider: unexpected command %T", cmd)
+	}
+	return nil
+}
+
+// tickClock is a Clock whose After() never fires; polls are driven via Poke().
+type tickClock struct{ now time.Time }
+
+func (tc *tickClock) Now() time.Time                         { return tc.now }
+func (tc *tickClock) After(d time.Duration) <-chan time.Time { return make(chan time.Time) }
+
+var _ = Describe("TransferPoller", func() {
+
+	newEnv := func(tag Trytes, filters ...poller.ReceiveFilter) (*account.Account, *tangleProvider, *poller.TransferPoller, chan event.Event) {
+		tx := depositTx(tag)
+		prov := &tangleProvider{tx: tx, txHash: transaction.TransactionHash(tx)}
+		a, err := api.ComposeAPI(api.HTTPClientSettings{}, func(settings interface{}) (api.Provider, error) {
+			return prov, nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		st := store.NewInMemoryStore()
+		em := event.NewEventMachine()
+		plugin := poller.NewTransferPoller(time.Minute, filters...)
+		acc, err := account.NewAccount(&account.Settings{
+			API:           a,
+			Store:         st,
+			SeedProv:      account.NewInMemorySeedProvider(testSeed),
+			MWM:           1,
+			Depth:         3,
+			SecurityLevel: SecurityLevelLow,
+			EventMachine:  em,
+			Clock:         &tickClock{now: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)},
+			Plugins:       []account.Plugin{plugin},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(st.AddDepositAddress(acc.ID(), 0, &deposit.Conditions{Address: depositAddr})).ToNot(HaveOccurred())
+
+		events := make(chan event.Event, 10)
+		for _, ev := range []event.Event{event.EventReceivingDeposit, event.EventReceivedDeposit} {
+			func(ev event.Event) {
+				em.RegisterListener(func(data interface{}) {
+					events <- ev
+				}, ev)
+			}(ev)
+		}
+		Expect(acc.Start()).ToNot(HaveOccurred())
+		return acc, prov, plugin, events
+	}
+
+	It("should emit receiving and received events for a deposit exactly once", func() {
+		acc, prov, plugin, events := newEnv("GOPHER")
+		defer acc.Shutdown()
+
+		// unconfirmed: the deposit is seen as receiving
+		plugin.Poke()
+		Eventually(events).Should(Receive(Equal(event.EventReceivingDeposit)))
+
+		// polling again must not re-emit the same deposit
+		plugin.Poke()
+		Consistently(events).ShouldNot(Receive())
+
+		// confirmed: the deposit is received
+		prov.setConfirmed(true)
+		plugin.Poke()
+		Eventually(events).Should(Receive(Equal(event.EventReceivedDeposit)))
+		plugin.Poke()
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("should drop deposits rejected by a receive filter", func() {
+		acc, _, plugin, events := newEnv("GOPHER", poller.FilterByTag("OTHERTAG"))
+		defer acc.Shutdown()
+
+		plugin.Poke()
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("should let deposits pass which match a tag filter", func() {
+		acc, _, plugin, events := newEnv("GOPHER", poller.FilterByTag("GOPHER"))
+		defer acc.Shutdown()
+
+		plugin.Poke()
+		Eventually(events).Should(Receive(Equal(event.EventReceivingDeposit)))
+	})
+
+	It("should drop deposits to expired deposit addresses with FilterUnexpired", func() {
+		clock := &tickClock{now: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)}
+		acc, _, plugin, events := newEnv("GOPHER", poller.FilterUnexpired(clock))
+		defer acc.Shutdown()
+
+		// expire the watched deposit address
+		past := clock.Now().Add(-time.Hour)
+		Expect(acc.Settings().Store.AddDepositAddress(acc.ID(), 0, &deposit.Conditions{
+			Address: depositAddr, TimeoutAt: &past,
+		})).ToNot(HaveOccurred())
+
+		plugin.Poke()
+		Consistently(events).ShouldNot(Receive())
+	})
+})
@@ -0,0 +1,178 @@
+// Package promoter provides a plugin which automatically promotes and
+// reattaches the account's own pending transfers until they are confirmed.
+package promoter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Policy defines how aggressively a Promoter promotes and reattaches
+// the pending transfers of its account.
+type Policy struct {
+	// The interval in which pending transfers are checked.
+	Interval time.Duration
+	// The maximum amount of promotions per pending transfer before
+	// the Promoter switches to reattaching. Zero means no limit.
+	MaxPromotions uint64
+	// The age an attachment must exceed before a reattachment is issued.
+	ReattachThreshold time.Duration
+}
+
+// DefaultPolicy returns a Policy with sensible defaults:
+// check every 30 seconds, at most 10 promotions, reattach after 10 minutes.
+func DefaultPolicy() Policy {
+	return Policy{
+		Interval:          30 * time.Second,
+		MaxPromotions:     10,
+		ReattachThreshold: 10 * time.Minute,
+	}
+}
+
+// Promoter is a plugin which promotes and reattaches the account's own
+// pending transfers according to a per-account Policy. The promotion count
+// per pending transfer is persisted in the account's Store, so a restarted
+// account continues where the previous run stopped.
+type Promoter struct {
+	acc    *account.Account
+	policy Policy
+
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewPromoter creates a new Promoter working with the given policy.
+func NewPromoter(policy Policy) *Promoter {
+	return &Promoter{policy: policy, shutdown: make(chan struct{})}
+}
+
+// Name returns the name of the plugin.
+func (p *Promoter) Name() string {
+	return "promoter-reattacher"
+}
+
+// Start starts the promoter's background loop for the given account.
+func (p *Promoter) Start(acc *account.Account) error {
+	p.acc = acc
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		clock := p.acc.Settings().Clock
+		for {
+			select {
+			case <-clock.After(p.policy.Interval):
+			case <-p.shutdown:
+				return
+			}
+			if err := p.checkPendingTransfers(); err != nil {
+				p.acc.Settings().EventMachine.Emit(err, event.EventError)
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the promoter's background loop.
+func (p *Promoter) Shutdown() error {
+	close(p.shutdown)
+	p.wg.Wait()
+	return nil
+}
+
+func (p *Promoter) checkPendingTransfers() error {
+	setts := p.acc.Settings()
+	pendingTransfers, err := setts.Store.GetPendingTransfers(p.acc.ID())
+	if err != nil {
+		return err
+	}
+	for originTailTxHash, pendingTransfer := range pendingTransfers {
+		if err := p.checkPendingTransfer(originTailTxHash, pendingTransfer); err != nil {
+			setts.EventMachine.Emit(err, event.EventError)
+		}
+	}
+	return nil
+}
+
+func (p *Promoter) checkPendingTransfer(originTailTxHash Hash, pendingTransfer *store.PendingTransfer) error {
+	setts := p.acc.Settings()
+
+	states, err := setts.API.GetLatestInclusion(pendingTransfer.Tails)
+	if err != nil {
+		return err
+	}
+	for _, confirmed := range states {
+		if !confirmed {
+			continue
+		}
+		if err := setts.Store.RemovePendingTransfer(p.acc.ID(), originTailTxHash); err != nil {
+			return err
+		}
+		setts.EventMachine.Emit(originTailTxHash, event.EventTransferConfirmed)
+		return nil
+	}
+
+	// operate on the most recent attachment of the bundle
+	tailTxHash := pendingTransfer.Tails[len(pendingTransfer.Tails)-1]
+	if p.shouldReattach(pendingTransfer) {
+		return p.reattach(originTailTxHash, tailTxHash)
+	}
+	return p.promote(originTailTxHash, tailTxHash)
+}
+
+// shouldReattach tells whether the pending transfer exceeded either
+// the promotion limit or the age threshold of the policy.
+func (p *Promoter) shouldReattach(pendingTransfer *store.PendingTransfer) bool {
+	if p.policy.MaxPromotions != 0 && pendingTransfer.Promotions >= p.policy.MaxPromotions {
+		return true
+	}
+	if p.policy.ReattachThreshold == 0 {
+		return false
+	}
+	txs, err := transaction.AsTransactionObjects(pendingTransfer.Bundle, nil)
+	if err != nil {
+		return false
+	}
+	attachedAt := time.Unix(int64(txs[0].Timestamp), 0)
+	return p.acc.Settings().Clock.Now().Sub(attachedAt) >= p.policy.ReattachThreshold
+}
+
+func (p *Promoter) promote(originTailTxHash Hash, tailTxHash Hash) error {
+	setts := p.acc.Settings()
+	promotable, err := setts.API.IsPromotable(tailTxHash)
+	if err != nil {
+		return err
+	}
+	if !promotable {
+		return p.reattach(originTailTxHash, tailTxHash)
+	}
+	if _, err := setts.API.PromoteTransaction(tailTxHash, setts.Depth, setts.MWM, nil, api.PromoteTransactionOptions{}); err != nil {
+		return err
+	}
+	if _, err := setts.Store.AddPromotion(p.acc.ID(), originTailTxHash); err != nil {
+		return err
+	}
+	setts.EventMachine.Emit(tailTxHash, event.EventPromotion)
+	return nil
+}
+
+func (p *Promoter) reattach(originTailTxHash Hash, tailTxHash Hash) error {
+	setts := p.acc.Settings()
+	bndl, err := setts.API.ReplayBundle(tailTxHash, setts.Depth, setts.MWM)
+	if err != nil {
+		return err
+	}
+	newTailTxHash := bundle.TailTransactionHash(bndl)
+	if err := setts.Store.AddTailHash(p.acc.ID(), originTailTxHash, newTailTxHash); err != nil {
+		return err
+	}
+	setts.EventMachine.Emit(newTailTxHash, event.EventReattachment)
+	return nil
+}
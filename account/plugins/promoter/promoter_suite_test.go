@@ -0,0 +1,13 @@
+package promoter_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPromoter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Promoter Suite")
+}
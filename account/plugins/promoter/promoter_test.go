@@ -0,0 +1,211 @@
+package promoter_test
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/plugins/promoter"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var (
+	testSeed      = Trytes(strings.Repeat("N", 81))
+	testRecipient = Trytes(strings.Repeat("R", 81))
+	testTrunk     = Trytes(strings.Repeat("T", 81))
+	testBranch    = Trytes(strings.Repeat("B", 81))
+	testMilestone = Trytes(strings.Repeat("M", 81))
+)
+
+// nodeProvider fakes the IRI commands issued while checking and
+// promoting/reattaching a single pending transfer.
+type nodeProvider struct {
+	mu sync.Mutex
+	// the trytes served for getTrytes requests, keyed by transaction hash
+	trytes map[Hash]Trytes
+	// the inclusion state reported for every queried tail
+	confirmed bool
+}
+
+func (np *nodeProvider) SetSettings(settings interface{}) error { return nil }
+
+func (np *nodeProvider) setConfirmed(confirmed bool) {
+	np.mu.Lock()
+	defer np.mu.Unlock()
+	np.confirmed = confirmed
+}
+
+func (np *nodeProvider) Send(cmd interface{}, out interface{}) error {
+	np.mu.Lock()
+	defer np.mu.Unlock()
+	switch c := cmd.(type) {
+	case *api.GetNodeInfoCommand:
+		out.(*api.GetNodeInfoResponse).LatestSolidSubtangleMilestone = testMilestone
+	case *api.GetInclusionStatesCommand:
+		rsp := out.(*api.GetInclusionStatesResponse)
+		rsp.States = make([]bool, len(c.Transactions))
+		for i := range rsp.States {
+			rsp.States[i] = np.confirmed
+		}
+	case *api.CheckConsistencyCommand:
+		out.(*api.CheckConsistencyResponse).State = true
+	case *api.GetTrytesCommand:
+		rsp := out.(*api.GetTrytesResponse)
+		rsp.Trytes = make([]Trytes, len(c.Hashes))
+		for i, hash := range c.Hashes {
+			trytes, ok := np.trytes[hash]
+			if !ok {
+				return errors.Errorf("node provider: unknown transaction %s", hash)
+			}
+			rsp.Trytes[i] = trytes
+		}
+	case *api.GetTransactionsToApproveCommand:
+		rsp := out.(*api.GetTransactionsToApproveResponse)
+		rsp.TrunkTransaction = testTrunk
+		rsp.BranchTransaction = testBranch
+	case *api.StoreTransactionsCommand:
+	case *api.BroadcastTransactionsCommand:
+	default:
+		return errors.Errorf("node provider: unexpected command %T", cmd)
+	}
+	return nil
+}
+
+// tickClock is a Clock whose After() fires once per tick() call.
+type tickClock struct {
+	now   time.Time
+	ticks chan time.Time
+}
+
+func (tc *tickClock) Now() time.Time                         { return tc.now }
+func (tc *tickClock) After(d time.Duration) <-chan time.Time { return tc.ticks }
+func (tc *tickClock) tick()                                  { tc.ticks <- tc.now }
+
+type testEnv struct {
+	acc        *account.Account
+	prov       *nodeProvider
+	store      store.Store
+	clock      *tickClock
+	em         event.EventMachine
+	tailTxHash Hash
+}
+
+// newEnv creates a started account with the given promoter plugin and a
+// single persisted pending transfer whose attachment the provider serves.
+func newEnv(policy promoter.Policy) *testEnv {
+	prov := &nodeProvider{trytes: map[Hash]Trytes{}}
+	a, err := api.ComposeAPI(api.HTTPClientSettings{
+		LocalProofOfWorkFunc: func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+			return strings.Repeat("9", NonceTrinarySize/3), nil
+		},
+	}, func(settings interface{}) (api.Provider, error) {
+		return prov, nil
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	env := &testEnv{
+		prov:  prov,
+		store: store.NewInMemoryStore(),
+		clock: &tickClock{now: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC), ticks: make(chan time.Time)},
+		em:    event.NewEventMachine(),
+	}
+	env.acc, err = account.NewAccount(&account.Settings{
+		API:           a,
+		Store:         env.store,
+		SeedProv:      account.NewInMemorySeedProvider(testSeed),
+		MWM:           1,
+		Depth:         3,
+		SecurityLevel: SecurityLevelLow,
+		EventMachine:  env.em,
+		Clock:         env.clock,
+		Plugins:       []account.Plugin{promoter.NewPromoter(policy)},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	// persist a pending transfer with a real, locally attached bundle
+	trytes, err := a.PrepareTransfers(testSeed, bundle.Transfers{{Address: testRecipient}},
+		api.PrepareTransfersOptions{Security: SecurityLevelLow})
+	Expect(err).ToNot(HaveOccurred())
+	attached, err := a.AttachToTangle(testTrunk, testBranch, 1, trytes)
+	Expect(err).ToNot(HaveOccurred())
+	txs, err := transaction.AsTransactionObjects(attached, nil)
+	Expect(err).ToNot(HaveOccurred())
+	env.tailTxHash = transaction.TransactionHash(&txs[0])
+	prov.trytes[env.tailTxHash] = attached[0]
+	Expect(env.store.AddPendingTransfer(env.acc.ID(), env.tailTxHash, attached)).ToNot(HaveOccurred())
+
+	Expect(env.acc.Start()).ToNot(HaveOccurred())
+	return env
+}
+
+func (env *testEnv) listen(ev event.Event) chan interface{} {
+	ch := make(chan interface{}, 1)
+	env.em.RegisterListener(func(data interface{}) {
+		ch <- data
+	}, ev)
+	return ch
+}
+
+var _ = Describe("Promoter", func() {
+
+	It("should remove confirmed transfers and emit EventTransferConfirmed", func() {
+		env := newEnv(promoter.Policy{Interval: time.Minute})
+		defer env.acc.Shutdown()
+		confirmed := env.listen(event.EventTransferConfirmed)
+
+		env.prov.setConfirmed(true)
+		env.clock.tick()
+
+		var data interface{}
+		Eventually(confirmed).Should(Receive(&data))
+		Expect(data).To(Equal(env.tailTxHash))
+		pending, err := env.store.GetPendingTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(BeEmpty())
+	})
+
+	It("should promote unconfirmed transfers and persist the promotion count", func() {
+		env := newEnv(promoter.Policy{Interval: time.Minute, MaxPromotions: 10})
+		defer env.acc.Shutdown()
+		promoted := env.listen(event.EventPromotion)
+
+		env.clock.tick()
+
+		Eventually(promoted).Should(Receive(Equal(env.tailTxHash)))
+		Eventually(func() uint64 {
+			pending, err := env.store.GetPendingTransfers(env.acc.ID())
+			Expect(err).ToNot(HaveOccurred())
+			return pending[env.tailTxHash].Promotions
+		}).Should(Equal(uint64(1)))
+	})
+
+	It("should reattach once the promotion limit is reached", func() {
+		env := newEnv(promoter.Policy{Interval: time.Minute, MaxPromotions: 1})
+		defer env.acc.Shutdown()
+		reattached := env.listen(event.EventReattachment)
+
+		// the transfer already used up its only allowed promotion
+		_, err := env.store.AddPromotion(env.acc.ID(), env.tailTxHash)
+		Expect(err).ToNot(HaveOccurred())
+
+		env.clock.tick()
+
+		var newTail interface{}
+		Eventually(reattached).Should(Receive(&newTail))
+		Expect(newTail).ToNot(Equal(env.tailTxHash))
+		pending, err := env.store.GetPendingTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending[env.tailTxHash].Tails).To(Equal(Hashes{env.tailTxHash, newTail.(Hash)}))
+	})
+})
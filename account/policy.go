@@ -0,0 +1,119 @@
+package account
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrMaxValuePerTransferExceeded gets returned when a transfer exceeds the
+	// policy's maximum value per transfer.
+	ErrMaxValuePerTransferExceeded = errors.New("transfer exceeds the maximum value per transfer")
+	// ErrMaxValuePerDayExceeded gets returned when a transfer would exceed the
+	// policy's maximum value per day.
+	ErrMaxValuePerDayExceeded = errors.New("transfer exceeds the maximum value per day")
+	// ErrDestinationNotAllowed gets returned when a transfer targets an address
+	// outside the policy's destination allowlist.
+	ErrDestinationNotAllowed = errors.New("transfer destination is not allowed by policy")
+	// ErrTransferNotApproved gets returned when the policy's approver rejects a transfer.
+	ErrTransferNotApproved = errors.New("transfer was not approved")
+)
+
+// ApproveFunc decides whether the given transfers may be sent.
+// Returning an error rejects the transfers; the error is wrapped
+// into ErrTransferNotApproved and returned by Send().
+type ApproveFunc func(transfers bundle.Transfers) error
+
+// SpendingPolicy restricts what the account may spend. The policy is enforced
+// inside Send() before any signing happens, so callers don't have to replicate
+// the checks at every call site. The per-day bookkeeping is kept in process
+// memory and resets when the account is recreated.
+type SpendingPolicy struct {
+	// The maximum value of a single transfer. Zero means no limit.
+	MaxValuePerTransfer uint64
+	// The maximum summed value of all transfers within a calendar day (as per
+	// the account's Clock). Zero means no limit.
+	MaxValuePerDay uint64
+	// If non empty, transfers may only target these addresses (without checksum).
+	AllowedDestinations Hashes
+	// If set, transfers with a summed value of at least this threshold must
+	// additionally be approved by the Approver. Zero means every transfer
+	// requires approval if an Approver is set.
+	RequireApprovalAbove uint64
+	// The callback asked for approval before signing.
+	Approver ApproveFunc
+
+	mu        sync.Mutex
+	spentDay  string
+	spentIota uint64
+}
+
+// enforce validates the given transfers against the policy and
+// reserves their value in the per-day bookkeeping.
+func (sp *SpendingPolicy) enforce(transfers bundle.Transfers, now time.Time) error {
+	var total uint64
+	for i := range transfers {
+		if sp.MaxValuePerTransfer != 0 && transfers[i].Value > sp.MaxValuePerTransfer {
+			return errors.Wrapf(ErrMaxValuePerTransferExceeded, "value %d, limit %d", transfers[i].Value, sp.MaxValuePerTransfer)
+		}
+		if len(sp.AllowedDestinations) > 0 && !sp.destinationAllowed(transfers[i].Address) {
+			return errors.Wrapf(ErrDestinationNotAllowed, "address %s", transfers[i].Address)
+		}
+		total += transfers[i].Value
+	}
+
+	if sp.MaxValuePerDay != 0 {
+		sp.mu.Lock()
+		day := now.Format("2006-01-02")
+		if sp.spentDay != day {
+			sp.spentDay = day
+			sp.spentIota = 0
+		}
+		if sp.spentIota+total > sp.MaxValuePerDay {
+			sp.mu.Unlock()
+			return errors.Wrapf(ErrMaxValuePerDayExceeded, "spent %d, requested %d, limit %d", sp.spentIota, total, sp.MaxValuePerDay)
+		}
+		sp.spentIota += total
+		sp.mu.Unlock()
+	}
+
+	if sp.Approver != nil && total >= sp.RequireApprovalAbove {
+		if err := sp.Approver(transfers); err != nil {
+			sp.release(total, now)
+			return errors.Wrap(ErrTransferNotApproved, err.Error())
+		}
+	}
+	return nil
+}
+
+// release gives the reserved value back to the per-day budget,
+// used when a transfer fails after the policy check.
+func (sp *SpendingPolicy) release(total uint64, now time.Time) {
+	if sp.MaxValuePerDay == 0 {
+		return
+	}
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	if sp.spentDay != now.Format("2006-01-02") {
+		return
+	}
+	if total > sp.spentIota {
+		sp.spentIota = 0
+		return
+	}
+	sp.spentIota -= total
+}
+
+func (sp *SpendingPolicy) destinationAllowed(addr Hash) bool {
+	for _, allowed := range sp.AllowedDestinations {
+		if allowed == addr {
+			return true
+		}
+	}
+	return false
+}
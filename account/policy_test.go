@@ -0,0 +1,115 @@
+package account_test
+
+import (
+	"time"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// failingStore wraps a Store and lets AddInFlightTransfer fail on demand.
+type failingStore struct {
+	store.Store
+	addInFlightErr error
+}
+
+func (fs *failingStore) AddInFlightTransfer(id string, bundleHash Hash, transfer *store.InFlightTransfer) error {
+	if fs.addInFlightErr != nil {
+		return fs.addInFlightErr
+	}
+	return fs.Store.AddInFlightTransfer(id, bundleHash, transfer)
+}
+
+var _ = Describe("SpendingPolicy", func() {
+
+	newPolicyEnv := func(policy *account.SpendingPolicy, tweaks ...func(setts *account.Settings)) *testEnv {
+		tweaks = append([]func(setts *account.Settings){func(setts *account.Settings) {
+			setts.Policy = policy
+		}}, tweaks...)
+		env := newTestEnv(tweaks...)
+		env.prov.balancePerAddress = 1000
+		return env
+	}
+
+	It("should reject transfers exceeding the maximum value per transfer", func() {
+		env := newPolicyEnv(&account.SpendingPolicy{MaxValuePerTransfer: 10})
+		_, err := env.acc.Send(account.Recipient{Address: testRecipient, Value: 11})
+		Expect(errors.Cause(err)).To(Equal(account.ErrMaxValuePerTransferExceeded))
+		// the policy is enforced before anything is signed or sent
+		Expect(env.prov.sentCommands()).To(BeEmpty())
+	})
+
+	It("should reject transfers to addresses outside the allowlist", func() {
+		env := newPolicyEnv(&account.SpendingPolicy{AllowedDestinations: Hashes{testTrunk}})
+		_, err := env.acc.Send(account.Recipient{Address: testRecipient, Value: 1})
+		Expect(errors.Cause(err)).To(Equal(account.ErrDestinationNotAllowed))
+		Expect(env.prov.sentCommands()).To(BeEmpty())
+	})
+
+	It("should enforce the per-day budget and reset it on the next day", func() {
+		env := newPolicyEnv(&account.SpendingPolicy{MaxValuePerDay: 100})
+
+		_, err := env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(errors.Cause(err)).To(Equal(account.ErrMaxValuePerDayExceeded))
+
+		env.clock.Advance(24 * time.Hour)
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should release the reserved budget when the approver rejects", func() {
+		policy := &account.SpendingPolicy{
+			MaxValuePerDay: 60,
+			Approver: func(transfers bundle.Transfers) error {
+				return errors.New("no")
+			},
+		}
+		env := newPolicyEnv(policy)
+
+		_, err := env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(errors.Cause(err)).To(Equal(account.ErrTransferNotApproved))
+
+		policy.Approver = nil
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should release the reserved budget when the node rejects the transfer", func() {
+		env := newPolicyEnv(&account.SpendingPolicy{MaxValuePerDay: 60})
+
+		env.prov.failOnly(api.GetTransactionsToApproveCmd, errors.New("node unavailable"))
+		_, err := env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).To(HaveOccurred())
+
+		// the failed transfer must not stay booked against the day's budget
+		env.prov.setSendErr(nil)
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should release the reserved budget when persisting the in-flight transfer fails", func() {
+		fs := &failingStore{}
+		env := newPolicyEnv(&account.SpendingPolicy{MaxValuePerDay: 60}, func(setts *account.Settings) {
+			fs.Store = setts.Store
+			setts.Store = fs
+		})
+
+		fs.addInFlightErr = errors.New("disk full")
+		_, err := env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).To(Equal(fs.addInFlightErr))
+
+		fs.addInFlightErr = nil
+		_, err = env.acc.Send(account.Recipient{Address: testRecipient, Value: 60})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,98 @@
+package account_test
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/account"
+	"github.com/iotaledger/iota.go/account/event"
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("in-flight transfer resumption", func() {
+
+	// prepareInFlight persists an interrupted transfer in the env's store
+	// and returns its bundle hash.
+	prepareInFlight := func(env *testEnv, attached bool) Hash {
+		trytes, err := env.setts.API.PrepareTransfers(testSeed, bundle.Transfers{
+			{Address: testRecipient},
+		}, api.PrepareTransfersOptions{Security: env.setts.SecurityLevel})
+		Expect(err).ToNot(HaveOccurred())
+		if attached {
+			trytes, err = env.setts.API.AttachToTangle(testTrunk, testBranch, env.setts.MWM, trytes)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		tx, err := transaction.AsTransactionObject(trytes[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(env.store.AddInFlightTransfer(env.acc.ID(), tx.Bundle, &store.InFlightTransfer{
+			Trytes:    trytes,
+			Attached:  attached,
+			CreatedAt: env.clock.Now().Unix(),
+		})).ToNot(HaveOccurred())
+		return tx.Bundle
+	}
+
+	It("should roll an unattached transfer through the full remaining pipeline on Start()", func() {
+		env := newTestEnvUnstarted()
+		prepareInFlight(env, false)
+
+		Expect(env.acc.Start()).ToNot(HaveOccurred())
+		Expect(env.prov.sentCommands()).To(Equal([]api.IRICommand{
+			api.GetTransactionsToApproveCmd, api.StoreTransactionsCmd, api.BroadcastTransactionsCmd,
+		}))
+
+		pending, err := env.store.GetPendingTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+		inFlight, err := env.store.GetInFlightTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inFlight).To(BeEmpty())
+	})
+
+	It("should not redo Proof-of-Work for an already attached transfer", func() {
+		env := newTestEnvUnstarted()
+		prepareInFlight(env, true)
+
+		Expect(env.acc.Start()).ToNot(HaveOccurred())
+		// no tip selection: the persisted attachment is broadcast as is
+		Expect(env.prov.sentCommands()).To(Equal([]api.IRICommand{
+			api.StoreTransactionsCmd, api.BroadcastTransactionsCmd,
+		}))
+
+		pending, err := env.store.GetPendingTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+	})
+
+	It("should start the account even when resumption fails and keep the transfer in-flight", func() {
+		env := newTestEnvUnstarted()
+		bundleHash := prepareInFlight(env, false)
+
+		var emittedErr error
+		env.setts.EventMachine.RegisterListener(func(data interface{}) {
+			emittedErr = data.(error)
+		}, event.EventError)
+
+		env.prov.setSendErr(errors.New("node unavailable"))
+		Expect(env.acc.Start()).ToNot(HaveOccurred())
+		Expect(emittedErr).To(HaveOccurred())
+		Expect(emittedErr.Error()).To(ContainSubstring(bundleHash))
+
+		// the transfer stays in-flight for the next run
+		inFlight, err := env.store.GetInFlightTransfers(env.acc.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inFlight).To(HaveKey(bundleHash))
+
+		// the account is operational regardless
+		env.prov.setSendErr(nil)
+		_, err = env.acc.Send(account.Recipient{Address: Trytes(strings.Repeat("S", 81))})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
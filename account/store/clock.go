@@ -0,0 +1,22 @@
+package store
+
+import "time"
+
+// Clock is the source of time used by store implementations wherever they
+// stamp persisted data with wall time, such as account exports. It is the
+// subset of the account's Clock the stores need, so an account's Clock can
+// be injected directly.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// SystemClock returns a Clock backed by the system's wall clock.
+// It is the default time source of all store implementations.
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
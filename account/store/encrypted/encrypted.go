@@ -0,0 +1,97 @@
+// Package encrypted provides a transparent encryption decorator for any
+// KV backed Store, protecting account state (addresses, key indexes,
+// pending bundles) at rest regardless of the database used.
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/iotaledger/iota.go/account/store"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidKeyLength gets returned when the encryption key is not 16, 24 or 32 bytes long.
+	ErrInvalidKeyLength = errors.New("encryption key must be 16, 24 or 32 bytes long")
+	// ErrCiphertextTooShort gets returned when a stored value is shorter than the AEAD nonce.
+	ErrCiphertextTooShort = errors.New("ciphertext is too short")
+)
+
+// Options configure the encryption wrapper.
+type Options struct {
+	// If true, keys are additionally obfuscated through a keyed hash, so the
+	// backend sees neither account ids nor plaintext values. Key obfuscation
+	// is deterministic in order to keep lookups working.
+	HashKeys bool
+}
+
+// NewKV wraps the given KV so that all values are encrypted with
+// AES-GCM using the given key before they reach the backend.
+// A Store over an encrypted backend is created via
+// store.NewKVStore(encrypted.NewKV(backend, key, opts)).
+func NewKV(inner store.KV, key []byte, opts ...Options) (store.KV, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, ErrInvalidKeyLength
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	kv := &encryptedKV{inner: inner, aead: aead, hmacKey: key}
+	if len(opts) > 0 {
+		kv.hashKeys = opts[0].HashKeys
+	}
+	return kv, nil
+}
+
+type encryptedKV struct {
+	inner    store.KV
+	aead     cipher.AEAD
+	hmacKey  []byte
+	hashKeys bool
+}
+
+func (kv *encryptedKV) mapKey(key []byte) []byte {
+	if !kv.hashKeys {
+		return key
+	}
+	mac := hmac.New(sha256.New, kv.hmacKey)
+	mac.Write(key)
+	return mac.Sum(nil)
+}
+
+func (kv *encryptedKV) Get(key []byte) ([]byte, error) {
+	ciphertext, err := kv.inner.Get(kv.mapKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < kv.aead.NonceSize() {
+		return nil, ErrCiphertextTooShort
+	}
+	nonce, sealed := ciphertext[:kv.aead.NonceSize()], ciphertext[kv.aead.NonceSize():]
+	return kv.aead.Open(nil, nonce, sealed, nil)
+}
+
+func (kv *encryptedKV) Set(key []byte, value []byte) error {
+	nonce := make([]byte, kv.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := kv.aead.Seal(nonce, nonce, value, nil)
+	return kv.inner.Set(kv.mapKey(key), sealed)
+}
+
+func (kv *encryptedKV) Delete(key []byte) error {
+	return kv.inner.Delete(kv.mapKey(key))
+}
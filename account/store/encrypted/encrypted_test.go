@@ -0,0 +1,124 @@
+package encrypted_test
+
+import (
+	"bytes"
+
+	"github.com/iotaledger/iota.go/account/store"
+	"github.com/iotaledger/iota.go/account/store/encrypted"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var testKey = bytes.Repeat([]byte{0xAB}, 32)
+
+// peekableKV records what the encrypted wrapper hands to the backend.
+type peekableKV struct {
+	store.KV
+	keys   [][]byte
+	values [][]byte
+}
+
+func (kv *peekableKV) Set(key []byte, value []byte) error {
+	kv.keys = append(kv.keys, key)
+	kv.values = append(kv.values, value)
+	return kv.KV.Set(key, value)
+}
+
+var _ = Describe("NewKV()", func() {
+
+	It("should reject keys of invalid length", func() {
+		_, err := encrypted.NewKV(store.NewInMemoryKV(), make([]byte, 10))
+		Expect(err).To(Equal(encrypted.ErrInvalidKeyLength))
+	})
+
+	It("should round-trip values without the backend ever seeing the plaintext", func() {
+		backend := &peekableKV{KV: store.NewInMemoryKV()}
+		kv, err := encrypted.NewKV(backend, testKey)
+		Expect(err).ToNot(HaveOccurred())
+
+		plaintext := []byte("extremely secret account state")
+		Expect(kv.Set([]byte("state"), plaintext)).ToNot(HaveOccurred())
+
+		read, err := kv.Get([]byte("state"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(Equal(plaintext))
+
+		Expect(backend.values).To(HaveLen(1))
+		Expect(bytes.Contains(backend.values[0], plaintext)).To(BeFalse())
+	})
+
+	It("should detect tampered ciphertext", func() {
+		backend := store.NewInMemoryKV()
+		kv, err := encrypted.NewKV(backend, testKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Set([]byte("state"), []byte("payload"))).ToNot(HaveOccurred())
+
+		sealed, err := backend.Get([]byte("state"))
+		Expect(err).ToNot(HaveOccurred())
+		sealed[len(sealed)-1] ^= 0x01
+		Expect(backend.Set([]byte("state"), sealed)).ToNot(HaveOccurred())
+
+		_, err = kv.Get([]byte("state"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not decrypt values sealed under a different key", func() {
+		backend := store.NewInMemoryKV()
+		kv, err := encrypted.NewKV(backend, testKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(kv.Set([]byte("state"), []byte("payload"))).ToNot(HaveOccurred())
+
+		otherKV, err := encrypted.NewKV(backend, bytes.Repeat([]byte{0xCD}, 32))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = otherKV.Get([]byte("state"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject stored values shorter than the nonce", func() {
+		backend := store.NewInMemoryKV()
+		kv, err := encrypted.NewKV(backend, testKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(backend.Set([]byte("state"), []byte("x"))).ToNot(HaveOccurred())
+
+		_, err = kv.Get([]byte("state"))
+		Expect(err).To(Equal(encrypted.ErrCiphertextTooShort))
+	})
+
+	It("should obfuscate backend keys deterministically with HashKeys", func() {
+		backend := &peekableKV{KV: store.NewInMemoryKV()}
+		kv, err := encrypted.NewKV(backend, testKey, encrypted.Options{HashKeys: true})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(kv.Set([]byte("account-id"), []byte("payload"))).ToNot(HaveOccurred())
+		Expect(backend.keys).To(HaveLen(1))
+		Expect(backend.keys[0]).ToNot(Equal([]byte("account-id")))
+
+		// lookups and deletes must keep working through the obfuscated key
+		read, err := kv.Get([]byte("account-id"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(read).To(Equal([]byte("payload")))
+		Expect(kv.Delete([]byte("account-id"))).ToNot(HaveOccurred())
+		_, err = kv.Get([]byte("account-id"))
+		Expect(err).To(Equal(store.ErrKeyNotFound))
+	})
+
+	It("should back a fully functional KV store", func() {
+		kv, err := encrypted.NewKV(store.NewInMemoryKV(), testKey, encrypted.Options{HashKeys: true})
+		Expect(err).ToNot(HaveOccurred())
+		st := store.NewKVStore(kv)
+
+		Expect(st.WriteIndex("a", 9)).ToNot(HaveOccurred())
+		index, err := st.ReadIndex("a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(uint64(9)))
+
+		exported, err := st.ExportAccount("a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(st.RemoveAccount("a")).ToNot(HaveOccurred())
+		Expect(st.ImportAccount(exported)).ToNot(HaveOccurred())
+		index, err = st.ReadIndex("a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(index).To(Equal(uint64(9)))
+	})
+})
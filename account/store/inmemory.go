@@ -0,0 +1,327 @@
+package store
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/iotaledger/iota.go/account/deposit"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// NewInMemoryStore creates a new in-memory store which keeps all account states
+// in process memory. It is mainly meant for testing and short-lived accounts.
+// The optional clock is used to date account exports and defaults to the
+// system clock.
+func NewInMemoryStore(clock ...Clock) Store {
+	mem := &inMemoryStore{accounts: map[string]*AccountState{}, clock: SystemClock()}
+	if len(clock) > 0 && clock[0] != nil {
+		mem.clock = clock[0]
+	}
+	return mem
+}
+
+type inMemoryStore struct {
+	mu       sync.Mutex
+	accounts map[string]*AccountState
+	clock    Clock
+}
+
+func (mem *inMemoryStore) LoadAccount(id string) (*AccountState, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, ok := mem.accounts[id]
+	if !ok {
+		state = NewAccountState()
+		mem.accounts[id] = state
+	}
+	return state, nil
+}
+
+func (mem *inMemoryStore) RemoveAccount(id string) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	if _, ok := mem.accounts[id]; !ok {
+		return ErrAccountNotFound
+	}
+	delete(mem.accounts, id)
+	return nil
+}
+
+func (mem *inMemoryStore) ReadIndex(id string) (uint64, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return 0, err
+	}
+	return state.KeyIndex, nil
+}
+
+func (mem *inMemoryStore) WriteIndex(id string, index uint64) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	state.KeyIndex = index
+	return nil
+}
+
+func (mem *inMemoryStore) AddDepositAddress(id string, index uint64, conditions *deposit.Conditions) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	state.DepositAddresses[index] = conditions
+	return nil
+}
+
+func (mem *inMemoryStore) RemoveDepositAddress(id string, index uint64) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	if _, ok := state.DepositAddresses[index]; !ok {
+		return ErrDepositAddressNotFound
+	}
+	delete(state.DepositAddresses, index)
+	return nil
+}
+
+func (mem *inMemoryStore) GetDepositAddresses(id string) (map[uint64]*deposit.Conditions, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make(map[uint64]*deposit.Conditions, len(state.DepositAddresses))
+	for index, conditions := range state.DepositAddresses {
+		addrs[index] = conditions
+	}
+	return addrs, nil
+}
+
+func (mem *inMemoryStore) AddPendingTransfer(id string, originTailTxHash Hash, bundleTrytes []Trytes) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	state.PendingTransfers[originTailTxHash] = &PendingTransfer{
+		Bundle: bundleTrytes,
+		Tails:  Hashes{originTailTxHash},
+	}
+	return nil
+}
+
+func (mem *inMemoryStore) RemovePendingTransfer(id string, originTailTxHash Hash) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	if _, ok := state.PendingTransfers[originTailTxHash]; !ok {
+		return ErrPendingTransferNotFound
+	}
+	delete(state.PendingTransfers, originTailTxHash)
+	return nil
+}
+
+func (mem *inMemoryStore) AddTailHash(id string, originTailTxHash Hash, newTailTxHash Hash) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	pendingTransfer, ok := state.PendingTransfers[originTailTxHash]
+	if !ok {
+		return ErrPendingTransferNotFound
+	}
+	pendingTransfer.Tails = append(pendingTransfer.Tails, newTailTxHash)
+	return nil
+}
+
+func (mem *inMemoryStore) AddPromotion(id string, originTailTxHash Hash) (uint64, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return 0, err
+	}
+	pendingTransfer, ok := state.PendingTransfers[originTailTxHash]
+	if !ok {
+		return 0, ErrPendingTransferNotFound
+	}
+	pendingTransfer.Promotions++
+	return pendingTransfer.Promotions, nil
+}
+
+func (mem *inMemoryStore) GetPendingTransfers(id string) (map[string]*PendingTransfer, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make(map[string]*PendingTransfer, len(state.PendingTransfers))
+	for tailTxHash, pendingTransfer := range state.PendingTransfers {
+		transfers[tailTxHash] = pendingTransfer
+	}
+	return transfers, nil
+}
+
+func (mem *inMemoryStore) AddInFlightTransfer(id string, bundleHash Hash, transfer *InFlightTransfer) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	state.InFlightTransfers[bundleHash] = transfer
+	return nil
+}
+
+func (mem *inMemoryStore) RemoveInFlightTransfer(id string, bundleHash Hash) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	if _, ok := state.InFlightTransfers[bundleHash]; !ok {
+		return ErrInFlightTransferNotFound
+	}
+	delete(state.InFlightTransfers, bundleHash)
+	return nil
+}
+
+func (mem *inMemoryStore) GetInFlightTransfers(id string) (map[string]*InFlightTransfer, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make(map[string]*InFlightTransfer, len(state.InFlightTransfers))
+	for bundleHash, transfer := range state.InFlightTransfers {
+		transfers[bundleHash] = transfer
+	}
+	return transfers, nil
+}
+
+func (mem *inMemoryStore) AddDeadLetter(id string, letter *DeadLetter) (uint64, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return 0, err
+	}
+	index := state.DeadLetterIndex
+	state.DeadLetterIndex++
+	state.DeadLetters[index] = letter
+	return index, nil
+}
+
+func (mem *inMemoryStore) RemoveDeadLetter(id string, index uint64) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return err
+	}
+	if _, ok := state.DeadLetters[index]; !ok {
+		return ErrDeadLetterNotFound
+	}
+	delete(state.DeadLetters, index)
+	return nil
+}
+
+func (mem *inMemoryStore) GetDeadLetters(id string) (map[uint64]*DeadLetter, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return nil, err
+	}
+	letters := make(map[uint64]*DeadLetter, len(state.DeadLetters))
+	for index, letter := range state.DeadLetters {
+		letters[index] = letter
+	}
+	return letters, nil
+}
+
+func (mem *inMemoryStore) AppendJournalEntry(id string, entry *JournalEntry) (uint64, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return 0, err
+	}
+	entry.Sequence = state.JournalSequence
+	state.JournalSequence++
+	state.Journal = append(state.Journal, entry)
+	return entry.Sequence, nil
+}
+
+func (mem *inMemoryStore) GetJournalEntries(id string, fromSequence uint64) ([]*JournalEntry, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*JournalEntry, 0, len(state.Journal))
+	for _, entry := range state.Journal {
+		if entry.Sequence < fromSequence {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (mem *inMemoryStore) ExportAccount(id string) (*ExportedAccountState, error) {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	state, err := mem.load(id)
+	if err != nil {
+		return nil, err
+	}
+	// deep copy the state through its JSON representation
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	exported := &ExportedAccountState{ID: id, Date: mem.clock.Now().Unix()}
+	if err := json.Unmarshal(stateJSON, &exported.State); err != nil {
+		return nil, err
+	}
+	return exported, nil
+}
+
+func (mem *inMemoryStore) ImportAccount(state *ExportedAccountState) error {
+	mem.mu.Lock()
+	defer mem.mu.Unlock()
+	stateCopy := state.State
+	mem.accounts[state.ID] = &stateCopy
+	return nil
+}
+
+// load must be called while holding the mutex.
+func (mem *inMemoryStore) load(id string) (*AccountState, error) {
+	state, ok := mem.accounts[id]
+	if !ok {
+		state = NewAccountState()
+		mem.accounts[id] = state
+	}
+	return state, nil
+}
@@ -0,0 +1,349 @@
+package store
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/iotaledger/iota.go/account/deposit"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+// ErrKeyNotFound gets returned by KV implementations when a key does not exist.
+var ErrKeyNotFound = errors.New("key not found in kv store")
+
+// KV is a minimal key-value abstraction over which a Store can be built.
+// Database backends (Badger, Bolt, Redis, ...) only need to implement this
+// interface; decorators such as transparent encryption wrap it.
+type KV interface {
+	// Get returns the value stored under the given key
+	// or ErrKeyNotFound if the key does not exist.
+	Get(key []byte) ([]byte, error)
+	// Set stores the given value under the given key.
+	Set(key []byte, value []byte) error
+	// Delete removes the value stored under the given key.
+	Delete(key []byte) error
+}
+
+// NewInMemoryKV creates a KV keeping all data in process memory.
+func NewInMemoryKV() KV {
+	return &inMemoryKV{data: map[string][]byte{}}
+}
+
+type inMemoryKV struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (kv *inMemoryKV) Get(key []byte) ([]byte, error) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	value, ok := kv.data[string(key)]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	cp := make([]byte, len(value))
+	copy(cp, value)
+	return cp, nil
+}
+
+func (kv *inMemoryKV) Set(key []byte, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	cp := make([]byte, len(value))
+	copy(cp, value)
+	kv.data[string(key)] = cp
+	return nil
+}
+
+func (kv *inMemoryKV) Delete(key []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	delete(kv.data, string(key))
+	return nil
+}
+
+// NewKVStore creates a Store which persists account states as JSON
+// blobs in the given KV backend, one blob per account id.
+// The optional clock is used to date account exports and defaults to the
+// system clock.
+func NewKVStore(kv KV, clock ...Clock) Store {
+	st := &kvStore{kv: kv, clock: SystemClock()}
+	if len(clock) > 0 && clock[0] != nil {
+		st.clock = clock[0]
+	}
+	return st
+}
+
+type kvStore struct {
+	mu    sync.Mutex
+	kv    KV
+	clock Clock
+}
+
+func (st *kvStore) loadState(id string) (*AccountState, error) {
+	blob, err := st.kv.Get([]byte(id))
+	if err != nil {
+		if errors.Cause(err) == ErrKeyNotFound {
+			return NewAccountState(), nil
+		}
+		return nil, err
+	}
+	state := NewAccountState()
+	if err := json.Unmarshal(blob, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (st *kvStore) saveState(id string, state *AccountState) error {
+	blob, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return st.kv.Set([]byte(id), blob)
+}
+
+// mutate loads the state of the given account, applies the mutation
+// and writes the state back.
+func (st *kvStore) mutate(id string, mutate func(state *AccountState) error) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return err
+	}
+	if err := mutate(state); err != nil {
+		return err
+	}
+	return st.saveState(id, state)
+}
+
+func (st *kvStore) LoadAccount(id string) (*AccountState, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := st.saveState(id, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (st *kvStore) RemoveAccount(id string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if _, err := st.kv.Get([]byte(id)); err != nil {
+		if errors.Cause(err) == ErrKeyNotFound {
+			return ErrAccountNotFound
+		}
+		return err
+	}
+	return st.kv.Delete([]byte(id))
+}
+
+func (st *kvStore) ReadIndex(id string) (uint64, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return 0, err
+	}
+	return state.KeyIndex, nil
+}
+
+func (st *kvStore) WriteIndex(id string, index uint64) error {
+	return st.mutate(id, func(state *AccountState) error {
+		state.KeyIndex = index
+		return nil
+	})
+}
+
+func (st *kvStore) AddDepositAddress(id string, index uint64, conditions *deposit.Conditions) error {
+	return st.mutate(id, func(state *AccountState) error {
+		state.DepositAddresses[index] = conditions
+		return nil
+	})
+}
+
+func (st *kvStore) RemoveDepositAddress(id string, index uint64) error {
+	return st.mutate(id, func(state *AccountState) error {
+		if _, ok := state.DepositAddresses[index]; !ok {
+			return ErrDepositAddressNotFound
+		}
+		delete(state.DepositAddresses, index)
+		return nil
+	})
+}
+
+func (st *kvStore) GetDepositAddresses(id string) (map[uint64]*deposit.Conditions, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	return state.DepositAddresses, nil
+}
+
+func (st *kvStore) AddPendingTransfer(id string, originTailTxHash Hash, bundleTrytes []Trytes) error {
+	return st.mutate(id, func(state *AccountState) error {
+		state.PendingTransfers[originTailTxHash] = &PendingTransfer{
+			Bundle: bundleTrytes,
+			Tails:  Hashes{originTailTxHash},
+		}
+		return nil
+	})
+}
+
+func (st *kvStore) RemovePendingTransfer(id string, originTailTxHash Hash) error {
+	return st.mutate(id, func(state *AccountState) error {
+		if _, ok := state.PendingTransfers[originTailTxHash]; !ok {
+			return ErrPendingTransferNotFound
+		}
+		delete(state.PendingTransfers, originTailTxHash)
+		return nil
+	})
+}
+
+func (st *kvStore) AddTailHash(id string, originTailTxHash Hash, newTailTxHash Hash) error {
+	return st.mutate(id, func(state *AccountState) error {
+		pendingTransfer, ok := state.PendingTransfers[originTailTxHash]
+		if !ok {
+			return ErrPendingTransferNotFound
+		}
+		pendingTransfer.Tails = append(pendingTransfer.Tails, newTailTxHash)
+		return nil
+	})
+}
+
+func (st *kvStore) AddPromotion(id string, originTailTxHash Hash) (uint64, error) {
+	var count uint64
+	err := st.mutate(id, func(state *AccountState) error {
+		pendingTransfer, ok := state.PendingTransfers[originTailTxHash]
+		if !ok {
+			return ErrPendingTransferNotFound
+		}
+		pendingTransfer.Promotions++
+		count = pendingTransfer.Promotions
+		return nil
+	})
+	return count, err
+}
+
+func (st *kvStore) GetPendingTransfers(id string) (map[string]*PendingTransfer, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	return state.PendingTransfers, nil
+}
+
+func (st *kvStore) AddInFlightTransfer(id string, bundleHash Hash, transfer *InFlightTransfer) error {
+	return st.mutate(id, func(state *AccountState) error {
+		state.InFlightTransfers[bundleHash] = transfer
+		return nil
+	})
+}
+
+func (st *kvStore) RemoveInFlightTransfer(id string, bundleHash Hash) error {
+	return st.mutate(id, func(state *AccountState) error {
+		if _, ok := state.InFlightTransfers[bundleHash]; !ok {
+			return ErrInFlightTransferNotFound
+		}
+		delete(state.InFlightTransfers, bundleHash)
+		return nil
+	})
+}
+
+func (st *kvStore) GetInFlightTransfers(id string) (map[string]*InFlightTransfer, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	return state.InFlightTransfers, nil
+}
+
+func (st *kvStore) AddDeadLetter(id string, letter *DeadLetter) (uint64, error) {
+	var index uint64
+	err := st.mutate(id, func(state *AccountState) error {
+		index = state.DeadLetterIndex
+		state.DeadLetterIndex++
+		state.DeadLetters[index] = letter
+		return nil
+	})
+	return index, err
+}
+
+func (st *kvStore) RemoveDeadLetter(id string, index uint64) error {
+	return st.mutate(id, func(state *AccountState) error {
+		if _, ok := state.DeadLetters[index]; !ok {
+			return ErrDeadLetterNotFound
+		}
+		delete(state.DeadLetters, index)
+		return nil
+	})
+}
+
+func (st *kvStore) GetDeadLetters(id string) (map[uint64]*DeadLetter, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	return state.DeadLetters, nil
+}
+
+func (st *kvStore) AppendJournalEntry(id string, entry *JournalEntry) (uint64, error) {
+	var sequence uint64
+	err := st.mutate(id, func(state *AccountState) error {
+		entry.Sequence = state.JournalSequence
+		state.JournalSequence++
+		state.Journal = append(state.Journal, entry)
+		sequence = entry.Sequence
+		return nil
+	})
+	return sequence, err
+}
+
+func (st *kvStore) GetJournalEntries(id string, fromSequence uint64) ([]*JournalEntry, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*JournalEntry, 0, len(state.Journal))
+	for _, entry := range state.Journal {
+		if entry.Sequence < fromSequence {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (st *kvStore) ExportAccount(id string) (*ExportedAccountState, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, err := st.loadState(id)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportedAccountState{ID: id, Date: st.clock.Now().Unix(), State: *state}, nil
+}
+
+func (st *kvStore) ImportAccount(state *ExportedAccountState) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	stateCopy := state.State
+	return st.saveState(state.ID, &stateCopy)
+}
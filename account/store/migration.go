@@ -0,0 +1,113 @@
+package store
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// SchemaVersion is the version of the account state schema
+// written by this version of the library.
+const SchemaVersion = 1
+
+var (
+	// ErrSchemaTooNew gets returned when a store contains a state with a newer
+	// schema version than this version of the library understands.
+	ErrSchemaTooNew = errors.New("account state was written by a newer library version")
+	// ErrMigrationGap gets returned when the registered migrations can not
+	// upgrade a state contiguously to the current schema version.
+	ErrMigrationGap = errors.New("no contiguous migration path to the current schema version")
+)
+
+// Migration upgrades an account state from TargetVersion-1 to TargetVersion.
+type Migration struct {
+	// The schema version the migration upgrades to.
+	TargetVersion uint64
+	// A human readable description of what the migration does.
+	Description string
+	// Apply mutates the given state to conform to TargetVersion.
+	Apply func(state *AccountState) error
+}
+
+var migrations = map[uint64]Migration{}
+
+// RegisterMigration registers a migration with the migration runner.
+// Migrations shipped by the library are registered automatically;
+// custom store implementations may register additional ones.
+func RegisterMigration(migration Migration) {
+	migrations[migration.TargetVersion] = migration
+}
+
+// MigrateOptions configure a Migrate() run.
+type MigrateOptions struct {
+	// If true, migrations are applied to a copy of the state
+	// and the result is not written back to the store.
+	DryRun bool
+	// If non nil, a JSON export of the state as it was before
+	// the migration is written to this writer.
+	Backup io.Writer
+	// The time source used to date the migrated state.
+	// Defaults to the system clock.
+	Clock Clock
+}
+
+// Migrate upgrades the persisted state of the account with the given id to
+// the current schema version by applying all registered migrations in order.
+// It returns the target versions of the migrations which were applied.
+// Stores should call Migrate() when opening an account persisted by an older
+// library version.
+func Migrate(st Store, id string, opts MigrateOptions) ([]uint64, error) {
+	exported, err := st.ExportAccount(id)
+	if err != nil {
+		return nil, err
+	}
+	state := &exported.State
+
+	if state.Version > SchemaVersion {
+		return nil, errors.Wrapf(ErrSchemaTooNew, "state version %d, library version %d", state.Version, SchemaVersion)
+	}
+	if state.Version == SchemaVersion {
+		return nil, nil
+	}
+
+	if opts.Backup != nil {
+		if err := json.NewEncoder(opts.Backup).Encode(exported); err != nil {
+			return nil, errors.Wrap(err, "unable to write backup")
+		}
+	}
+
+	pending := make([]Migration, 0, SchemaVersion-state.Version)
+	for version := state.Version + 1; version <= SchemaVersion; version++ {
+		migration, ok := migrations[version]
+		if !ok {
+			return nil, errors.Wrapf(ErrMigrationGap, "missing migration to version %d", version)
+		}
+		pending = append(pending, migration)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].TargetVersion < pending[j].TargetVersion })
+
+	applied := make([]uint64, 0, len(pending))
+	for _, migration := range pending {
+		if err := migration.Apply(state); err != nil {
+			return applied, errors.Wrapf(err, "migration to version %d failed", migration.TargetVersion)
+		}
+		state.Version = migration.TargetVersion
+		applied = append(applied, migration.TargetVersion)
+	}
+
+	if opts.DryRun {
+		return applied, nil
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = SystemClock()
+	}
+	exported.Date = clock.Now().Unix()
+	if err := st.ImportAccount(exported); err != nil {
+		return applied, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,112 @@
+package store_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/iotaledger/iota.go/account/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// frozenClock is a Clock stuck at a fixed point in time.
+type frozenClock time.Time
+
+func (fc frozenClock) Now() time.Time { return time.Time(fc) }
+
+var testTime = time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// The specs in this container run in declaration order: registered
+// migrations can not be unregistered again, so the migration gap is
+// verified before the migration to the current version is registered.
+var _ = Describe("Migrate()", func() {
+
+	// importOutdated persists a state with the given schema version.
+	importOutdated := func(st store.Store, id string, version uint64) {
+		state := store.NewAccountState()
+		state.Version = version
+		state.KeyIndex = 7
+		Expect(st.ImportAccount(&store.ExportedAccountState{
+			ID: id, Date: testTime.Unix(), State: *state,
+		})).ToNot(HaveOccurred())
+	}
+
+	newStore := func() store.Store {
+		return store.NewInMemoryStore(frozenClock(testTime))
+	}
+
+	It("should return ErrMigrationGap when no migration path is registered", func() {
+		st := newStore()
+		importOutdated(st, "a", 0)
+		_, err := store.Migrate(st, "a", store.MigrateOptions{})
+		Expect(errors.Cause(err)).To(Equal(store.ErrMigrationGap))
+	})
+
+	It("should apply registered migrations and persist the upgraded state", func() {
+		store.RegisterMigration(store.Migration{
+			TargetVersion: store.SchemaVersion,
+			Description:   "bumps the key index past the legacy reserved range",
+			Apply: func(state *store.AccountState) error {
+				state.KeyIndex += 1000
+				return nil
+			},
+		})
+
+		st := newStore()
+		importOutdated(st, "a", 0)
+		applied, err := store.Migrate(st, "a", store.MigrateOptions{Clock: frozenClock(testTime)})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(Equal([]uint64{store.SchemaVersion}))
+
+		exported, err := st.ExportAccount("a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exported.State.Version).To(Equal(uint64(store.SchemaVersion)))
+		Expect(exported.State.KeyIndex).To(Equal(uint64(1007)))
+		Expect(exported.Date).To(Equal(testTime.Unix()))
+	})
+
+	It("should be a no-op for a state which is already current", func() {
+		st := newStore()
+		importOutdated(st, "a", store.SchemaVersion)
+		applied, err := store.Migrate(st, "a", store.MigrateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(BeEmpty())
+	})
+
+	It("should not persist the result of a dry run", func() {
+		st := newStore()
+		importOutdated(st, "a", 0)
+		applied, err := store.Migrate(st, "a", store.MigrateOptions{DryRun: true})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(applied).To(Equal([]uint64{store.SchemaVersion}))
+
+		exported, err := st.ExportAccount("a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exported.State.Version).To(Equal(uint64(0)))
+		Expect(exported.State.KeyIndex).To(Equal(uint64(7)))
+	})
+
+	It("should write a backup of the state as it was before the migration", func() {
+		st := newStore()
+		importOutdated(st, "a", 0)
+		var backup bytes.Buffer
+		_, err := store.Migrate(st, "a", store.MigrateOptions{Backup: &backup, Clock: frozenClock(testTime)})
+		Expect(err).ToNot(HaveOccurred())
+
+		var backedUp store.ExportedAccountState
+		Expect(json.NewDecoder(&backup).Decode(&backedUp)).ToNot(HaveOccurred())
+		Expect(backedUp.ID).To(Equal("a"))
+		Expect(backedUp.State.Version).To(Equal(uint64(0)))
+		Expect(backedUp.State.KeyIndex).To(Equal(uint64(7)))
+	})
+
+	It("should reject states written by a newer library version", func() {
+		st := newStore()
+		importOutdated(st, "a", store.SchemaVersion+1)
+		_, err := store.Migrate(st, "a", store.MigrateOptions{})
+		Expect(errors.Cause(err)).To(Equal(store.ErrSchemaTooNew))
+	})
+})
@@ -0,0 +1,179 @@
+// Package store provides the Store interface which is used by accounts
+// to persist their state, plus an in-memory reference implementation.
+package store
+
+import (
+	"github.com/iotaledger/iota.go/account/deposit"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrAccountNotFound gets returned when an account could not be found in the store.
+	ErrAccountNotFound = errors.New("account not found in store")
+	// ErrPendingTransferNotFound gets returned when a pending transfer could not be found in the store.
+	ErrPendingTransferNotFound = errors.New("pending transfer not found in store")
+	// ErrDepositAddressNotFound gets returned when a deposit address could not be found in the store.
+	ErrDepositAddressNotFound = errors.New("deposit address not found in store")
+	// ErrDeadLetterNotFound gets returned when a dead letter could not be found in the store.
+	ErrDeadLetterNotFound = errors.New("dead letter not found in store")
+	// ErrInFlightTransferNotFound gets returned when an in-flight transfer could not be found in the store.
+	ErrInFlightTransferNotFound = errors.New("in-flight transfer not found in store")
+)
+
+// PendingTransfer defines a pending transfer in the store
+// which is being monitored for confirmation.
+type PendingTransfer struct {
+	// The trytes making up the bundle of the pending transfer.
+	Bundle []Trytes `json:"bundle"`
+	// The tail transaction hashes of the attachments of the bundle.
+	Tails Hashes `json:"tails"`
+	// The amount of promotions done for the pending transfer.
+	Promotions uint64 `json:"promotions"`
+}
+
+// AccountState is the aggregated state of an account in the store.
+type AccountState struct {
+	// The version of the schema with which the state was persisted.
+	Version uint64 `json:"version"`
+	// The index of the next key to use for deriving new deposit addresses.
+	KeyIndex uint64 `json:"key_index"`
+	// The deposit addresses being monitored, keyed by their key index.
+	DepositAddresses map[uint64]*deposit.Conditions `json:"deposit_addresses"`
+	// The pending transfers being monitored, keyed by the hash of their origin tail transaction.
+	PendingTransfers map[string]*PendingTransfer `json:"pending_transfers"`
+	// The transfers whose send pipeline has not completed yet, keyed by bundle hash.
+	InFlightTransfers map[string]*InFlightTransfer `json:"in_flight_transfers"`
+	// The undeliverable event payloads kept for redelivery, keyed by their index.
+	DeadLetters map[uint64]*DeadLetter `json:"dead_letters"`
+	// The append-only journal of emitted events.
+	Journal []*JournalEntry `json:"journal"`
+	// The sequence number assigned to the next journal entry.
+	JournalSequence uint64 `json:"journal_sequence"`
+	// The index under which the next dead letter is stored.
+	DeadLetterIndex uint64 `json:"dead_letter_index"`
+}
+
+// NewAccountState creates a new empty AccountState.
+func NewAccountState() *AccountState {
+	return &AccountState{
+		Version:           SchemaVersion,
+		DepositAddresses:  map[uint64]*deposit.Conditions{},
+		PendingTransfers:  map[string]*PendingTransfer{},
+		InFlightTransfers: map[string]*InFlightTransfer{},
+		DeadLetters:       map[uint64]*DeadLetter{},
+	}
+}
+
+// IsNew tells whether the account state is new, i.e. has no usage yet.
+func (state *AccountState) IsNew() bool {
+	return state.KeyIndex == 0 && len(state.DepositAddresses) == 0 && len(state.PendingTransfers) == 0
+}
+
+// InFlightTransfer is a transfer whose send pipeline has not completed yet.
+// It is persisted before each irreversible step so that an account which is
+// shut down (or crashes) mid-send can safely roll the transfer forward on
+// the next start instead of leaving half-broadcast bundles behind.
+type InFlightTransfer struct {
+	// The trytes of the prepared (and possibly attached) bundle.
+	Trytes []Trytes `json:"trytes"`
+	// Whether the bundle has already been attached (Proof-of-Work done).
+	Attached bool `json:"attached"`
+	// The unix timestamp in seconds at which the transfer was prepared.
+	CreatedAt int64 `json:"created_at"`
+}
+
+// JournalEntry is a single persisted account event in the append-only
+// event journal.
+type JournalEntry struct {
+	// The monotonically increasing sequence number of the entry.
+	Sequence uint64 `json:"sequence"`
+	// The type of the emitted event.
+	Event int32 `json:"event"`
+	// The JSON serialized event data.
+	Data []byte `json:"data"`
+	// The unix timestamp in seconds at which the event was emitted.
+	EmittedAt int64 `json:"emitted_at"`
+}
+
+// DeadLetter is an event which could not be delivered to an external
+// consumer (e.g. a webhook endpoint) and is kept for later redelivery.
+type DeadLetter struct {
+	// The target to which the delivery failed, e.g. a webhook URL.
+	Target string `json:"target"`
+	// The serialized payload which should have been delivered.
+	Payload []byte `json:"payload"`
+	// The amount of delivery attempts made.
+	Attempts uint64 `json:"attempts"`
+	// The unix timestamp in seconds of the last delivery attempt.
+	LastAttempt int64 `json:"last_attempt"`
+}
+
+// Store defines a persistence layer for account states.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// LoadAccount loads the state of the account with the given id,
+	// creating a new empty state if none exists yet.
+	LoadAccount(id string) (*AccountState, error)
+	// RemoveAccount removes the state of the account with the given id.
+	RemoveAccount(id string) error
+	// ReadIndex reads the current key index of the account with the given id.
+	ReadIndex(id string) (uint64, error)
+	// WriteIndex stores the given key index for the account with the given id.
+	WriteIndex(id string, index uint64) error
+	// AddDepositAddress stores the given deposit address conditions under the given key index.
+	AddDepositAddress(id string, index uint64, conditions *deposit.Conditions) error
+	// RemoveDepositAddress removes the deposit address stored under the given key index.
+	RemoveDepositAddress(id string, index uint64) error
+	// GetDepositAddresses returns all deposit addresses of the account with the given id.
+	GetDepositAddresses(id string) (map[uint64]*deposit.Conditions, error)
+	// AddPendingTransfer stores a new pending transfer originating from the given tail transaction.
+	AddPendingTransfer(id string, originTailTxHash Hash, bundleTrytes []Trytes) error
+	// RemovePendingTransfer removes the pending transfer originating from the given tail transaction.
+	RemovePendingTransfer(id string, originTailTxHash Hash) error
+	// AddTailHash adds a new tail transaction hash (of a reattachment) to the given pending transfer.
+	AddTailHash(id string, originTailTxHash Hash, newTailTxHash Hash) error
+	// AddPromotion increments the promotion count of the given pending transfer
+	// and returns the new count.
+	AddPromotion(id string, originTailTxHash Hash) (uint64, error)
+	// GetPendingTransfers returns all pending transfers of the account with the given id.
+	GetPendingTransfers(id string) (map[string]*PendingTransfer, error)
+	// AddInFlightTransfer stores the given in-flight transfer under the given bundle hash,
+	// overriding a previously stored stage of the same transfer.
+	AddInFlightTransfer(id string, bundleHash Hash, transfer *InFlightTransfer) error
+	// RemoveInFlightTransfer removes the in-flight transfer with the given bundle hash.
+	RemoveInFlightTransfer(id string, bundleHash Hash) error
+	// GetInFlightTransfers returns all in-flight transfers of the account with the given id.
+	GetInFlightTransfers(id string) (map[string]*InFlightTransfer, error)
+	// AddDeadLetter stores an undeliverable event payload for later redelivery
+	// and returns the index under which it was stored.
+	AddDeadLetter(id string, letter *DeadLetter) (uint64, error)
+	// RemoveDeadLetter removes the dead letter stored under the given index.
+	RemoveDeadLetter(id string, index uint64) error
+	// GetDeadLetters returns all dead letters of the account with the given id,
+	// keyed by their index.
+	GetDeadLetters(id string) (map[uint64]*DeadLetter, error)
+	// AppendJournalEntry appends the given entry to the account's event journal,
+	// assigns it the next sequence number and returns that number.
+	AppendJournalEntry(id string, entry *JournalEntry) (uint64, error)
+	// GetJournalEntries returns all journal entries of the account with a
+	// sequence number of at least fromSequence, in sequence order.
+	GetJournalEntries(id string, fromSequence uint64) ([]*JournalEntry, error)
+	// ExportAccount exports the full state of the account with the given id.
+	ExportAccount(id string) (*ExportedAccountState, error)
+	// ImportAccount imports the given state, overriding any existing state
+	// of the same account.
+	ImportAccount(state *ExportedAccountState) error
+}
+
+// ExportedAccountState is the exported state of an account,
+// used for backups, transfers between stores and migrations.
+type ExportedAccountState struct {
+	// The id of the account.
+	ID string `json:"id"`
+	// The unix timestamp in seconds at which the state was exported.
+	Date int64 `json:"date"`
+	// The state of the account.
+	State AccountState `json:"state"`
+}
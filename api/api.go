@@ -46,7 +46,7 @@ func ComposeAPI(settings Settings, createProvider ...CreateProviderFunc) (*API,
 		return nil, err
 	}
 
-	api := &API{provider: provider}
+	api := &API{provider: classifyProvider(provider)}
 	if settings.ProofOfWorkFunc() != nil {
 		api.localProofOfWorkFunc = settings.ProofOfWorkFunc()
 	}
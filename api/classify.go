@@ -0,0 +1,53 @@
+package api
+
+import (
+	"reflect"
+
+	"github.com/iotaledger/iota.go/ierrors"
+)
+
+// classifyProvider decorates the given provider so that every error returned
+// from the command path is classified into an *ierrors.Error carrying its
+// stable code and kind plus the IRI command during which it occurred.
+// ComposeAPI() wraps every provider with it, so callers can distinguish
+// validation, network and node errors via the ierrors package.
+func classifyProvider(provider Provider) Provider {
+	return &classifyingProvider{inner: provider}
+}
+
+type classifyingProvider struct {
+	inner Provider
+}
+
+func (cp *classifyingProvider) Send(cmd interface{}, out interface{}) error {
+	err := cp.inner.Send(cmd, out)
+	if err == nil {
+		return nil
+	}
+	if command := commandName(cmd); command != "" {
+		return ierrors.Wrap(err, ierrors.WithCommand(command))
+	}
+	return ierrors.Wrap(err)
+}
+
+func (cp *classifyingProvider) SetSettings(settings interface{}) error {
+	return ierrors.Wrap(cp.inner.SetSettings(settings))
+}
+
+// commandName extracts the IRI command name from the Command field
+// of the given command payload.
+func commandName(cmd interface{}) string {
+	value := reflect.Indirect(reflect.ValueOf(cmd))
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	field := value.FieldByName("Command")
+	if !field.IsValid() {
+		return ""
+	}
+	command, ok := field.Interface().(IRICommand)
+	if !ok {
+		return ""
+	}
+	return string(command)
+}
@@ -515,8 +515,8 @@ func (api *API) PrepareTransfers(seed Trytes, transfers bundle.Transfers, option
 		}
 
 		// filter out inputs which are already spent
-		inputAddresses := make(Hashes, len(props.Inputs))
-		for i := range props.Inputs {
+		inputAddresses := make(Hashes, len(inputs.Inputs))
+		for i := range inputs.Inputs {
 			inputAddresses[i] = inputs.Inputs[i].Address
 		}
 
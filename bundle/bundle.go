@@ -6,8 +6,10 @@ import (
 	. "github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/kerl"
 	"github.com/iotaledger/iota.go/signing"
+	"github.com/iotaledger/iota.go/sponge"
 	"github.com/iotaledger/iota.go/transaction"
 	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/iotaledger/iota.go/units"
 	"github.com/pkg/errors"
 	"math"
 	"time"
@@ -72,6 +74,31 @@ var EmptyTransfer = Transfer{
 	Address: NullHashTrytes,
 }
 
+// ParseTransferAmount parses a human readable amount string with an optional
+// unit suffix, e.g. "2.5 Gi", into its value in iotas, validated against the
+// total supply.
+func ParseTransferAmount(amount string) (uint64, error) {
+	value, err := units.ParseAmount(amount)
+	if err != nil {
+		return 0, err
+	}
+	if value > TotalSupply {
+		return 0, ErrAmountExceedsTotalSupply
+	}
+	return value, nil
+}
+
+// NewTransferWithAmount creates a new transfer with its value given as a
+// human readable amount string, so call sites don't have to do their own
+// unit math.
+func NewTransferWithAmount(address Hash, amount string, message Trytes, tag Trytes) (*Transfer, error) {
+	value, err := ParseTransferAmount(amount)
+	if err != nil {
+		return nil, err
+	}
+	return &Transfer{Address: address, Value: value, Message: message, Tag: tag}, nil
+}
+
 // TransfersToBundleEntries translates transfers to bundle entries.
 func TransfersToBundleEntries(timestamp uint64, transfers ...Transfer) (BundleEntries, error) {
 	entries := BundleEntries{}
@@ -172,7 +199,7 @@ func AddEntry(txs Bundle, bndlEntry BundleEntry) Bundle {
 
 // Finalize finalizes the bundle by calculating the bundle hash and setting it on each transaction
 // bundle hash field.
-func Finalize(bundle Bundle) (Bundle, error) {
+func Finalize(bundle Bundle, spongeFunc ...sponge.SpongeFunction) (Bundle, error) {
 	var valueTrits = make([]Trits, len(bundle))
 	var timestampTrits = make([]Trits, len(bundle))
 	var currentIndexTrits = make([]Trits, len(bundle))
@@ -186,9 +213,11 @@ func Finalize(bundle Bundle) (Bundle, error) {
 		obsoleteTagTrits[i] = PadTrits(MustTrytesToTrits(bundle[i].ObsoleteTag), 81)
 	}
 
+	h := sponge.GetSpongeFunc(spongeFunc, kerl.NewKerl())
+
 	var bundleHash Hash
 	for {
-		k := kerl.NewKerl()
+		h.Reset()
 
 		for i := 0; i < len(bundle); i++ {
 			relevantTritsForBundleHash := MustTrytesToTrits(
@@ -199,10 +228,12 @@ func Finalize(bundle Bundle) (Bundle, error) {
 					MustTritsToTrytes(currentIndexTrits[i]) +
 					MustTritsToTrytes(lastIndexTrits),
 			)
-			k.Absorb(relevantTritsForBundleHash)
+			if err := h.AbsorbTrits(relevantTritsForBundleHash); err != nil {
+				return nil, err
+			}
 		}
 
-		bundleHashTrits, err := k.Squeeze(HashTrinarySize)
+		bundleHashTrits, err := h.SqueezeTrits(HashTrinarySize)
 		if err != nil {
 			return nil, err
 		}
@@ -283,11 +314,12 @@ func ValidateBundleSignatures(bundle Bundle) (bool, error) {
 
 // ValidBundle checks if a bundle is syntactically valid.
 // Validates signatures and overall structure.
-func ValidBundle(bundle Bundle) error {
+func ValidBundle(bundle Bundle, spongeFunc ...sponge.SpongeFunction) error {
 	var totalSum int64
 
 	sigs := make(map[Hash][]Trytes)
-	k := kerl.NewKerl()
+	h := sponge.GetSpongeFunc(spongeFunc, kerl.NewKerl())
+	h.Reset()
 
 	lastIndex := uint64(len(bundle) - 1)
 	for i := range bundle {
@@ -302,7 +334,9 @@ func ValidBundle(bundle Bundle) error {
 		}
 
 		txTrits := MustTrytesToTrits(transaction.MustTransactionToTrytes(tx)[2187 : 2187+162])
-		k.Absorb(txTrits)
+		if err := h.AbsorbTrits(txTrits); err != nil {
+			return err
+		}
 
 		// continue if output or signature tx
 		if tx.Value >= 0 {
@@ -330,7 +364,7 @@ func ValidBundle(bundle Bundle) error {
 		return errors.Wrapf(ErrInvalidBundle, "bundle total sum should be 0 but got %d", totalSum)
 	}
 
-	bundleHashTrits, err := k.Squeeze(HashTrinarySize)
+	bundleHashTrits, err := h.SqueezeTrits(HashTrinarySize)
 	if err != nil {
 		return err
 	}
@@ -292,4 +292,25 @@ var _ = Describe("Bundle", func() {
 			Expect(TailTransactionHash(Bundle{})).To(Equal(""))
 		})
 	})
+
+	Context("NewTransferWithAmount()", func() {
+		addr := strings.Repeat("A", 81)
+
+		It("converts the amount string exactly to iotas", func() {
+			transfer, err := NewTransferWithAmount(addr, "2.5 Gi", "", tag)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(transfer.Value).To(Equal(uint64(2500000000)))
+			Expect(transfer.Address).To(Equal(addr))
+		})
+
+		It("rejects amounts above the total supply", func() {
+			_, err := NewTransferWithAmount(addr, "2780 Pi", "", tag)
+			Expect(err).To(Equal(ErrAmountExceedsTotalSupply))
+		})
+
+		It("rejects malformed amounts", func() {
+			_, err := NewTransferWithAmount(addr, "2.5 Zi", "", tag)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
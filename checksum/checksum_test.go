@@ -75,4 +75,54 @@ var _ = Describe("Checksum", func() {
 		})
 	})
 
+	Context("ValidChecksum()", func() {
+		It("accepts a valid address with checksum", func() {
+			Expect(ValidChecksum(addrsWithChecksums[0])).ToNot(HaveOccurred())
+		})
+
+		It("rejects an address with a wrong checksum", func() {
+			Expect(ValidChecksum(addrs[0] + checksum[1])).To(Equal(ErrInvalidChecksum))
+		})
+	})
+
+	Context("ValidChecksums()", func() {
+		It("returns one result per address", func() {
+			results := ValidChecksums([]Trytes{addrsWithChecksums[0], addrs[1] + checksum[0]})
+			Expect(results[0]).ToNot(HaveOccurred())
+			Expect(results[1]).To(Equal(ErrInvalidChecksum))
+		})
+	})
+
+	Context("CorrectChecksum()", func() {
+		It("returns a valid address unchanged", func() {
+			corrected, changed, err := CorrectChecksum(addrsWithChecksums[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(corrected).To(Equal(addrsWithChecksums[0]))
+		})
+
+		It("corrects a single mistyped tryte in the address part", func() {
+			mistyped := "A" + addrsWithChecksums[0][1:]
+			if mistyped == addrsWithChecksums[0] {
+				mistyped = "B" + addrsWithChecksums[0][1:]
+			}
+			corrected, changed, err := CorrectChecksum(mistyped)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(corrected).To(Equal(addrsWithChecksums[0]))
+		})
+
+		It("corrects a single mistyped tryte in the checksum part", func() {
+			pos := len(addrsWithChecksums[0]) - 1
+			mistyped := addrsWithChecksums[0][:pos] + "A"
+			if mistyped == addrsWithChecksums[0] {
+				mistyped = addrsWithChecksums[0][:pos] + "B"
+			}
+			corrected, changed, err := CorrectChecksum(mistyped)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(corrected).To(Equal(addrsWithChecksums[0]))
+		})
+	})
+
 })
@@ -0,0 +1,95 @@
+package checksum
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrChecksumCorrectionFailed gets returned when an address can not be
+	// corrected by changing a single tryte.
+	ErrChecksumCorrectionFailed = errors.New("unable to correct the address with a single tryte change")
+	// ErrAmbiguousCorrection gets returned when more than one single tryte
+	// change yields a valid address.
+	ErrAmbiguousCorrection = errors.New("multiple single tryte corrections are possible")
+)
+
+// ValidChecksum checks whether the checksum of the given address with
+// checksum matches its address part.
+func ValidChecksum(input Trytes) error {
+	if !guards.IsTrytesOfExactLength(input, AddressWithChecksumTrytesSize) {
+		return ErrInvalidAddress
+	}
+	withChecksum, err := AddChecksum(input[:HashTrytesSize], true, AddressChecksumTrytesSize)
+	if err != nil {
+		return err
+	}
+	if withChecksum != input {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// ValidChecksums is a wrapper function around ValidChecksum for multiple
+// trytes strings, returning one result per input.
+func ValidChecksums(inputs []Trytes) []error {
+	results := make([]error, len(inputs))
+	for i, input := range inputs {
+		results[i] = ValidChecksum(input)
+	}
+	return results
+}
+
+// CorrectChecksum corrects a single mistyped tryte in the given address with
+// checksum. The 9-tryte checksum carries enough redundancy to not only
+// detect but also locate a single tryte transcription error by exhaustively
+// re-validating every single tryte substitution, so no additional trailer
+// beyond the standard checksum is needed and corrected addresses stay
+// compatible with the usual 90-tryte format. It returns the corrected
+// address and whether a correction was applied. If no single tryte change
+// yields a valid address, or more than one does, an error is returned.
+func CorrectChecksum(input Trytes) (Trytes, bool, error) {
+	if !guards.IsTrytesOfExactLength(input, AddressWithChecksumTrytesSize) {
+		return "", false, ErrInvalidAddress
+	}
+	if err := ValidChecksum(input); err == nil {
+		return input, false, nil
+	}
+
+	var corrected Trytes
+	for pos := 0; pos < len(input); pos++ {
+		for _, c := range TryteAlphabet {
+			if byte(c) == input[pos] {
+				continue
+			}
+			candidate := input[:pos] + Trytes(c) + input[pos+1:]
+			if err := ValidChecksum(candidate); err != nil {
+				continue
+			}
+			if corrected != "" {
+				return "", false, ErrAmbiguousCorrection
+			}
+			corrected = candidate
+		}
+	}
+	if corrected == "" {
+		return "", false, ErrChecksumCorrectionFailed
+	}
+	return corrected, true, nil
+}
+
+// CorrectChecksums is a wrapper function around CorrectChecksum for multiple
+// trytes strings.
+func CorrectChecksums(inputs []Trytes) ([]Trytes, error) {
+	correctedInputs := make([]Trytes, len(inputs))
+	for i, input := range inputs {
+		corrected, _, err := CorrectChecksum(input)
+		if err != nil {
+			return nil, err
+		}
+		correctedInputs[i] = corrected
+	}
+	return correctedInputs, nil
+}
@@ -0,0 +1,321 @@
+// Command iota exposes the library on the command line: seed and address
+// generation, balance checks, preparing/signing/attaching transfers
+// (including offline signing via trytes files), local PoW benchmarks and
+// tailing new transactions on an address.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/mobile"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "seed":
+		err = cmdSeed()
+	case "addresses":
+		err = cmdAddresses(os.Args[2:])
+	case "balance":
+		err = cmdBalance(os.Args[2:])
+	case "prepare":
+		err = cmdPrepare(os.Args[2:])
+	case "attach":
+		err = cmdAttach(os.Args[2:])
+	case "powbench":
+		err = cmdPoWBench(os.Args[2:])
+	case "tail":
+		err = cmdTail(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: iota <command> [flags]
+
+commands:
+  seed       generate a new random seed
+  addresses  derive addresses from a seed
+  balance    query the balance of addresses
+  prepare    prepare and sign a transfer (writes bundle trytes to a file)
+  attach     attach previously prepared bundle trytes to the Tangle
+  powbench   benchmark the local Proof-of-Work implementations
+  tail       continuously print new transactions on an address`)
+}
+
+func cmdSeed() error {
+	seed, err := mobile.GenerateSeed()
+	if err != nil {
+		return err
+	}
+	fmt.Println(seed)
+	return nil
+}
+
+func cmdAddresses(args []string) error {
+	flags := flag.NewFlagSet("addresses", flag.ExitOnError)
+	seed := flags.String("seed", "", "the seed to derive addresses from (falls back to the IOTA_SEED environment variable)")
+	start := flags.Uint64("start", 0, "the first address index")
+	count := flags.Uint64("count", 1, "the amount of addresses to derive")
+	security := flags.Int("security", int(SecurityLevelMedium), "the security level (1-3)")
+	noChecksum := flags.Bool("no-checksum", false, "omit the checksum")
+	flags.Parse(args)
+
+	addrs, err := address.GenerateAddresses(seedOrEnv(*seed), *start, *count, SecurityLevel(*security), !*noChecksum)
+	if err != nil {
+		return err
+	}
+	for i, addr := range addrs {
+		fmt.Printf("%d: %s\n", *start+uint64(i), addr)
+	}
+	return nil
+}
+
+func cmdBalance(args []string) error {
+	flags := flag.NewFlagSet("balance", flag.ExitOnError)
+	node := flags.String("node", api.DefaultLocalIRIURI, "the node to query")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		return fmt.Errorf("no addresses given")
+	}
+
+	a, err := api.ComposeAPI(api.HTTPClientSettings{URI: *node})
+	if err != nil {
+		return err
+	}
+	addrs := make(Hashes, flags.NArg())
+	for i, arg := range flags.Args() {
+		addrs[i] = Hash(arg)
+	}
+	balances, err := a.GetBalances(addrs, 100)
+	if err != nil {
+		return err
+	}
+	var total uint64
+	for i, balance := range balances.Balances {
+		total += balance
+		fmt.Printf("%s: %d\n", addrs[i], balance)
+	}
+	fmt.Printf("total: %d\n", total)
+	return nil
+}
+
+func cmdPrepare(args []string) error {
+	flags := flag.NewFlagSet("prepare", flag.ExitOnError)
+	node := flags.String("node", "", "the node to resolve inputs with; omit for offline signing with -input")
+	seed := flags.String("seed", "", "the seed to sign with (falls back to the IOTA_SEED environment variable)")
+	target := flags.String("address", "", "the address to send to")
+	amount := flags.String("amount", "0", "the amount to send, e.g. \"2.5 Gi\"")
+	tag := flags.String("tag", "", "the tag to use")
+	message := flags.String("message", "", "the message trytes to use")
+	remainder := flags.String("remainder", "", "the remainder address for offline signing")
+	out := flags.String("out", "bundle.trytes", "the file to write the bundle trytes to")
+	var inputs inputFlags
+	flags.Var(&inputs, "input", "an input for offline signing as address,index,security,balance (repeatable)")
+	flags.Parse(args)
+
+	value, err := bundle.ParseTransferAmount(*amount)
+	if err != nil {
+		return err
+	}
+	transfers := bundle.Transfers{{
+		Address: Hash(*target),
+		Value:   value,
+		Tag:     Trytes(*tag),
+		Message: Trytes(*message),
+	}}
+	opts := api.PrepareTransfersOptions{Inputs: inputs}
+	if *remainder != "" {
+		remainderAddr := Hash(*remainder)
+		opts.RemainderAddress = &remainderAddr
+	}
+
+	settings := api.HTTPClientSettings{}
+	if *node != "" {
+		settings.URI = *node
+	}
+	a, err := api.ComposeAPI(settings)
+	if err != nil {
+		return err
+	}
+	trytes, err := a.PrepareTransfers(seedOrEnv(*seed), transfers, opts)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	for _, tx := range trytes {
+		if _, err := fmt.Fprintln(file, tx); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("wrote %d transaction(s) to %s\n", len(trytes), *out)
+	return nil
+}
+
+func cmdAttach(args []string) error {
+	flags := flag.NewFlagSet("attach", flag.ExitOnError)
+	node := flags.String("node", api.DefaultLocalIRIURI, "the node to attach with")
+	in := flags.String("in", "bundle.trytes", "the file holding the bundle trytes")
+	depth := flags.Uint64("depth", 3, "the depth for tip selection")
+	mwm := flags.Uint64("mwm", 14, "the minimum weight magnitude")
+	local := flags.Bool("local-pow", false, "perform the Proof-of-Work locally")
+	flags.Parse(args)
+
+	trytes, err := readTrytesFile(*in)
+	if err != nil {
+		return err
+	}
+	settings := api.HTTPClientSettings{URI: *node}
+	if *local {
+		_, powFunc := pow.GetFastestProofOfWorkImpl()
+		settings.LocalProofOfWorkFunc = powFunc
+	}
+	a, err := api.ComposeAPI(settings)
+	if err != nil {
+		return err
+	}
+	bndl, err := a.SendTrytes(trytes, *depth, *mwm)
+	if err != nil {
+		return err
+	}
+	fmt.Println("bundle:", bndl[0].Bundle)
+	for _, tx := range bndl {
+		fmt.Printf("%d: %s\n", tx.CurrentIndex, tx.Hash)
+	}
+	return nil
+}
+
+func cmdPoWBench(args []string) error {
+	flags := flag.NewFlagSet("powbench", flag.ExitOnError)
+	mwm := flags.Int("mwm", 14, "the minimum weight magnitude")
+	rounds := flags.Int("rounds", 3, "the amount of rounds per implementation")
+	flags.Parse(args)
+
+	trytes := Trytes(strings.Repeat("9", TransactionTrytesSize))
+	for _, name := range pow.GetProofOfWorkImplementations() {
+		powFunc, err := pow.GetProofOfWorkImpl(name)
+		if err != nil {
+			return err
+		}
+		var total time.Duration
+		for i := 0; i < *rounds; i++ {
+			start := time.Now()
+			if _, err := powFunc(trytes, *mwm); err != nil {
+				return err
+			}
+			total += time.Since(start)
+		}
+		fmt.Printf("%s: %v avg over %d round(s)\n", name, total/time.Duration(*rounds), *rounds)
+	}
+	return nil
+}
+
+func cmdTail(args []string) error {
+	flags := flag.NewFlagSet("tail", flag.ExitOnError)
+	node := flags.String("node", api.DefaultLocalIRIURI, "the node to query")
+	addr := flags.String("address", "", "the address to tail")
+	interval := flags.Duration("interval", 5*time.Second, "the polling interval")
+	flags.Parse(args)
+	if *addr == "" {
+		return fmt.Errorf("no address given")
+	}
+
+	a, err := api.ComposeAPI(api.HTTPClientSettings{URI: *node})
+	if err != nil {
+		return err
+	}
+	seen := map[Hash]struct{}{}
+	for {
+		txs, err := a.FindTransactionObjects(api.FindTransactionsQuery{Addresses: Hashes{Hash(*addr)}})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+		for i := range txs {
+			if _, ok := seen[txs[i].Hash]; ok {
+				continue
+			}
+			seen[txs[i].Hash] = struct{}{}
+			fmt.Printf("%s value=%d tag=%s bundle=%s\n", txs[i].Hash, txs[i].Value, txs[i].Tag, txs[i].Bundle)
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// inputFlags collects repeated -input flags of the form
+// address,index,security,balance.
+type inputFlags []api.Input
+
+func (f *inputFlags) String() string {
+	return fmt.Sprintf("%d input(s)", len(*f))
+}
+
+func (f *inputFlags) Set(value string) error {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return fmt.Errorf("input must be address,index,security,balance")
+	}
+	var index, balance uint64
+	var security int
+	if _, err := fmt.Sscanf(parts[1]+" "+parts[2]+" "+parts[3], "%d %d %d", &index, &security, &balance); err != nil {
+		return err
+	}
+	*f = append(*f, api.Input{
+		Address:  Hash(parts[0]),
+		KeyIndex: index,
+		Security: SecurityLevel(security),
+		Balance:  balance,
+	})
+	return nil
+}
+
+func readTrytesFile(path string) ([]Trytes, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	trytes := []Trytes{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		trytes = append(trytes, Trytes(line))
+	}
+	return trytes, scanner.Err()
+}
+
+func seedOrEnv(seed string) Trytes {
+	if seed != "" {
+		return Trytes(seed)
+	}
+	return Trytes(os.Getenv("IOTA_SEED"))
+}
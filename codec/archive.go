@@ -0,0 +1,124 @@
+package codec
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+const (
+	// archiveVersion is the version byte of the archival frame format.
+	archiveVersion = 1
+
+	// archivePayloadSize is the byte size of a t5b1 encoded transaction.
+	archivePayloadSize = (TransactionTrinarySize + TritsPerByteT5B1 - 1) / TritsPerByteT5B1
+
+	// ArchiveFrameSize is the total byte size of one archival transaction
+	// frame: version byte, t5b1 payload and CRC32 checksum.
+	ArchiveFrameSize = 1 + archivePayloadSize + 4
+)
+
+var (
+	// ErrInvalidArchiveFrame gets returned when bytes do not hold a valid
+	// archival transaction frame.
+	ErrInvalidArchiveFrame = errors.New("invalid archive frame")
+	// ErrArchiveChecksumMismatch gets returned when the checksum of an
+	// archival frame does not match its payload.
+	ErrArchiveChecksumMismatch = errors.New("archive checksum mismatch")
+)
+
+// the checksum polynomial; Castagnoli has hardware support on most platforms.
+var archiveChecksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// EncodeTransaction encodes full transaction trytes into their minimal
+// archival binary form: 5 trits per byte plus checksum framing, 1609 bytes
+// per transaction instead of the 2673 bytes of the raw tryte string. The
+// format is targeted at long-term storage and network transfer.
+func EncodeTransaction(trytes Trytes) ([]byte, error) {
+	if len(trytes) != TransactionTrytesSize {
+		return nil, errors.Wrapf(ErrInvalidTransactionTrytes, "must be %d trytes in size", TransactionTrytesSize)
+	}
+	trits, err := TrytesToTrits(trytes)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := TritsToT5B1Bytes(trits)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, ArchiveFrameSize)
+	frame[0] = archiveVersion
+	copy(frame[1:], payload)
+	binary.LittleEndian.PutUint32(frame[1+archivePayloadSize:], crc32.Checksum(payload, archiveChecksumTable))
+	return frame, nil
+}
+
+// DecodeTransaction decodes an archival frame created by EncodeTransaction()
+// back into transaction trytes, verifying its checksum.
+func DecodeTransaction(frame []byte) (Trytes, error) {
+	if len(frame) != ArchiveFrameSize {
+		return "", errors.Wrapf(ErrInvalidArchiveFrame, "must be %d bytes in size", ArchiveFrameSize)
+	}
+	if frame[0] != archiveVersion {
+		return "", errors.Wrapf(ErrInvalidArchiveFrame, "unknown version %d", frame[0])
+	}
+
+	payload := frame[1 : 1+archivePayloadSize]
+	if crc32.Checksum(payload, archiveChecksumTable) != binary.LittleEndian.Uint32(frame[1+archivePayloadSize:]) {
+		return "", ErrArchiveChecksumMismatch
+	}
+
+	trits, err := T5B1BytesToTrits(payload)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidArchiveFrame, err.Error())
+	}
+	return TritsToTrytes(trits[:TransactionTrinarySize])
+}
+
+// ArchiveEncoder writes archival transaction frames to an io.Writer.
+type ArchiveEncoder struct {
+	w io.Writer
+}
+
+// NewArchiveEncoder creates a new ArchiveEncoder writing to w.
+func NewArchiveEncoder(w io.Writer) *ArchiveEncoder {
+	return &ArchiveEncoder{w: w}
+}
+
+// Write encodes the given transaction trytes and writes one frame.
+func (e *ArchiveEncoder) Write(trytes Trytes) error {
+	frame, err := EncodeTransaction(trytes)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(frame)
+	return err
+}
+
+// ArchiveDecoder reads archival transaction frames from an io.Reader.
+type ArchiveDecoder struct {
+	r     io.Reader
+	frame [ArchiveFrameSize]byte
+}
+
+// NewArchiveDecoder creates a new ArchiveDecoder reading from r.
+func NewArchiveDecoder(r io.Reader) *ArchiveDecoder {
+	return &ArchiveDecoder{r: r}
+}
+
+// Read reads one frame and decodes it into transaction trytes. It returns
+// io.EOF when the stream ends cleanly on a frame boundary.
+func (d *ArchiveDecoder) Read() (Trytes, error) {
+	if _, err := io.ReadFull(d.r, d.frame[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return "", errors.Wrap(ErrInvalidArchiveFrame, "stream ends inside a frame")
+		}
+		return "", err
+	}
+	return DecodeTransaction(d.frame[:])
+}
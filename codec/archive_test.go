@@ -0,0 +1,86 @@
+package codec_test
+
+import (
+	"bytes"
+	"io"
+
+	. "github.com/iotaledger/iota.go/codec"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Archive", func() {
+
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	Context("EncodeTransaction()", func() {
+		It("should round trip a transaction", func() {
+			frame, err := EncodeTransaction(rawTx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(frame).To(HaveLen(ArchiveFrameSize))
+
+			back, err := DecodeTransaction(frame)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(Trytes(rawTx)))
+		})
+
+		It("should return an error for wrongly sized trytes", func() {
+			_, err := EncodeTransaction("TXEFL")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("DecodeTransaction()", func() {
+		It("should detect a corrupted payload", func() {
+			frame, err := EncodeTransaction(rawTx)
+			Expect(err).ToNot(HaveOccurred())
+			frame[100]++
+
+			_, err = DecodeTransaction(frame)
+			Expect(err).To(Equal(ErrArchiveChecksumMismatch))
+		})
+
+		It("should return an error for an unknown version", func() {
+			frame, err := EncodeTransaction(rawTx)
+			Expect(err).ToNot(HaveOccurred())
+			frame[0] = 0
+
+			_, err = DecodeTransaction(frame)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for wrongly sized bytes", func() {
+			_, err := DecodeTransaction(make([]byte, 10))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("streaming", func() {
+		It("should round trip multiple transactions over a stream", func() {
+			var buf bytes.Buffer
+			enc := NewArchiveEncoder(&buf)
+			Expect(enc.Write(rawTx)).To(Succeed())
+			Expect(enc.Write(rawTx)).To(Succeed())
+			Expect(buf.Len()).To(Equal(2 * ArchiveFrameSize))
+
+			dec := NewArchiveDecoder(&buf)
+			for i := 0; i < 2; i++ {
+				trytes, err := dec.Read()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(trytes).To(Equal(Trytes(rawTx)))
+			}
+			_, err := dec.Read()
+			Expect(err).To(Equal(io.EOF))
+		})
+
+		It("should return an error when the stream ends inside a frame", func() {
+			var buf bytes.Buffer
+			Expect(NewArchiveEncoder(&buf).Write(rawTx)).To(Succeed())
+			buf.Truncate(buf.Len() - 1)
+
+			_, err := NewArchiveDecoder(&buf).Read()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
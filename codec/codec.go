@@ -0,0 +1,122 @@
+// Package codec provides helpers for storing JSON encoded Go structs inside
+// the signature/message fragments of zero-value transactions.
+package codec
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/converter"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// the tryte length of an unsigned integer header field
+const uintFieldTryteSize = 9
+
+// the tryte length of the message header (schema version, payload length)
+const headerTryteSize = 2 * uintFieldTryteSize
+
+var (
+	// ErrInvalidEncodedMessage gets returned when message trytes do not hold
+	// an encoded struct.
+	ErrInvalidEncodedMessage = errors.New("invalid encoded message")
+	// ErrSchemaVersionMismatch gets returned when a message was encoded with
+	// a different schema version than expected.
+	ErrSchemaVersionMismatch = errors.New("schema version mismatch")
+)
+
+// Marshal marshals the given value to JSON and encodes it into message
+// trytes tagged with the given schema version. The result can be placed
+// directly into a transfer's message; PrepareTransfers splits it across as
+// many transactions as needed.
+func Marshal(value interface{}, schemaVersion uint64) (Trytes, error) {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	payload, err := converter.ASCIIToTrytes(string(jsonBytes))
+	if err != nil {
+		return "", err
+	}
+	return encodeUint(schemaVersion) + encodeUint(uint64(len(payload))) + payload, nil
+}
+
+// Unmarshal decodes the given message trytes and unmarshals the contained
+// JSON into the given value, returning the schema version the message was
+// encoded with. Padding trytes after the payload are ignored.
+func Unmarshal(msgTrytes Trytes, value interface{}) (uint64, error) {
+	if len(msgTrytes) < headerTryteSize {
+		return 0, ErrInvalidEncodedMessage
+	}
+	schemaVersion := decodeUint(msgTrytes[:uintFieldTryteSize])
+	payloadLen, err := decodeLen(msgTrytes[uintFieldTryteSize:headerTryteSize])
+	if err != nil {
+		return 0, err
+	}
+	if len(msgTrytes)-headerTryteSize < payloadLen {
+		return 0, ErrInvalidEncodedMessage
+	}
+	jsonString, err := converter.TrytesToASCII(msgTrytes[headerTryteSize : headerTryteSize+payloadLen])
+	if err != nil {
+		return 0, errors.Wrap(ErrInvalidEncodedMessage, err.Error())
+	}
+	if err := json.Unmarshal([]byte(jsonString), value); err != nil {
+		return 0, errors.Wrap(ErrInvalidEncodedMessage, err.Error())
+	}
+	return schemaVersion, nil
+}
+
+// UnmarshalExpect decodes like Unmarshal but additionally checks that the
+// message was encoded with the expected schema version.
+func UnmarshalExpect(msgTrytes Trytes, value interface{}, schemaVersion uint64) error {
+	actual, err := Unmarshal(msgTrytes, value)
+	if err != nil {
+		return err
+	}
+	if actual != schemaVersion {
+		return errors.Wrapf(ErrSchemaVersionMismatch, "expected %d, got %d", schemaVersion, actual)
+	}
+	return nil
+}
+
+// BundleMessage reassembles the message trytes carried by the given bundle's
+// transactions, ordered by their index within the bundle.
+func BundleMessage(bndl bundle.Bundle) Trytes {
+	txs := make(transaction.Transactions, len(bndl))
+	copy(txs, bndl)
+	sort.Slice(txs, func(i, j int) bool { return txs[i].CurrentIndex < txs[j].CurrentIndex })
+	var msg Trytes
+	for i := range txs {
+		msg += txs[i].SignatureMessageFragment
+	}
+	return msg
+}
+
+// UnmarshalBundle reassembles the message carried by the given bundle and
+// unmarshals the contained JSON into the given value, returning the schema
+// version the message was encoded with.
+func UnmarshalBundle(bndl bundle.Bundle, value interface{}) (uint64, error) {
+	return Unmarshal(BundleMessage(bndl), value)
+}
+
+func encodeUint(value uint64) Trytes {
+	return MustTritsToTrytes(PadTrits(IntToTrits(int64(value)), uintFieldTryteSize*3))
+}
+
+func decodeUint(trytes Trytes) uint64 {
+	return uint64(TritsToInt(MustTrytesToTrits(trytes)))
+}
+
+// decodeLen decodes the payload length header field, rejecting encodings
+// which do not represent a non negative integer. Messages come from the
+// Tangle, so the field must be validated before it is used for slicing.
+func decodeLen(trytes Trytes) (int, error) {
+	value, err := TritsToIntChecked(MustTrytesToTrits(trytes))
+	if err != nil || value < 0 {
+		return 0, ErrInvalidEncodedMessage
+	}
+	return int(value), nil
+}
@@ -0,0 +1,92 @@
+package codec_test
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/codec"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+type sensorReading struct {
+	Sensor string  `json:"sensor"`
+	Value  float64 `json:"value"`
+}
+
+var _ = Describe("Codec", func() {
+
+	reading := sensorReading{Sensor: "temperature", Value: 23.5}
+
+	Context("Marshal()/Unmarshal()", func() {
+		It("round trips a struct with its schema version", func() {
+			msg, err := Marshal(reading, 2)
+			Expect(err).ToNot(HaveOccurred())
+			decoded := sensorReading{}
+			schemaVersion, err := Unmarshal(msg, &decoded)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schemaVersion).To(Equal(uint64(2)))
+			Expect(decoded).To(Equal(reading))
+		})
+
+		It("ignores padding after the payload", func() {
+			msg, err := Marshal(reading, 1)
+			Expect(err).ToNot(HaveOccurred())
+			padded := msg + strings.Repeat("9", 81)
+			decoded := sensorReading{}
+			_, err = Unmarshal(padded, &decoded)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(Equal(reading))
+		})
+
+		It("rejects truncated messages", func() {
+			msg, err := Marshal(reading, 1)
+			Expect(err).ToNot(HaveOccurred())
+			decoded := sensorReading{}
+			_, err = Unmarshal(msg[:len(msg)-1], &decoded)
+			Expect(errors.Cause(err)).To(Equal(ErrInvalidEncodedMessage))
+		})
+
+		It("rejects a negative payload length encoding", func() {
+			msg, err := Marshal(reading, 1)
+			Expect(err).ToNot(HaveOccurred())
+			// overwrite the payload length field with an encoding of -1
+			negative := MustTritsToTrytes(PadTrits(IntToTrits(-1), 27))
+			tampered := msg[:9] + negative + msg[18:]
+			decoded := sensorReading{}
+			_, err = Unmarshal(tampered, &decoded)
+			Expect(errors.Cause(err)).To(Equal(ErrInvalidEncodedMessage))
+		})
+	})
+
+	Context("UnmarshalExpect()", func() {
+		It("rejects a different schema version", func() {
+			msg, err := Marshal(reading, 1)
+			Expect(err).ToNot(HaveOccurred())
+			decoded := sensorReading{}
+			err = UnmarshalExpect(msg, &decoded, 2)
+			Expect(errors.Cause(err)).To(Equal(ErrSchemaVersionMismatch))
+		})
+	})
+
+	Context("UnmarshalBundle()", func() {
+		It("reassembles the message from the bundle's fragments", func() {
+			msg, err := Marshal(reading, 3)
+			Expect(err).ToNot(HaveOccurred())
+			// split the message across two out of order transactions
+			half := len(msg) / 2
+			bndl := bundle.Bundle{
+				transaction.Transaction{CurrentIndex: 1, SignatureMessageFragment: msg[half:]},
+				transaction.Transaction{CurrentIndex: 0, SignatureMessageFragment: msg[:half]},
+			}
+			decoded := sensorReading{}
+			schemaVersion, err := UnmarshalBundle(bndl, &decoded)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(schemaVersion).To(Equal(uint64(3)))
+			Expect(decoded).To(Equal(reading))
+		})
+	})
+})
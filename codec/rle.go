@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidCompressedBytes gets returned when bytes do not hold valid run-length encoded trytes.
+var ErrInvalidCompressedBytes = errors.New("invalid run-length encoded bytes")
+
+// the minimum run length worth a run token instead of literal bytes.
+const minRunLength = 3
+
+// the high bit marks a byte as a run token holding the tryte value of the run.
+const runTokenFlag = 0x80
+
+// the maximum amount of trytes Decompress is willing to produce. Generous
+// enough for the fragments of any realistic bundle, while bounding the
+// allocation a malicious run length can trigger.
+const maxDecompressedTryteSize = 1 << 24
+
+// Compress run-length encodes the given trytes into bytes. Transaction trytes
+// consist mostly of long runs of '9' (empty signature fragments), which this
+// codec stores as a single token plus a varint run length, typically shrinking
+// an empty transaction to a few dozen bytes.
+func Compress(trytes Trytes) ([]byte, error) {
+	if err := ValidTrytes(trytes); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	var varintBuf [binary.MaxVarintLen64]byte
+	for i := 0; i < len(trytes); {
+		j := i
+		for j < len(trytes) && trytes[j] == trytes[i] {
+			j++
+		}
+		value := byte(strings.IndexByte(TryteAlphabet, trytes[i]))
+		if runLength := j - i; runLength >= minRunLength {
+			buf.WriteByte(value | runTokenFlag)
+			buf.Write(varintBuf[:binary.PutUvarint(varintBuf[:], uint64(runLength))])
+		} else {
+			for ; i < j; i++ {
+				buf.WriteByte(value)
+			}
+		}
+		i = j
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decodes bytes created by Compress() back to the original trytes.
+func Decompress(data []byte) (Trytes, error) {
+	var trytes strings.Builder
+	reader := bytes.NewReader(data)
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			break
+		}
+
+		value := b &^ runTokenFlag
+		if int(value) >= len(TryteAlphabet) {
+			return "", errors.Wrapf(ErrInvalidCompressedBytes, "tryte value %d out of range", value)
+		}
+
+		if b&runTokenFlag == 0 {
+			trytes.WriteByte(TryteAlphabet[value])
+			continue
+		}
+
+		runLength, err := binary.ReadUvarint(reader)
+		if err != nil || runLength == 0 {
+			return "", errors.Wrap(ErrInvalidCompressedBytes, "malformed run length")
+		}
+		if runLength > uint64(maxDecompressedTryteSize-trytes.Len()) {
+			return "", errors.Wrapf(ErrInvalidCompressedBytes, "run length %d exceeds the decompression limit", runLength)
+		}
+		trytes.WriteString(strings.Repeat(string(TryteAlphabet[value]), int(runLength)))
+	}
+	return trytes.String(), nil
+}
@@ -0,0 +1,60 @@
+package codec_test
+
+import (
+	"strings"
+
+	. "github.com/iotaledger/iota.go/codec"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RLE", func() {
+
+	It("should shrink mostly-empty transaction trytes considerably", func() {
+		trytes := strings.Repeat("9", 2187) + "A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ" + strings.Repeat("9", 405)
+		compressed, err := Compress(trytes)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(compressed)).To(BeNumerically("<", len(trytes)/10))
+
+		back, err := Decompress(compressed)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(back).To(Equal(trytes))
+	})
+
+	It("should round trip trytes without runs", func() {
+		trytes := "ABCDEFGHIJKLMNOPQRSTUVWXYZ9"
+		compressed, err := Compress(trytes)
+		Expect(err).ToNot(HaveOccurred())
+
+		back, err := Decompress(compressed)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(back).To(Equal(trytes))
+	})
+
+	It("should decompress empty bytes to empty trytes", func() {
+		back, err := Decompress(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(back).To(Equal(""))
+	})
+
+	It("should return an error for invalid trytes", func() {
+		_, err := Compress("abc")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for an out of range tryte value", func() {
+		_, err := Decompress([]byte{27})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for a truncated run token", func() {
+		_, err := Decompress([]byte{0x80 | 9})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for a run length exceeding the decompression limit", func() {
+		// a run token claiming 2^64-1 nines
+		_, err := Decompress([]byte{0x80 | 9, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01})
+		Expect(err).To(HaveOccurred())
+	})
+})
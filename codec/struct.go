@@ -0,0 +1,175 @@
+package codec
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidStructTag gets returned when a tryte struct tag is malformed.
+	ErrInvalidStructTag = errors.New("invalid tryte struct tag")
+	// ErrUnsupportedFieldType gets returned for struct fields of a type which
+	// can not be mapped onto trytes.
+	ErrUnsupportedFieldType = errors.New("unsupported field type")
+	// ErrFieldDoesNotFit gets returned when a field value does not fit into
+	// the tryte length given by its tag.
+	ErrFieldDoesNotFit = errors.New("field value does not fit into its tryte length")
+)
+
+// tryteField describes one struct field placed inside a fixed trytes layout.
+type tryteField struct {
+	index  int
+	offset int
+	length int
+}
+
+// parses the `tryte:"offset=...,len=..."` tags of the given struct type.
+// Fields without a tryte tag are ignored.
+func parseTryteLayout(typ reflect.Type) ([]tryteField, int, error) {
+	var fields []tryteField
+	total := 0
+	for i := 0; i < typ.NumField(); i++ {
+		tag, ok := typ.Field(i).Tag.Lookup("tryte")
+		if !ok {
+			continue
+		}
+
+		field := tryteField{index: i, offset: -1, length: -1}
+		for _, part := range strings.Split(tag, ",") {
+			keyValue := strings.SplitN(part, "=", 2)
+			if len(keyValue) != 2 {
+				return nil, 0, errors.Wrapf(ErrInvalidStructTag, "field %s", typ.Field(i).Name)
+			}
+			value, err := strconv.Atoi(keyValue[1])
+			if err != nil || value < 0 {
+				return nil, 0, errors.Wrapf(ErrInvalidStructTag, "field %s", typ.Field(i).Name)
+			}
+			switch keyValue[0] {
+			case "offset":
+				field.offset = value
+			case "len":
+				field.length = value
+			default:
+				return nil, 0, errors.Wrapf(ErrInvalidStructTag, "field %s: unknown key %q", typ.Field(i).Name, keyValue[0])
+			}
+		}
+		if field.offset < 0 || field.length < 1 {
+			return nil, 0, errors.Wrapf(ErrInvalidStructTag, "field %s: offset and len are required", typ.Field(i).Name)
+		}
+
+		fields = append(fields, field)
+		if end := field.offset + field.length; end > total {
+			total = end
+		}
+	}
+	return fields, total, nil
+}
+
+// MarshalFixed serializes a struct into fixed-layout trytes as described by
+// the `tryte:"offset=...,len=..."` tags of its fields. Tryte string fields are
+// right padded with 9s to their length, integer fields are encoded as
+// little-endian balanced ternary. Untagged fields and gaps in the layout are
+// filled with 9s, so custom payload formats do not need hand-written offset
+// arithmetic.
+func MarshalFixed(value interface{}) (Trytes, error) {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() != reflect.Struct {
+		return "", errors.Wrap(ErrUnsupportedFieldType, "value must be a struct")
+	}
+
+	fields, total, err := parseTryteLayout(v.Type())
+	if err != nil {
+		return "", err
+	}
+
+	buf := []byte(strings.Repeat("9", total))
+	for _, field := range fields {
+		fieldValue := v.Field(field.index)
+		name := v.Type().Field(field.index).Name
+
+		switch fieldValue.Kind() {
+		case reflect.String:
+			trytes := fieldValue.String()
+			if len(trytes) > field.length {
+				return "", errors.Wrapf(ErrFieldDoesNotFit, "field %s holds %d trytes", name, len(trytes))
+			}
+			if len(trytes) > 0 {
+				if err := ValidTrytes(trytes); err != nil {
+					return "", errors.Wrapf(err, "field %s", name)
+				}
+			}
+			copy(buf[field.offset:], trytes)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			trits, err := IntToTritsOfLength(fieldValue.Int(), field.length*3)
+			if err != nil {
+				return "", errors.Wrapf(err, "field %s", name)
+			}
+			copy(buf[field.offset:], MustTritsToTrytes(trits))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			trits, err := UintToTritsOfLength(fieldValue.Uint(), field.length*3)
+			if err != nil {
+				return "", errors.Wrapf(err, "field %s", name)
+			}
+			copy(buf[field.offset:], MustTritsToTrytes(trits))
+		default:
+			return "", errors.Wrapf(ErrUnsupportedFieldType, "field %s is a %s", name, fieldValue.Kind())
+		}
+	}
+	return Trytes(buf), nil
+}
+
+// UnmarshalFixed deserializes fixed-layout trytes created by MarshalFixed()
+// back into the tagged fields of the given struct pointer. Tryte string fields
+// keep their right padding, as trailing 9s may be significant.
+func UnmarshalFixed(trytes Trytes, value interface{}) error {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || reflect.Indirect(v).Kind() != reflect.Struct {
+		return errors.Wrap(ErrUnsupportedFieldType, "value must be a struct pointer")
+	}
+	v = reflect.Indirect(v)
+
+	fields, total, err := parseTryteLayout(v.Type())
+	if err != nil {
+		return err
+	}
+	if len(trytes) < total {
+		return errors.Wrapf(ErrInvalidEncodedMessage, "layout needs %d trytes", total)
+	}
+
+	for _, field := range fields {
+		segment := trytes[field.offset : field.offset+field.length]
+		fieldValue := v.Field(field.index)
+		name := v.Type().Field(field.index).Name
+
+		switch fieldValue.Kind() {
+		case reflect.String:
+			if err := ValidTrytes(segment); err != nil {
+				return errors.Wrapf(err, "field %s", name)
+			}
+			fieldValue.SetString(string(segment))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			trits, err := TrytesToTrits(segment)
+			if err != nil {
+				return errors.Wrapf(err, "field %s", name)
+			}
+			fieldValue.SetInt(TritsToInt(trits))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			trits, err := TrytesToTrits(segment)
+			if err != nil {
+				return errors.Wrapf(err, "field %s", name)
+			}
+			num := TritsToInt(trits)
+			if num < 0 {
+				return errors.Wrapf(ErrFieldDoesNotFit, "field %s holds the negative value %d", name, num)
+			}
+			fieldValue.SetUint(uint64(num))
+		default:
+			return errors.Wrapf(ErrUnsupportedFieldType, "field %s is a %s", name, fieldValue.Kind())
+		}
+	}
+	return nil
+}
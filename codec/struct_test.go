@@ -0,0 +1,67 @@
+package codec_test
+
+import (
+	. "github.com/iotaledger/iota.go/codec"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type fixedPayload struct {
+	Tag     Trytes `tryte:"offset=0,len=27"`
+	Value   int64  `tryte:"offset=27,len=11"`
+	Index   uint64 `tryte:"offset=38,len=9"`
+	ignored string
+}
+
+var _ = Describe("Struct", func() {
+
+	payload := fixedPayload{Tag: "TXEFLKNPJRBYZ", Value: -1094385, Index: 42}
+
+	Context("MarshalFixed()", func() {
+		It("should round trip a tagged struct", func() {
+			trytes, err := MarshalFixed(&payload)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trytes).To(HaveLen(47))
+			Expect(ValidTrytes(trytes)).To(Succeed())
+
+			decoded := fixedPayload{}
+			Expect(UnmarshalFixed(trytes, &decoded)).To(Succeed())
+			Expect(decoded.Tag).To(Equal(payload.Tag + "99999999999999"))
+			Expect(decoded.Value).To(Equal(payload.Value))
+			Expect(decoded.Index).To(Equal(payload.Index))
+		})
+
+		It("should return an error when a field does not fit", func() {
+			_, err := MarshalFixed(&struct {
+				Value int64 `tryte:"offset=0,len=1"`
+			}{Value: 1000000})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for a malformed tag", func() {
+			_, err := MarshalFixed(&struct {
+				Tag Trytes `tryte:"offset=0"`
+			}{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for an unsupported field type", func() {
+			_, err := MarshalFixed(&struct {
+				Tag float64 `tryte:"offset=0,len=9"`
+			}{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("UnmarshalFixed()", func() {
+		It("should return an error for too short trytes", func() {
+			decoded := fixedPayload{}
+			Expect(UnmarshalFixed("999", &decoded)).ToNot(Succeed())
+		})
+
+		It("should return an error for a non pointer value", func() {
+			Expect(UnmarshalFixed("999", fixedPayload{})).ToNot(Succeed())
+		})
+	})
+})
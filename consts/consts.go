@@ -56,6 +56,9 @@ const (
 	KeySegmentHashRounds = 26
 )
 
+// TotalSupply is the total supply of iotas.
+const TotalSupply = 2779530283277761
+
 // Address and checksum constants.
 const (
 	AddressChecksumTrytesSize     = 9
@@ -19,6 +19,8 @@ var (
 	ErrInvalidTritsLength = errors.New("invalid trits length")
 	// ErrInvalidBytesLength gets returned when the bytes length are invalid for the given operation.
 	ErrInvalidBytesLength = errors.New("invalid bytes length")
+	// ErrInvalidBatchSize gets returned when the amount of inputs of a batched operation is out of range.
+	ErrInvalidBatchSize = errors.New("invalid batch size")
 	// ErrInsufficientBalance gets returned when an operation needs a certain amount of balance to fulfill the operation.
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	// ErrInvalidAddress gets returned for invalid address parameters.
@@ -65,6 +67,8 @@ var (
 	ErrInvalidThreshold = errors.New("invalid threshold option")
 	// ErrInvalidTransfer gets returned for invalid transfer parameters.
 	ErrInvalidTransfer = errors.New("invalid transfer object")
+	// ErrAmountExceedsTotalSupply gets returned when an amount is larger than the total supply.
+	ErrAmountExceedsTotalSupply = errors.New("amount exceeds total supply")
 	// ErrInvalidTrunkTransaction gets returned for invalid trunk transaction parameters.
 	ErrInvalidTrunkTransaction = errors.New("invalid trunk transaction")
 	// ErrInvalidReferenceHash gets returned for invalid reference hashes.
@@ -73,12 +77,26 @@ var (
 	ErrInvalidTrytes = errors.New("invalid trytes")
 	// ErrInvalidTrit gets returned for invalid trit.
 	ErrInvalidTrit = errors.New("invalid trit")
+	// ErrInvalidByte gets returned for bytes which are invalid for the given encoding.
+	ErrInvalidByte = errors.New("invalid byte")
+	// ErrIntegerOverflow gets returned when a value does not fit into a fixed-width trits field.
+	ErrIntegerOverflow = errors.New("value does not fit into the given trits length")
 	// ErrInvalidURI gets returned for invalid URIs.
 	ErrInvalidURI = errors.New("invalid uri")
+	// ErrInvalidMilestoneIndex gets returned for invalid milestone indexes.
+	ErrInvalidMilestoneIndex = errors.New("invalid milestone index")
+	// ErrInvalidMWM gets returned for invalid minimum weight magnitude parameters.
+	ErrInvalidMWM = errors.New("invalid minimum weight magnitude")
+	// ErrInvalidDepth gets returned for invalid depth parameters.
+	ErrInvalidDepth = errors.New("invalid depth")
 	// ErrInvalidASCIIInput gets returned for invalid ASCII input for to trytes conversion.
 	ErrInvalidASCIIInput = errors.New("conversion to trytes requires type of input to be encoded in ascii")
 	// ErrInvalidOddLength gets returned for odd trytes length for to ASCII conversion.
 	ErrInvalidOddLength = errors.New("conversion from trytes requires length of trytes to be even")
+	// ErrStringTooLong gets returned when a string is too long for a length prefixed trytes conversion.
+	ErrStringTooLong = errors.New("string is too long for conversion to length prefixed trytes")
+	// ErrInvalidStringTrytes gets returned when trytes do not hold a valid length prefixed string.
+	ErrInvalidStringTrytes = errors.New("trytes do not hold a valid length prefixed string")
 	// ErrInvalidTryteEncodedJSON gets returned for invalid tryte encoded JSON messages.
 	ErrInvalidTryteEncodedJSON = errors.New("invalid tryte encoded JSON message")
 	// ErrSendingBackToInputs gets returned when a transfer sends back to an Input.
@@ -38,7 +38,7 @@ func TrytesToASCII(trytes Trytes) (string, error) {
 
 	ascii := ""
 	for i := 0; i < len(trytes); i += 2 {
-		ascii += string(strings.IndexRune(TryteAlphabet, rune(trytes[i])) + (strings.IndexRune(TryteAlphabet, rune(trytes[i+1])) * 27))
+		ascii += string(rune(strings.IndexRune(TryteAlphabet, rune(trytes[i])) + (strings.IndexRune(TryteAlphabet, rune(trytes[i+1])) * 27)))
 	}
 
 	return ascii, nil
@@ -1,6 +1,7 @@
 package converter_test
 
 import (
+	. "github.com/iotaledger/iota.go/consts"
 	. "github.com/iotaledger/iota.go/converter"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,6 +22,50 @@ var _ = Describe("Converter", func() {
 		})
 	})
 
+	Context("StringToTrytes()", func() {
+		It("round trips non ASCII strings", func() {
+			trytes, err := StringToTrytes("Γιώτα")
+			Expect(err).ToNot(HaveOccurred())
+
+			s, err := TrytesToString(trytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).To(Equal("Γιώτα"))
+		})
+
+		It("round trips the empty string", func() {
+			trytes, err := StringToTrytes("")
+			Expect(err).ToNot(HaveOccurred())
+
+			s, err := TrytesToString(trytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).To(Equal(""))
+		})
+	})
+
+	Context("TrytesToString()", func() {
+		It("ignores padding trytes beyond the message boundary", func() {
+			trytes, err := StringToTrytes("IOTA")
+			Expect(err).ToNot(HaveOccurred())
+
+			s, err := TrytesToString(trytes + "999999999")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(s).To(Equal("IOTA"))
+		})
+
+		It("returns an error when the trytes are shorter than the framed length", func() {
+			trytes, err := StringToTrytes("IOTA")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = TrytesToString(trytes[:len(trytes)-2])
+			Expect(err).To(Equal(ErrInvalidStringTrytes))
+		})
+
+		It("returns an error for invalid trytes", func() {
+			_, err := TrytesToString("AAAfasds")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("TrytesToASCII()", func() {
 		It("returns the correct ascii representation", func() {
 			ascii, err := TrytesToASCII("SBYBCCKB")
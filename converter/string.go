@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"strings"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// the amount of trytes used to encode the byte length of the message.
+const stringLengthPrefixTrytes = 4
+
+// the maximum byte length encodable with the length prefix (27^4 - 1).
+const maxStringLength = 27*27*27*27 - 1
+
+// StringToTrytes converts an arbitrary UTF-8 encoded string to trytes. Unlike
+// ASCIIToTrytes() the raw bytes of the string are encoded, so non ASCII messages
+// are preserved. The byte length of the message is framed into a length prefix of
+// 4 trytes, so TrytesToString() stops at the message boundary even when the
+// trytes were padded with 9s to fit a signature message fragment.
+func StringToTrytes(s string) (Trytes, error) {
+	if len(s) > maxStringLength {
+		return "", ErrStringTooLong
+	}
+
+	var trytes strings.Builder
+	trytes.Grow(stringLengthPrefixTrytes + len(s)*2)
+
+	length := len(s)
+	for i := 0; i < stringLengthPrefixTrytes; i++ {
+		trytes.WriteByte(TryteAlphabet[length%27])
+		length /= 27
+	}
+
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		trytes.WriteByte(TryteAlphabet[b%27])
+		trytes.WriteByte(TryteAlphabet[b/27])
+	}
+
+	return NewTrytes(trytes.String())
+}
+
+// TrytesToString converts length prefixed trytes created by StringToTrytes() back
+// to the original UTF-8 encoded string. Trailing padding trytes beyond the framed
+// message boundary are ignored.
+func TrytesToString(trytes Trytes) (string, error) {
+	if err := ValidTrytes(trytes); err != nil {
+		return "", err
+	}
+
+	if len(trytes) < stringLengthPrefixTrytes {
+		return "", ErrInvalidStringTrytes
+	}
+
+	length := 0
+	for i := stringLengthPrefixTrytes - 1; i >= 0; i-- {
+		length = length*27 + strings.IndexByte(TryteAlphabet, trytes[i])
+	}
+
+	if len(trytes) < stringLengthPrefixTrytes+length*2 {
+		return "", ErrInvalidStringTrytes
+	}
+
+	bytes := make([]byte, length)
+	for i := 0; i < length; i++ {
+		b := strings.IndexByte(TryteAlphabet, trytes[stringLengthPrefixTrytes+i*2]) +
+			strings.IndexByte(TryteAlphabet, trytes[stringLengthPrefixTrytes+i*2+1])*27
+		if b > 255 {
+			return "", ErrInvalidStringTrytes
+		}
+		bytes[i] = byte(b)
+	}
+
+	return string(bytes), nil
+}
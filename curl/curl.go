@@ -2,8 +2,12 @@
 package curl
 
 import (
+	"encoding/binary"
+
 	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/sponge"
 	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -38,16 +42,37 @@ func init() {
 // b = r + c, b = StateSize, r = HashSize, c = StateSize - HashSize
 type Curl struct {
 	State Trits
+	// Rounds is the number of rounds applied in each Transform.
+	Rounds int
 }
 
-// NewCurl initializes a new instance with an empty State.
-func NewCurl() *Curl {
+var _ sponge.SpongeFunction = (*Curl)(nil)
+
+// NewCurl initializes a new instance with an empty State. The optional number
+// of rounds defaults to NumberOfRounds, i.e. Curl-P-81.
+func NewCurl(rounds ...int) *Curl {
+	r := NumberOfRounds
+	if len(rounds) > 0 && rounds[0] > 0 {
+		r = rounds[0]
+	}
 	c := &Curl{
-		State: make(Trits, StateSize),
+		State:  make(Trits, StateSize),
+		Rounds: r,
 	}
 	return c
 }
 
+// NewCurlP27 initializes a new Curl-P-27 instance, as used outside of the
+// transaction hash and Proof-of-Work.
+func NewCurlP27() *Curl {
+	return NewCurl(27)
+}
+
+// NewCurlP81 initializes a new Curl-P-81 instance.
+func NewCurlP81() *Curl {
+	return NewCurl(NumberOfRounds)
+}
+
 //Squeeze do Squeeze in sponge func.
 func (c *Curl) Squeeze() Trytes {
 	ret := MustTritsToTrytes(c.State[:HashTrinarySize])
@@ -63,7 +88,11 @@ func (c *Curl) Absorb(inn Trytes) {
 	if len(inn) == 0 {
 		in = Trits{0}
 	} else {
-		in = MustTrytesToTrits(inn)
+		in = GetTritsBuffer(len(inn) * 3)
+		defer PutTritsBuffer(in)
+		if err := TrytesToTritsInto(inn, in); err != nil {
+			panic(err)
+		}
 	}
 	var lenn int
 	for i := 0; i < len(in); i += lenn {
@@ -78,16 +107,103 @@ func (c *Curl) Absorb(inn Trytes) {
 	}
 }
 
+// AbsorbTrits fills the internal State of the sponge with the given trits.
+func (c *Curl) AbsorbTrits(in Trits) error {
+	if err := ValidTrits(in); err != nil {
+		return err
+	}
+	if len(in) == 0 {
+		in = Trits{0}
+	}
+	var lenn int
+	for i := 0; i < len(in); i += lenn {
+		lenn = HashTrinarySize
+
+		if len(in)-i < HashTrinarySize {
+			lenn = len(in) - i
+		}
+
+		copy(c.State, in[i:i+lenn])
+		c.Transform()
+	}
+	return nil
+}
+
+// SqueezeTrits squeezes out trits of the given length, which must be a
+// multiple of HashTrinarySize.
+func (c *Curl) SqueezeTrits(length int) (Trits, error) {
+	if length%HashTrinarySize != 0 {
+		return nil, ErrInvalidSqueezeLength
+	}
+
+	out := make(Trits, length)
+	for i := 0; i < length/HashTrinarySize; i++ {
+		copy(out[i*HashTrinarySize:], c.State[:HashTrinarySize])
+		c.Transform()
+	}
+	return out, nil
+}
+
+// Clone returns a deep copy of the Curl sponge including its internal State.
+func (c *Curl) Clone() sponge.SpongeFunction {
+	clone := NewCurl(c.Rounds)
+	copy(clone.State, c.State)
+	return clone
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, so
+// mid-states of the sponge can be cached or persisted and resumed later.
+// The state is encoded as the round count followed by one byte per trit.
+func (c *Curl) MarshalBinary() ([]byte, error) {
+	rounds := c.Rounds
+	if rounds == 0 {
+		rounds = NumberOfRounds
+	}
+	data := make([]byte, 2+StateSize)
+	binary.LittleEndian.PutUint16(data, uint16(rounds))
+	for i, trit := range c.State {
+		data[2+i] = byte(trit + 1)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (c *Curl) UnmarshalBinary(data []byte) error {
+	if len(data) != 2+StateSize {
+		return errors.Wrapf(ErrInvalidBytesLength, "Curl state must be %d bytes in size", 2+StateSize)
+	}
+	rounds := int(binary.LittleEndian.Uint16(data))
+	if rounds < 1 {
+		return errors.Wrap(ErrInvalidByte, "round count must be at least 1")
+	}
+	state := make(Trits, StateSize)
+	for i, b := range data[2:] {
+		if b > 2 {
+			return errors.Wrapf(ErrInvalidByte, "at index %d", i)
+		}
+		state[i] = int8(b) - 1
+	}
+	c.Rounds = rounds
+	c.State = state
+	return nil
+}
+
 // Transform does Transform in sponge func.
 func (c *Curl) Transform() {
-	if transformC != nil {
+	// the zero value of Curl hashes with the default number of rounds
+	rounds := c.Rounds
+	if rounds == 0 {
+		rounds = NumberOfRounds
+	}
+	// the accelerated transform is fixed to the default number of rounds
+	if transformC != nil && rounds == NumberOfRounds {
 		transformC(c.State)
 		return
 	}
 
 	var cpy [StateSize]int8
 
-	for r := NumberOfRounds; r > 0; r-- {
+	for r := rounds; r > 0; r-- {
 		copy(cpy[:], c.State)
 		for i := 0; i < StateSize; i++ {
 			t1 := Indices[i]
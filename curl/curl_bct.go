@@ -0,0 +1,113 @@
+package curl
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// MaxBatchSize is the maximum amount of inputs a single HashBatch() call can
+// hash simultaneously, one input per bit of a word.
+const MaxBatchSize = 64
+
+// HashBatch hashes up to 64 equally sized inputs simultaneously using the
+// binary-coded ternary representation of the state, with the same bit-slicing
+// trick the PoW code uses for the nonce search. Every input must be a multiple
+// of HashTrinarySize in length. It returns one hash per input and is
+// considerably faster than hashing the inputs one at a time.
+func HashBatch(inputs []Trits) ([]Trits, error) {
+	if len(inputs) == 0 || len(inputs) > MaxBatchSize {
+		return nil, errors.Wrapf(ErrInvalidBatchSize, "must be 1 to %d inputs", MaxBatchSize)
+	}
+	length := len(inputs[0])
+	if length == 0 || length%HashTrinarySize != 0 {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "input length must be a multiple of 243")
+	}
+	for _, in := range inputs {
+		if len(in) != length {
+			return nil, errors.Wrap(ErrInvalidTritsLength, "inputs must be of equal length")
+		}
+	}
+
+	// the all zero state encodes as all bits set in both words
+	var lstate, hstate [StateSize]uint64
+	for i := 0; i < StateSize; i++ {
+		lstate[i] = ^uint64(0)
+		hstate[i] = ^uint64(0)
+	}
+
+	for block := 0; block < length/HashTrinarySize; block++ {
+		for i := 0; i < HashTrinarySize; i++ {
+			// unused lanes keep the zero trit encoding of both bits set
+			l := ^uint64(0) << uint(len(inputs))
+			h := ^uint64(0) << uint(len(inputs))
+			for j, in := range inputs {
+				switch in[block*HashTrinarySize+i] {
+				case 0:
+					l |= 1 << uint(j)
+					h |= 1 << uint(j)
+				case 1:
+					h |= 1 << uint(j)
+				case -1:
+					l |= 1 << uint(j)
+				default:
+					return nil, errors.Wrapf(ErrInvalidTrit, "at index %d of input %d", block*HashTrinarySize+i, j)
+				}
+			}
+			lstate[i] = l
+			hstate[i] = h
+		}
+		transformBCT(&lstate, &hstate)
+	}
+
+	hashes := make([]Trits, len(inputs))
+	for j := range inputs {
+		hash := make(Trits, HashTrinarySize)
+		for i := 0; i < HashTrinarySize; i++ {
+			l := (lstate[i] >> uint(j)) & 1
+			h := (hstate[i] >> uint(j)) & 1
+			switch {
+			case l == 0 && h == 1:
+				hash[i] = 1
+			case l == 1 && h == 0:
+				hash[i] = -1
+			}
+		}
+		hashes[j] = hash
+	}
+	return hashes, nil
+}
+
+// transformBCT applies the Curl transformation to the binary-coded ternary
+// state, processing all 64 bit lanes at once.
+func transformBCT(lmid *[StateSize]uint64, hmid *[StateSize]uint64) {
+	var ltmp, htmp [StateSize]uint64
+	lfrom := lmid
+	hfrom := hmid
+	lto := &ltmp
+	hto := &htmp
+
+	for r := 0; r < NumberOfRounds; r++ {
+		for j := 0; j < StateSize; j++ {
+			t1 := Indices[j]
+			t2 := Indices[j+1]
+
+			alpha := lfrom[t1]
+			beta := hfrom[t1]
+			gamma := hfrom[t2]
+			delta := (alpha | (^gamma)) & (lfrom[t2] ^ beta)
+
+			lto[j] = ^delta
+			hto[j] = (alpha ^ gamma) | delta
+		}
+
+		lfrom, lto = lto, lfrom
+		hfrom, hto = hto, hfrom
+	}
+
+	// after an odd number of rounds the result sits in the temporary buffers
+	if lfrom != lmid {
+		copy(lmid[:], lfrom[:])
+		copy(hmid[:], hfrom[:])
+	}
+}
@@ -0,0 +1,70 @@
+package curl_test
+
+import (
+	"math/rand"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HashBatch()", func() {
+
+	randomTrits := func(n int) Trits {
+		trits := make(Trits, n)
+		for i := range trits {
+			trits[i] = int8(rand.Intn(3) - 1)
+		}
+		return trits
+	}
+
+	It("should match the single input hash for every lane", func() {
+		for _, batchSize := range []int{1, 2, 63, 64} {
+			inputs := make([]Trits, batchSize)
+			for i := range inputs {
+				inputs[i] = randomTrits(HashTrinarySize)
+			}
+
+			hashes, err := HashBatch(inputs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hashes).To(HaveLen(batchSize))
+			for i := range inputs {
+				Expect(hashes[i]).To(Equal(HashTrits(inputs[i])))
+			}
+		}
+	})
+
+	It("should hash multi block inputs", func() {
+		inputs := []Trits{randomTrits(3 * HashTrinarySize), randomTrits(3 * HashTrinarySize)}
+		hashes, err := HashBatch(inputs)
+		Expect(err).ToNot(HaveOccurred())
+		for i := range inputs {
+			Expect(hashes[i]).To(Equal(HashTrits(inputs[i])))
+		}
+	})
+
+	It("should return an error for an empty or too large batch", func() {
+		_, err := HashBatch(nil)
+		Expect(err).To(HaveOccurred())
+
+		_, err = HashBatch(make([]Trits, 65))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for wrongly sized inputs", func() {
+		_, err := HashBatch([]Trits{randomTrits(100)})
+		Expect(err).To(HaveOccurred())
+
+		_, err = HashBatch([]Trits{randomTrits(HashTrinarySize), randomTrits(2 * HashTrinarySize)})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error for an invalid trit", func() {
+		in := randomTrits(HashTrinarySize)
+		in[10] = 2
+		_, err := HashBatch([]Trits{in})
+		Expect(err).To(HaveOccurred())
+	})
+})
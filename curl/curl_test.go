@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	. "github.com/iotaledger/iota.go/consts"
 	. "github.com/iotaledger/iota.go/curl"
 	. "github.com/iotaledger/iota.go/trinary"
 )
@@ -21,4 +22,77 @@ var _ = Describe("Curl", func() {
 		Entry("empty trytes", "", "999999999999999999999999999999999999999999999999999999999999999999999999999999999"),
 	)
 
+	Context("round count constructors", func() {
+		// an independent implementation of a single transform with the given rounds
+		transform := func(state Trits, rounds int) {
+			var cpy [StateSize]int8
+			for r := rounds; r > 0; r-- {
+				copy(cpy[:], state)
+				for i := 0; i < StateSize; i++ {
+					state[i] = TruthTable[cpy[Indices[i]]+(cpy[Indices[i+1]]<<2)+5]
+				}
+			}
+		}
+
+		hashWithRounds := func(in Trytes, rounds int) Trytes {
+			state := make(Trits, StateSize)
+			copy(state, MustTrytesToTrits(in))
+			transform(state, rounds)
+			return MustTritsToTrytes(state[:HashTrinarySize])
+		}
+
+		It("should default to Curl-P-81", func() {
+			c := NewCurl()
+			Expect(c.Rounds).To(Equal(NumberOfRounds))
+			Expect(NewCurlP81().Rounds).To(Equal(NumberOfRounds))
+		})
+
+		It("should honor the round count of the instance", func() {
+			for _, rounds := range []int{27, 81} {
+				c := NewCurl(rounds)
+				c.Absorb("A")
+				Expect(c.Squeeze()).To(Equal(hashWithRounds("A", rounds)))
+			}
+		})
+
+		It("should hash with 27 rounds for NewCurlP27()", func() {
+			c := NewCurlP27()
+			Expect(c.Rounds).To(Equal(27))
+			c.Absorb("A")
+			Expect(c.Squeeze()).To(Equal(hashWithRounds("A", 27)))
+		})
+
+		It("should keep the round count on Clone()", func() {
+			clone := NewCurlP27().Clone()
+			Expect(clone.(*Curl).Rounds).To(Equal(27))
+		})
+	})
+
+	Context("state export and resume", func() {
+
+		It("should squeeze the same hash after a marshal and unmarshal round trip", func() {
+			c := NewCurlP27()
+			c.Absorb("ABCDEFGHIJ")
+
+			data, err := c.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+
+			restored := new(Curl)
+			Expect(restored.UnmarshalBinary(data)).ToNot(HaveOccurred())
+			Expect(restored.Rounds).To(Equal(27))
+			Expect(restored.Squeeze()).To(Equal(c.Squeeze()))
+		})
+
+		It("should return an error for data of the wrong size", func() {
+			Expect(new(Curl).UnmarshalBinary(make([]byte, 10))).To(HaveOccurred())
+		})
+
+		It("should return an error for an invalid trit byte", func() {
+			data, err := NewCurl().MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+			data[2] = 3
+			Expect(new(Curl).UnmarshalBinary(data)).To(HaveOccurred())
+		})
+	})
+
 })
@@ -6,7 +6,9 @@ import (
 	"github.com/iotaledger/iota.go/curl"
 	. "github.com/iotaledger/iota.go/trinary"
 
+	"net/url"
 	"regexp"
+	"strconv"
 )
 
 // IsTrytes checks if input is correct trytes consisting of [9A-Z]
@@ -99,3 +101,28 @@ func IsTransactionTrytesWithMWM(trytes Trytes, mwm uint) (bool, error) {
 func IsAttachedTrytes(trytes Trytes) bool {
 	return IsTrytesOfExactLength(trytes, TransactionTrytesSize) && !IsEmptyTrytes(trytes[(TransactionTrytesSize)-3*HashTrytesSize:])
 }
+
+// IsNodeURI checks if input is a valid node URI: tcp:// and udp:// URIs must
+// carry an explicit port, http:// and https:// URIs may omit it.
+func IsNodeURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" || parsed.Hostname() == "" {
+		return false
+	}
+	switch parsed.Scheme {
+	case "tcp", "udp":
+		if parsed.Port() == "" {
+			return false
+		}
+	case "http", "https":
+	default:
+		return false
+	}
+	if port := parsed.Port(); port != "" {
+		portNum, err := strconv.Atoi(port)
+		if err != nil || portNum < 1 || portNum > 65535 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package validators
+
+import (
+	"strings"
+)
+
+// FieldError is a validation failure annotated with the name of the field
+// which failed to validate.
+type FieldError struct {
+	// The name of the field.
+	Field string
+	// The underlying validation error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Cause returns the underlying validation error.
+func (e *FieldError) Cause() error {
+	return e.Err
+}
+
+// Field annotates the error of the given validator with the given field
+// name, so aggregated validation results can be mapped back to the input
+// which caused them.
+func Field(name string, validator Validatable) Validatable {
+	return func() error {
+		if err := validator(); err != nil {
+			return &FieldError{Field: name, Err: err}
+		}
+		return nil
+	}
+}
+
+// ValidationErrors aggregates multiple validation failures.
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateAll calls all given validators and aggregates every occurred error
+// into a ValidationErrors, instead of stopping at the first one like
+// Validate. It returns nil if all validators pass.
+func ValidateAll(validators ...Validatable) error {
+	var errs ValidationErrors
+	for i := range validators {
+		if err := validators[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
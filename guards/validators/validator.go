@@ -113,6 +113,59 @@ func ValidateURIs(uris ...string) Validatable {
 	}
 }
 
+// ValidateNodeURIs validates the given node URIs, including the port rules
+// per scheme.
+func ValidateNodeURIs(uris ...string) Validatable {
+	return func() error {
+		for i := range uris {
+			if !IsNodeURI(uris[i]) {
+				return errors.Wrapf(ErrInvalidURI, "%s at index %d", uris[i], i)
+			}
+		}
+		return nil
+	}
+}
+
+// MaxMilestoneIndex is the highest valid milestone index.
+const MaxMilestoneIndex = 1<<31 - 1
+
+// ValidateMilestoneIndex validates the given milestone index.
+func ValidateMilestoneIndex(index uint64) Validatable {
+	return func() error {
+		if index == 0 || index > MaxMilestoneIndex {
+			return errors.Wrapf(ErrInvalidMilestoneIndex, "%d", index)
+		}
+		return nil
+	}
+}
+
+// MaxMWM is the highest sensible minimum weight magnitude; well known
+// networks use 9 (devnet) and 14 (mainnet).
+const MaxMWM = 26
+
+// ValidateMWM validates the given minimum weight magnitude.
+func ValidateMWM(mwm uint64) Validatable {
+	return func() error {
+		if mwm == 0 || mwm > MaxMWM {
+			return errors.Wrapf(ErrInvalidMWM, "%d", mwm)
+		}
+		return nil
+	}
+}
+
+// MaxDepth is the highest depth accepted for tip selection.
+const MaxDepth = 15
+
+// ValidateDepth validates the given depth for tip selection.
+func ValidateDepth(depth uint64) Validatable {
+	return func() error {
+		if depth == 0 || depth > MaxDepth {
+			return errors.Wrapf(ErrInvalidDepth, "%d", depth)
+		}
+		return nil
+	}
+}
+
 // ValidateSecurityLevel validates the given security level.
 func ValidateSecurityLevel(secLvl SecurityLevel) Validatable {
 	return func() error {
@@ -123,6 +123,57 @@ var _ = Describe("Validators", func() {
 		})
 	})
 
+	Context("ValidateNodeURIs()", func() {
+		It("shouldn't return an error for valid node uris", func() {
+			Expect(ValidateNodeURIs(
+				"tcp://example.com:14600",
+				"udp://balalaika.com:14600",
+				"https://nodes.example.org",
+				"https://nodes.example.org:14265",
+			)()).ToNot(HaveOccurred())
+		})
+
+		It("should return an error for invalid node uris", func() {
+			Expect(ValidateNodeURIs("tcp://example.com")()).To(HaveOccurred())
+			Expect(ValidateNodeURIs("udp://example.com:0")()).To(HaveOccurred())
+			Expect(ValidateNodeURIs("ftp://example.com:14600")()).To(HaveOccurred())
+			Expect(ValidateNodeURIs("https://nodes.example.org:66000")()).To(HaveOccurred())
+		})
+	})
+
+	Context("ValidateMilestoneIndex()", func() {
+		It("shouldn't return an error for valid milestone indexes", func() {
+			Expect(ValidateMilestoneIndex(1050000)()).ToNot(HaveOccurred())
+		})
+
+		It("should return an error for invalid milestone indexes", func() {
+			Expect(ValidateMilestoneIndex(0)()).To(HaveOccurred())
+			Expect(ValidateMilestoneIndex(MaxMilestoneIndex + 1)()).To(HaveOccurred())
+		})
+	})
+
+	Context("ValidateMWM()", func() {
+		It("shouldn't return an error for valid minimum weight magnitudes", func() {
+			Expect(ValidateMWM(14)()).ToNot(HaveOccurred())
+		})
+
+		It("should return an error for invalid minimum weight magnitudes", func() {
+			Expect(ValidateMWM(0)()).To(HaveOccurred())
+			Expect(ValidateMWM(MaxMWM + 1)()).To(HaveOccurred())
+		})
+	})
+
+	Context("ValidateDepth()", func() {
+		It("shouldn't return an error for valid depths", func() {
+			Expect(ValidateDepth(3)()).ToNot(HaveOccurred())
+		})
+
+		It("should return an error for invalid depths", func() {
+			Expect(ValidateDepth(0)()).To(HaveOccurred())
+			Expect(ValidateDepth(MaxDepth + 1)()).To(HaveOccurred())
+		})
+	})
+
 	Context("ValidateSecurityLevel()", func() {
 		It("shouldn't return an error for valid security level", func() {
 			Expect(ValidateSecurityLevel(SecurityLevelMedium)()).ToNot(HaveOccurred())
@@ -164,6 +215,29 @@ var _ = Describe("Validators", func() {
 		})
 	})
 
+	Context("ValidateAll()", func() {
+		It("shouldn't return an error when all validators pass", func() {
+			Expect(ValidateAll(
+				ValidateSecurityLevel(SecurityLevelMedium),
+				ValidateTags("TRAVELING999IOTA99999999999"),
+			)).ToNot(HaveOccurred())
+		})
+
+		It("should aggregate all failures with their field names", func() {
+			err := ValidateAll(
+				Field("securityLevel", ValidateSecurityLevel(SecurityLevel(4))),
+				Field("tag", ValidateTags("abcdef")),
+				Field("seed", ValidateSeed("JEDDPBHQSSKN9TDZVDITVFHFZOGXKUHGUATPHLLVJCVOQFCAFRBJATLVZLPCHVUKTHATGANRCIETJRGBB")),
+			)
+			Expect(err).To(HaveOccurred())
+			errs, ok := err.(ValidationErrors)
+			Expect(ok).To(BeTrue())
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Error()).To(HavePrefix("securityLevel: "))
+			Expect(errs[1].Error()).To(HavePrefix("tag: "))
+		})
+	})
+
 	Context("ValidateTransfers()", func() {
 		It("shouldn't return an error for valid transfers", func() {
 			Expect(ValidateTransfers(bundle.Transfers{
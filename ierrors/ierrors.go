@@ -0,0 +1,261 @@
+// Package ierrors provides a unified typed error system over the error
+// variables scattered across consts, pow and api: every known error maps to
+// a stable machine readable code and a kind, and errors can be enriched with
+// the command, field and index they occurred for. The types fully support
+// errors.Is/errors.As, so callers can programmatically distinguish
+// validation errors from network errors from node errors.
+package ierrors
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/pow"
+	"github.com/pkg/errors"
+)
+
+// Kind groups errors by their origin.
+type Kind uint8
+
+const (
+	// KindUnknown marks errors which could not be classified.
+	KindUnknown Kind = iota
+	// KindValidation marks errors caused by invalid caller supplied input.
+	KindValidation
+	// KindNetwork marks errors caused by the transport to the node.
+	KindNetwork
+	// KindNode marks errors reported by the node itself.
+	KindNode
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindValidation:
+		return "validation"
+	case KindNetwork:
+		return "network"
+	case KindNode:
+		return "node"
+	default:
+		return "unknown"
+	}
+}
+
+// Error is a classified error with a stable code and optional context.
+type Error struct {
+	// The kind of the error.
+	Kind Kind
+	// The stable machine readable code of the error.
+	Code string
+	// The API command during which the error occurred. Optional.
+	Command string
+	// The field which caused the error. Optional.
+	Field string
+	// The index of the input which caused the error, -1 if not applicable.
+	Index int
+	// The underlying error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Kind.String() + " error [" + e.Code + "]")
+	if e.Command != "" {
+		sb.WriteString(" in " + e.Command)
+	}
+	if e.Field != "" {
+		sb.WriteString(" on field " + e.Field)
+	}
+	if e.Index != -1 {
+		sb.WriteString(fmt.Sprintf(" at index %d", e.Index))
+	}
+	sb.WriteString(": " + e.Err.Error())
+	return sb.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the underlying error.
+func (e *Error) Cause() error {
+	return e.Err
+}
+
+// Is reports whether the target matches this error: another *Error matches
+// by code (or by kind alone if it carries no code), anything else is matched
+// against the underlying error.
+func (e *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	if !ok {
+		return errors.Cause(e.Err) == target
+	}
+	if other.Code != "" {
+		return other.Code == e.Code
+	}
+	return other.Kind == e.Kind
+}
+
+// Option enriches an Error with context.
+type Option func(*Error)
+
+// WithCommand records the API command during which the error occurred.
+func WithCommand(command string) Option {
+	return func(e *Error) { e.Command = command }
+}
+
+// WithField records the field which caused the error.
+func WithField(field string) Option {
+	return func(e *Error) { e.Field = field }
+}
+
+// WithIndex records the index of the input which caused the error.
+func WithIndex(index int) Option {
+	return func(e *Error) { e.Index = index }
+}
+
+// Wrap classifies the given error into an *Error with its stable code and
+// kind and applies the given context options. Already classified errors only
+// get the additional context applied. A nil error stays nil.
+func Wrap(err error, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+	classified, ok := asError(err)
+	if !ok {
+		classified = &Error{Kind: kindOf(err), Code: codeOf(err), Index: -1, Err: err}
+	}
+	for _, opt := range opts {
+		opt(classified)
+	}
+	return classified
+}
+
+// IsValidation reports whether the given error is a validation error.
+func IsValidation(err error) bool {
+	return kindOfWrapped(err) == KindValidation
+}
+
+// IsNetwork reports whether the given error is a network error.
+func IsNetwork(err error) bool {
+	return kindOfWrapped(err) == KindNetwork
+}
+
+// IsNode reports whether the given error is an error reported by the node.
+func IsNode(err error) bool {
+	return kindOfWrapped(err) == KindNode
+}
+
+// Code returns the stable code of the given error, or "" if it has none.
+func Code(err error) string {
+	if classified, ok := asError(err); ok {
+		return classified.Code
+	}
+	return codeOf(err)
+}
+
+func asError(err error) (*Error, bool) {
+	for err != nil {
+		if classified, ok := err.(*Error); ok {
+			return classified, true
+		}
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return nil, false
+		}
+		err = unwrapper.Unwrap()
+	}
+	return nil, false
+}
+
+func kindOfWrapped(err error) Kind {
+	if classified, ok := asError(err); ok {
+		return classified.Kind
+	}
+	return kindOf(err)
+}
+
+func kindOf(err error) Kind {
+	cause := errors.Cause(err)
+	if kind, ok := errorKinds[cause]; ok {
+		return kind
+	}
+	return KindUnknown
+}
+
+func codeOf(err error) string {
+	if code, ok := errorCodes[errors.Cause(err)]; ok {
+		return code
+	}
+	return ""
+}
+
+// errorKinds classifies the known error variables.
+var errorKinds = map[error]Kind{
+	ErrNonOKStatusCodeFromAPIRequest: KindNetwork,
+	ErrUnknownErrorFromAPIRequest:    KindNetwork,
+	ErrInconsistentSubtangle:         KindNode,
+	ErrInsufficientBalance:           KindNode,
+}
+
+// errorCodes maps the known error variables to their stable codes.
+var errorCodes = map[error]string{
+	ErrSettingsNil:                     "settings_nil",
+	ErrNonOKStatusCodeFromAPIRequest:   "non_ok_status",
+	ErrUnknownErrorFromAPIRequest:      "unknown_backend_error",
+	ErrInvalidSettingsType:             "invalid_settings_type",
+	ErrInconsistentSubtangle:           "inconsistent_subtangle",
+	ErrInvalidSqueezeLength:            "invalid_squeeze_length",
+	ErrInvalidTritsLength:              "invalid_trits_length",
+	ErrInvalidBytesLength:              "invalid_bytes_length",
+	ErrInsufficientBalance:             "insufficient_balance",
+	ErrInvalidAddress:                  "invalid_address",
+	ErrInvalidRemainderAddress:         "invalid_remainder_address",
+	ErrInvalidBranchTransaction:        "invalid_branch_transaction",
+	ErrInvalidBundle:                   "invalid_bundle",
+	ErrInvalidBundleHash:               "invalid_bundle_hash",
+	ErrInvalidSignature:                "invalid_signature",
+	ErrInvalidChecksum:                 "invalid_checksum",
+	ErrInvalidHash:                     "invalid_hash",
+	ErrInvalidIndex:                    "invalid_index",
+	ErrInvalidTotalOption:              "invalid_total_option",
+	ErrInvalidInput:                    "invalid_input",
+	ErrInvalidSecurityLevel:            "invalid_security_level",
+	ErrInvalidSeed:                     "invalid_seed",
+	ErrInvalidStartEndOptions:          "invalid_start_end_options",
+	ErrInvalidTag:                      "invalid_tag",
+	ErrInvalidTransaction:              "invalid_transaction",
+	ErrInvalidTransactionTrytes:        "invalid_transaction_trytes",
+	ErrInvalidAttachedTrytes:           "invalid_attached_trytes",
+	ErrInvalidTransactionHash:          "invalid_transaction_hash",
+	ErrInvalidTailTransaction:          "invalid_tail_transaction",
+	ErrInvalidThreshold:                "invalid_threshold",
+	ErrInvalidTransfer:                 "invalid_transfer",
+	ErrAmountExceedsTotalSupply:        "amount_exceeds_total_supply",
+	ErrInvalidTrunkTransaction:         "invalid_trunk_transaction",
+	ErrInvalidReferenceHash:            "invalid_reference_hash",
+	ErrInvalidTrytes:                   "invalid_trytes",
+	ErrInvalidTrit:                     "invalid_trit",
+	ErrInvalidURI:                      "invalid_uri",
+	ErrInvalidMilestoneIndex:           "invalid_milestone_index",
+	ErrInvalidMWM:                      "invalid_mwm",
+	ErrInvalidDepth:                    "invalid_depth",
+	ErrInvalidASCIIInput:               "invalid_ascii_input",
+	ErrInvalidOddLength:                "invalid_odd_length",
+	ErrInvalidTryteEncodedJSON:         "invalid_tryte_encoded_json",
+	ErrSendingBackToInputs:             "sending_back_to_inputs",
+	ErrNoRemainderSpecified:            "no_remainder_specified",
+	pow.ErrInvalidTrytesForProofOfWork: "invalid_trytes_for_pow",
+	pow.ErrUnknownProofOfWorkFunc:      "unknown_pow_func",
+}
+
+func init() {
+	// everything with a code but no explicit kind is a validation error
+	for err := range errorCodes {
+		if _, ok := errorKinds[err]; !ok {
+			errorKinds[err] = KindValidation
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package ierrors_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestIerrors(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ierrors Suite")
+}
@@ -0,0 +1,58 @@
+package ierrors_test
+
+import (
+	goerrors "errors"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/ierrors"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Ierrors", func() {
+
+	Context("Wrap()", func() {
+		It("classifies validation errors with their stable code", func() {
+			err := Wrap(ErrInvalidAddress, WithCommand("getBalances"), WithField("addresses"), WithIndex(2))
+			Expect(IsValidation(err)).To(BeTrue())
+			Expect(IsNetwork(err)).To(BeFalse())
+			Expect(Code(err)).To(Equal("invalid_address"))
+			Expect(err.Error()).To(ContainSubstring("getBalances"))
+			Expect(err.Error()).To(ContainSubstring("addresses"))
+			Expect(err.Error()).To(ContainSubstring("index 2"))
+		})
+
+		It("classifies network and node errors", func() {
+			Expect(IsNetwork(Wrap(ErrNonOKStatusCodeFromAPIRequest))).To(BeTrue())
+			Expect(IsNode(Wrap(ErrInconsistentSubtangle))).To(BeTrue())
+		})
+
+		It("classifies wrapped causes", func() {
+			err := Wrap(errors.Wrap(ErrInvalidTag, "some context"))
+			Expect(IsValidation(err)).To(BeTrue())
+			Expect(Code(err)).To(Equal("invalid_tag"))
+		})
+
+		It("keeps unknown errors unclassified", func() {
+			err := Wrap(goerrors.New("something else"))
+			Expect(IsValidation(err)).To(BeFalse())
+			Expect(Code(err)).To(Equal(""))
+		})
+	})
+
+	Context("errors.Is/As", func() {
+		It("matches the underlying sentinel error", func() {
+			err := Wrap(ErrInvalidAddress, WithCommand("getBalances"))
+			Expect(goerrors.Is(err, ErrInvalidAddress)).To(BeTrue())
+			Expect(goerrors.Is(err, ErrInvalidTag)).To(BeFalse())
+		})
+
+		It("supports errors.As for the typed error", func() {
+			wrapped := Wrap(ErrInvalidAddress, WithIndex(1))
+			var typed *Error
+			Expect(goerrors.As(wrapped, &typed)).To(BeTrue())
+			Expect(typed.Index).To(Equal(1))
+		})
+	})
+})
@@ -2,11 +2,14 @@
 package kerl
 
 import (
-	"github.com/pkg/errors"
+	"encoding"
 	"hash"
 
+	"github.com/pkg/errors"
+
 	. "github.com/iotaledger/iota.go/consts"
 	keccak "github.com/iotaledger/iota.go/kerl/sha3"
+	"github.com/iotaledger/iota.go/sponge"
 	. "github.com/iotaledger/iota.go/trinary"
 )
 
@@ -15,6 +18,8 @@ type Kerl struct {
 	s hash.Hash
 }
 
+var _ sponge.SpongeFunction = (*Kerl)(nil)
+
 // NewKerl returns a new Kerl
 func NewKerl() *Kerl {
 	k := &Kerl{
@@ -75,3 +80,35 @@ func (k *Kerl) Absorb(in Trits) error {
 func (k *Kerl) Reset() {
 	k.s.Reset()
 }
+
+// AbsorbTrits fills the internal state of the sponge with the given trits.
+func (k *Kerl) AbsorbTrits(in Trits) error {
+	return k.Absorb(in)
+}
+
+// SqueezeTrits squeezes out trits of the given length, which must be a
+// multiple of HashTrinarySize.
+func (k *Kerl) SqueezeTrits(length int) (Trits, error) {
+	return k.Squeeze(length)
+}
+
+// Clone returns a deep copy of the Kerl sponge including its internal state.
+func (k *Kerl) Clone() sponge.SpongeFunction {
+	return &Kerl{s: k.s.(keccak.ShakeHash).Clone().(hash.Hash)}
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, so
+// mid-states of the sponge can be cached or persisted and resumed later.
+func (k *Kerl) MarshalBinary() ([]byte, error) {
+	return k.s.(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (k *Kerl) UnmarshalBinary(data []byte) error {
+	s := keccak.New384()
+	if err := s.(encoding.BinaryUnmarshaler).UnmarshalBinary(data); err != nil {
+		return err
+	}
+	k.s = s
+	return nil
+}
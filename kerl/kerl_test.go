@@ -34,6 +34,47 @@ var _ = Describe("Kerl", func() {
 		)
 	})
 
+	Context("state export and resume", func() {
+
+		in := MustTrytesToTrits("HHPELNTNJIOKLYDUW9NDULWPHCWFRPTDIUWLYUHQWWJVPAKKGKOAZFJPQJBLNDPALCVXGJLRBFSHATF9C")
+
+		It("should squeeze the same hash after a marshal and unmarshal round trip", func() {
+			k := NewKerl()
+			Expect(k.Absorb(in)).ToNot(HaveOccurred())
+
+			data, err := k.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+
+			expected, err := k.Squeeze(HashTrinarySize)
+			Expect(err).ToNot(HaveOccurred())
+
+			restored := NewKerl()
+			Expect(restored.UnmarshalBinary(data)).ToNot(HaveOccurred())
+			Expect(restored.Squeeze(HashTrinarySize)).To(Equal(expected))
+		})
+
+		It("should resume a sponge that is already squeezing", func() {
+			k := NewKerl()
+			Expect(k.Absorb(in)).ToNot(HaveOccurred())
+			_, err := k.Squeeze(HashTrinarySize)
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := k.MarshalBinary()
+			Expect(err).ToNot(HaveOccurred())
+
+			expected, err := k.Squeeze(HashTrinarySize)
+			Expect(err).ToNot(HaveOccurred())
+
+			restored := NewKerl()
+			Expect(restored.UnmarshalBinary(data)).ToNot(HaveOccurred())
+			Expect(restored.Squeeze(HashTrinarySize)).To(Equal(expected))
+		})
+
+		It("should return an error for data of the wrong size", func() {
+			Expect(NewKerl().UnmarshalBinary(make([]byte, 10))).To(HaveOccurred())
+		})
+	})
+
 	Context("with invalid tytes", func() {
 
 		var k *Kerl
@@ -4,6 +4,11 @@
 
 package sha3
 
+import (
+	"encoding/binary"
+	"errors"
+)
+
 // spongeDirection indicates the direction bytes are flowing through the sponge.
 type spongeDirection int
 
@@ -74,6 +79,64 @@ func (d *state) clone() *state {
 	return &ret
 }
 
+// marshaledSize is the size of a marshaled hash state: the 25 word sponge
+// state, rate, dsbyte, output length, sponge direction, buffer fill and the
+// input-output buffer.
+const marshaledSize = 25*8 + 5 + maxRate
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, so the
+// current hash state can be persisted and resumed later.
+func (d *state) MarshalBinary() ([]byte, error) {
+	data := make([]byte, marshaledSize)
+	for i, w := range d.a {
+		binary.LittleEndian.PutUint64(data[i*8:], w)
+	}
+	rest := data[25*8:]
+	rest[0] = byte(d.rate)
+	rest[1] = d.dsbyte
+	rest[2] = byte(d.outputLen)
+	rest[3] = byte(d.state)
+	// mirrors clone(): the fill is given by len() when absorbing and by cap()
+	// when squeezing
+	if d.state == spongeAbsorbing {
+		rest[4] = byte(len(d.buf))
+	} else {
+		rest[4] = byte(cap(d.buf))
+	}
+	copy(rest[5:], d.storage[:])
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (d *state) UnmarshalBinary(data []byte) error {
+	if len(data) != marshaledSize {
+		return errors.New("sha3: invalid hash state size")
+	}
+	rest := data[25*8:]
+	rate := int(rest[0])
+	direction := spongeDirection(rest[3])
+	fill := int(rest[4])
+	if rate <= 0 || rate > maxRate || fill > rate ||
+		(direction != spongeAbsorbing && direction != spongeSqueezing) {
+		return errors.New("sha3: invalid hash state")
+	}
+
+	for i := range d.a {
+		d.a[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	d.rate = rate
+	d.dsbyte = rest[1]
+	d.outputLen = int(rest[2])
+	d.state = direction
+	copy(d.storage[:], rest[5:])
+	if direction == spongeAbsorbing {
+		d.buf = d.storage[:fill]
+	} else {
+		d.buf = d.storage[d.rate-fill : d.rate]
+	}
+	return nil
+}
+
 // permute applies the KeccakF-1600 permutation. It handles
 // any input-output buffering.
 func (d *state) permute() {
@@ -0,0 +1,118 @@
+package mam
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Channel is the publishing side of a MAM stream. It maintains the current
+// and the next Merkle tree of one-time signing keys; each published message
+// consumes one leaf of the current tree and announces the next tree's root
+// once the current one runs out of leaves.
+type Channel struct {
+	seed    Trytes
+	mode    ChannelMode
+	secLvl  SecurityLevel
+	sideKey Trytes
+	// the amount of leaves per tree
+	count uint64
+	// the index of the next leaf to use within the current tree
+	index uint64
+	// the key index at which the current tree starts
+	start uint64
+
+	curTree  *MerkleTree
+	nextTree *MerkleTree
+}
+
+// NewChannel creates a new channel publishing in the given mode with trees of
+// the given leaf count. For restricted mode a side key must be supplied.
+func NewChannel(seed Trytes, mode ChannelMode, secLvl SecurityLevel, count uint64, sideKey ...Trytes) (*Channel, error) {
+	switch mode {
+	case ChannelModePublic, ChannelModePrivate:
+	case ChannelModeRestricted:
+		if len(sideKey) == 0 || len(sideKey[0]) == 0 {
+			return nil, ErrSideKeyRequired
+		}
+	default:
+		return nil, ErrInvalidChannelMode
+	}
+
+	ch := &Channel{seed: seed, mode: mode, secLvl: secLvl, count: count}
+	if mode == ChannelModeRestricted {
+		ch.sideKey = sideKey[0]
+	}
+
+	var err error
+	ch.curTree, err = NewMerkleTree(seed, ch.start, count, secLvl)
+	if err != nil {
+		return nil, err
+	}
+	ch.nextTree, err = NewMerkleTree(seed, ch.start+count, count, secLvl)
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// Mode returns the mode of the channel.
+func (ch *Channel) Mode() ChannelMode {
+	return ch.mode
+}
+
+// Root returns the root of the channel's current tree. Subscribers need it
+// (plus the side key in restricted mode) to fetch the channel's messages.
+func (ch *Channel) Root() Trytes {
+	return ch.curTree.Root()
+}
+
+// NextRoot returns the root of the channel's next tree.
+func (ch *Channel) NextRoot() Trytes {
+	return ch.nextTree.Root()
+}
+
+// SideKey returns the side key of a restricted channel.
+func (ch *Channel) SideKey() Trytes {
+	return ch.sideKey
+}
+
+// maskKey returns the key used for masking payloads in the channel's mode,
+// or an empty string for public channels.
+func (ch *Channel) maskKey(root Trytes) Trytes {
+	switch ch.mode {
+	case ChannelModePrivate:
+		return root
+	case ChannelModeRestricted:
+		return ch.sideKey
+	}
+	return ""
+}
+
+// advance moves the channel to the next leaf, rolling over to the next
+// tree when the current one is exhausted.
+func (ch *Channel) advance() error {
+	ch.index++
+	if ch.index < ch.count {
+		return nil
+	}
+	ch.index = 0
+	ch.start += ch.count
+	ch.curTree = ch.nextTree
+	nextTree, err := NewMerkleTree(ch.seed, ch.start+ch.count, ch.count, ch.secLvl)
+	if err != nil {
+		return err
+	}
+	ch.nextTree = nextTree
+	return nil
+}
+
+// MessageAddress returns the address on which messages of a channel with
+// the given root are attached: the root itself for public channels and the
+// hash of the root for private and restricted channels.
+func MessageAddress(root Trytes, mode ChannelMode) Hash {
+	if mode == ChannelModePublic {
+		return root
+	}
+	return curl.HashTrytes(root)
+}
@@ -0,0 +1,143 @@
+package mam
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// chunkMarker prefixes the payload of a chunk of a chunked stream.
+const chunkMarker = "MAM9CHUNK9MARKER99"
+
+// DefaultChunkSize is the chunk payload size used when no explicit
+// size is given.
+const DefaultChunkSize = 3 * sigFragmentTryteSize
+
+var (
+	// ErrNotAChunk gets returned when a message payload is not part of a
+	// chunked stream.
+	ErrNotAChunk = errors.New("message payload is not a chunk")
+	// ErrInvalidChunk gets returned when a chunk is malformed or does not
+	// belong to the stream being reassembled.
+	ErrInvalidChunk = errors.New("invalid chunk")
+	// ErrChunkChecksumMismatch gets returned when the reassembled payload
+	// does not match the checksum carried by the chunks.
+	ErrChunkChecksumMismatch = errors.New("reassembled payload does not match the stream checksum")
+)
+
+// Chunk is one piece of a payload split across consecutive channel messages.
+type Chunk struct {
+	// The index of the chunk within the stream.
+	Index uint64
+	// The total amount of chunks of the stream.
+	Count uint64
+	// The checksum over the complete payload, identifying the stream.
+	Checksum Trytes
+	// The piece of the payload carried by this chunk.
+	Data Trytes
+}
+
+// CreateChunkedMessages splits the given payload into chunks of the given
+// size and creates one channel message per chunk. Each chunk carries the
+// checksum over the complete payload so the subscriber can verify integrity
+// after reassembly. A chunkSize of 0 defaults to DefaultChunkSize.
+func (ch *Channel) CreateChunkedMessages(payload Trytes, chunkSize int) ([]*PreparedMessage, error) {
+	if err := ValidTrytes(payload); err != nil && len(payload) != 0 {
+		return nil, err
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	count := (len(payload) + chunkSize - 1) / chunkSize
+	if count == 0 {
+		count = 1
+	}
+	checksum := curl.HashTrytes(payload)
+
+	msgs := make([]*PreparedMessage, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunkPayload := chunkMarker +
+			encodeUint(uint64(i)) + encodeUint(uint64(count)) +
+			checksum + payload[i*chunkSize:end]
+		msg, err := ch.CreateMessage(chunkPayload)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// ParseChunk parses a chunk from the given message payload.
+func ParseChunk(payload Trytes) (*Chunk, error) {
+	if !strings.HasPrefix(string(payload), chunkMarker) {
+		return nil, ErrNotAChunk
+	}
+	rest := payload[len(chunkMarker):]
+	if uint64(len(rest)) < 2*uintFieldTryteSize+hashTryteSize {
+		return nil, ErrInvalidChunk
+	}
+	index := decodeUint(rest[:uintFieldTryteSize])
+	count := decodeUint(rest[uintFieldTryteSize : 2*uintFieldTryteSize])
+	rest = rest[2*uintFieldTryteSize:]
+	if count == 0 || index >= count {
+		return nil, ErrInvalidChunk
+	}
+	return &Chunk{
+		Index:    index,
+		Count:    count,
+		Checksum: rest[:hashTryteSize],
+		Data:     rest[hashTryteSize:],
+	}, nil
+}
+
+// Reassembler reassembles a chunked payload from channel messages. Chunks
+// may arrive in any order; chunks of a different stream than the one being
+// reassembled are rejected.
+type Reassembler struct {
+	checksum Trytes
+	count    uint64
+	chunks   map[uint64]Trytes
+}
+
+// NewReassembler creates a new reassembler.
+func NewReassembler() *Reassembler {
+	return &Reassembler{chunks: map[uint64]Trytes{}}
+}
+
+// Add adds the chunk carried by the given message to the reassembler. Once
+// all chunks of the stream have been added, the reassembled payload is
+// verified against the stream checksum and returned with complete set to
+// true. Messages which do not carry a chunk yield ErrNotAChunk.
+func (r *Reassembler) Add(msg *Message) (payload Trytes, complete bool, err error) {
+	chunk, err := ParseChunk(msg.Payload)
+	if err != nil {
+		return "", false, err
+	}
+	if r.count == 0 {
+		r.checksum = chunk.Checksum
+		r.count = chunk.Count
+	}
+	if chunk.Checksum != r.checksum || chunk.Count != r.count {
+		return "", false, ErrInvalidChunk
+	}
+	r.chunks[chunk.Index] = chunk.Data
+	if uint64(len(r.chunks)) < r.count {
+		return "", false, nil
+	}
+
+	var assembled Trytes
+	for i := uint64(0); i < r.count; i++ {
+		assembled += r.chunks[i]
+	}
+	if curl.HashTrytes(assembled) != r.checksum {
+		return "", false, ErrChunkChecksumMismatch
+	}
+	return assembled, true, nil
+}
@@ -0,0 +1,122 @@
+package mam
+
+import (
+	"strings"
+	"time"
+
+	"github.com/iotaledger/iota.go/converter"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// envelopeMarker prefixes the payload of an enveloped message.
+const envelopeMarker = "MAM9ENVELOPE9MARKER99"
+
+// ErrNotAnEnvelope gets returned when a message payload does not carry an
+// envelope.
+var ErrNotAnEnvelope = errors.New("message payload is not an envelope")
+
+// Envelope wraps a payload with structured metadata. The envelope is encoded
+// into the message payload and thereby covered by the message signature, so
+// consumers can rely on the metadata being authenticated.
+type Envelope struct {
+	// The time the payload was created.
+	Timestamp time.Time
+	// The media type of the payload, e.g. "application/json".
+	ContentType string
+	// An application defined schema identifier for the payload.
+	SchemaID string
+	// The actual payload.
+	Payload Trytes
+}
+
+// Encode encodes the envelope into trytes suitable as a message payload.
+func (env *Envelope) Encode() (Trytes, error) {
+	contentType, err := converter.ASCIIToTrytes(env.ContentType)
+	if err != nil {
+		return "", err
+	}
+	schemaID, err := converter.ASCIIToTrytes(env.SchemaID)
+	if err != nil {
+		return "", err
+	}
+	if err := ValidTrytes(env.Payload); err != nil && len(env.Payload) != 0 {
+		return "", err
+	}
+	return envelopeMarker +
+		encodeUint(uint64(env.Timestamp.Unix())) +
+		encodeUint(uint64(len(contentType))) + contentType +
+		encodeUint(uint64(len(schemaID))) + schemaID +
+		env.Payload, nil
+}
+
+// DecodeEnvelope decodes an envelope from the given message payload.
+func DecodeEnvelope(payload Trytes) (*Envelope, error) {
+	if !strings.HasPrefix(string(payload), envelopeMarker) {
+		return nil, ErrNotAnEnvelope
+	}
+	rest := payload[len(envelopeMarker):]
+	if uint64(len(rest)) < 3*uintFieldTryteSize {
+		return nil, ErrInvalidMessage
+	}
+	timestamp := decodeUint(rest[:uintFieldTryteSize])
+	rest = rest[uintFieldTryteSize:]
+
+	contentTypeLen, err := decodeLen(rest[:uintFieldTryteSize])
+	if err != nil {
+		return nil, err
+	}
+	rest = rest[uintFieldTryteSize:]
+	if len(rest)-uintFieldTryteSize < contentTypeLen {
+		return nil, ErrInvalidMessage
+	}
+	contentType, err := asciiField(rest[:contentTypeLen])
+	if err != nil {
+		return nil, err
+	}
+	rest = rest[contentTypeLen:]
+
+	schemaIDLen, err := decodeLen(rest[:uintFieldTryteSize])
+	if err != nil {
+		return nil, err
+	}
+	rest = rest[uintFieldTryteSize:]
+	if len(rest) < schemaIDLen {
+		return nil, ErrInvalidMessage
+	}
+	schemaID, err := asciiField(rest[:schemaIDLen])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		Timestamp:   time.Unix(int64(timestamp), 0).UTC(),
+		ContentType: contentType,
+		SchemaID:    schemaID,
+		Payload:     rest[schemaIDLen:],
+	}, nil
+}
+
+// asciiField converts an envelope string field back to ASCII, treating an
+// empty field as the empty string.
+func asciiField(trytes Trytes) (string, error) {
+	if len(trytes) == 0 {
+		return "", nil
+	}
+	return converter.TrytesToASCII(trytes)
+}
+
+// CreateEnvelopedMessage creates the next message of the channel carrying
+// the given envelope, authenticating its metadata along with the payload.
+func (ch *Channel) CreateEnvelopedMessage(env *Envelope) (*PreparedMessage, error) {
+	payload, err := env.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return ch.CreateMessage(payload)
+}
+
+// Envelope decodes the envelope carried by the message's payload.
+func (m *Message) Envelope() (*Envelope, error) {
+	return DecodeEnvelope(m.Payload)
+}
@@ -0,0 +1,181 @@
+package mam
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/iota.go/api"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/signing"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Message is a fetched and verified channel message.
+type Message struct {
+	// The root of the tree the message belongs to.
+	Root Trytes
+	// The root of the channel's next tree, used to follow the channel forward.
+	NextRoot Trytes
+	// The index of the leaf which signed the message.
+	Index uint64
+	// The unmasked payload of the message.
+	Payload Trytes
+}
+
+// the tryte length of the fixed message header (index, tree size,
+// security level, section length, audit path length)
+const headerTryteSize = 5 * uintFieldTryteSize
+
+// ParseMessage parses and verifies the given message trytes against the
+// given channel root: the signature is checked against the signing leaf and
+// the leaf's audit path must recompute to the root. The payload and next
+// root are unmasked according to the channel mode.
+func ParseMessage(msgTrytes Trytes, root Trytes, mode ChannelMode, sideKey ...Trytes) (*Message, error) {
+	proof, err := ExtractProof(msgTrytes, root)
+	if err != nil {
+		return nil, err
+	}
+	if !proof.Verify(root) {
+		return nil, ErrInvalidMessageSignature
+	}
+
+	sectionLen := decodeUint(msgTrytes[27:36])
+	section := msgTrytes[headerTryteSize : headerTryteSize+sectionLen]
+
+	if mode != ChannelModePublic {
+		key := root
+		if mode == ChannelModeRestricted {
+			if len(sideKey) == 0 || len(sideKey[0]) == 0 {
+				return nil, ErrSideKeyRequired
+			}
+			key = sideKey[0]
+		}
+		section = UnmaskTrytes(section, key)
+	}
+
+	return &Message{
+		Root:     root,
+		NextRoot: section[:hashTryteSize],
+		Index:    proof.Index,
+		Payload:  section[hashTryteSize:],
+	}, nil
+}
+
+// signedLeaf recovers the Merkle leaf (the signer's address) from the
+// signature over the given hash.
+func signedLeaf(hash Hash, signature Trytes, secLvl SecurityLevel) (Trytes, error) {
+	normalized := signing.NormalizedBundleHash(hash)
+	digests := make(Trits, 0, int(secLvl)*HashTrinarySize)
+	for i := 0; i < int(secLvl); i++ {
+		fragment := MustTrytesToTrits(signature[i*sigFragmentTryteSize : (i+1)*sigFragmentTryteSize])
+		digest, err := signing.Digest(normalized[i*KeySegmentsPerFragment:(i+1)*KeySegmentsPerFragment], fragment)
+		if err != nil {
+			return "", err
+		}
+		digests = append(digests, digest...)
+	}
+	addrTrits, err := signing.Address(digests)
+	if err != nil {
+		return "", err
+	}
+	return TritsToTrytes(addrTrits)
+}
+
+// Fetch fetches all currently available messages of the channel with the
+// given root, verifying each of them. Messages which do not verify against
+// the root are skipped. The returned messages are sorted by their leaf index.
+func Fetch(a *api.API, root Trytes, mode ChannelMode, sideKey ...Trytes) ([]*Message, error) {
+	txs, err := a.FindTransactionObjects(api.FindTransactionsQuery{
+		Addresses: Hashes{MessageAddress(root, mode)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := []*Message{}
+	seen := map[uint64]struct{}{}
+	for _, msgTrytes := range bundleMessages(txs) {
+		msg, err := ParseMessage(msgTrytes, root, mode, sideKey...)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[msg.Index]; ok {
+			continue
+		}
+		seen[msg.Index] = struct{}{}
+		msgs = append(msgs, msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Index < msgs[j].Index })
+	return msgs, nil
+}
+
+// bundleMessages reassembles the message trytes carried by each bundle
+// within the given transactions.
+func bundleMessages(txs transaction.Transactions) []Trytes {
+	byBundle := map[string]transaction.Transactions{}
+	for i := range txs {
+		byBundle[txs[i].Bundle] = append(byBundle[txs[i].Bundle], txs[i])
+	}
+	msgs := make([]Trytes, 0, len(byBundle))
+	for _, bundleTxs := range byBundle {
+		sort.Slice(bundleTxs, func(i, j int) bool {
+			return bundleTxs[i].CurrentIndex < bundleTxs[j].CurrentIndex
+		})
+		var msg Trytes
+		for i := range bundleTxs {
+			msg += bundleTxs[i].SignatureMessageFragment
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
+
+// Subscribe follows the channel with the given root forward: it polls for
+// new messages in the given interval, emits each verified message exactly
+// once on the returned channel and automatically moves on to the next tree
+// root as it is announced. The subscription ends when the context is done,
+// upon which the returned channel is closed.
+func Subscribe(ctx context.Context, a *api.API, root Trytes, mode ChannelMode, interval time.Duration, sideKey ...Trytes) <-chan *Message {
+	msgChan := make(chan *Message)
+	go func() {
+		defer close(msgChan)
+		currentRoot := root
+		seen := map[uint64]struct{}{}
+		var nextRoot Trytes
+		for {
+			msgs, err := Fetch(a, currentRoot, mode, sideKey...)
+			if err == nil {
+				for _, msg := range msgs {
+					if _, ok := seen[msg.Index]; ok {
+						continue
+					}
+					seen[msg.Index] = struct{}{}
+					nextRoot = msg.NextRoot
+					select {
+					case msgChan <- msg:
+					case <-ctx.Done():
+						return
+					}
+				}
+				// once the next tree has messages, follow the channel forward
+				if nextRoot != "" {
+					nextMsgs, err := Fetch(a, nextRoot, mode, sideKey...)
+					if err == nil && len(nextMsgs) > 0 {
+						currentRoot = nextRoot
+						nextRoot = ""
+						seen = map[uint64]struct{}{}
+						continue
+					}
+				}
+			}
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return msgChan
+}
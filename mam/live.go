@@ -0,0 +1,87 @@
+package mam
+
+import (
+	"context"
+	"time"
+
+	"github.com/iotaledger/iota.go/api"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// SubscribeLive follows the channel with the given root forward like
+// Subscribe, but additionally reacts to the given address feed: whenever an
+// address matching the channel's current message address appears on the feed,
+// the subscriber fetches immediately instead of waiting for the next polling
+// interval. The feed is typically wired to a node's ZMQ tx event stream,
+// delivering the address of every newly seen transaction; non matching
+// addresses are ignored, so the raw feed can be passed through unfiltered.
+// The subscription ends when the context is done, upon which the returned
+// channel is closed.
+func SubscribeLive(ctx context.Context, a *api.API, feed <-chan Hash, root Trytes, mode ChannelMode, interval time.Duration, sideKey ...Trytes) <-chan *Message {
+	msgChan := make(chan *Message)
+	go func() {
+		defer close(msgChan)
+		currentRoot := root
+		seen := map[uint64]struct{}{}
+		var nextRoot Trytes
+		for {
+			msgs, err := Fetch(a, currentRoot, mode, sideKey...)
+			if err == nil {
+				for _, msg := range msgs {
+					if _, ok := seen[msg.Index]; ok {
+						continue
+					}
+					seen[msg.Index] = struct{}{}
+					nextRoot = msg.NextRoot
+					select {
+					case msgChan <- msg:
+					case <-ctx.Done():
+						return
+					}
+				}
+				// once the next tree has messages, follow the channel forward
+				if nextRoot != "" {
+					nextMsgs, err := Fetch(a, nextRoot, mode, sideKey...)
+					if err == nil && len(nextMsgs) > 0 {
+						currentRoot = nextRoot
+						nextRoot = ""
+						seen = map[uint64]struct{}{}
+						continue
+					}
+				}
+			}
+			if !awaitActivity(ctx, feed, interval, currentRoot, nextRoot, mode) {
+				return
+			}
+		}
+	}()
+	return msgChan
+}
+
+// awaitActivity blocks until the polling interval elapses, a matching
+// address appears on the feed, or the context is done. It reports whether
+// the subscription should continue.
+func awaitActivity(ctx context.Context, feed <-chan Hash, interval time.Duration, currentRoot Trytes, nextRoot Trytes, mode ChannelMode) bool {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case addr, ok := <-feed:
+			if !ok {
+				// feed closed, fall back to pure polling
+				feed = nil
+				continue
+			}
+			if addr == MessageAddress(currentRoot, mode) {
+				return true
+			}
+			if nextRoot != "" && addr == MessageAddress(nextRoot, mode) {
+				return true
+			}
+		case <-timer.C:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
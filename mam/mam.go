@@ -0,0 +1,37 @@
+// Package mam provides Masked Authenticated Messaging: authenticated,
+// optionally encrypted message streams published as zero-value transactions.
+// A channel is a chain of Merkle trees of one-time signing keys; each message
+// is signed with one leaf key, proves its membership via a Merkle audit path
+// against the channel root and announces the root of the next tree.
+// Messages can be published in public, private and restricted mode.
+package mam
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ChannelMode is the mode in which a channel publishes its messages.
+type ChannelMode string
+
+const (
+	// ChannelModePublic publishes messages in plaintext on the channel root address.
+	ChannelModePublic ChannelMode = "public"
+	// ChannelModePrivate masks messages with the channel root.
+	ChannelModePrivate ChannelMode = "private"
+	// ChannelModeRestricted masks messages with a separate side key.
+	ChannelModeRestricted ChannelMode = "restricted"
+)
+
+var (
+	// ErrInvalidChannelMode gets returned for unknown channel modes.
+	ErrInvalidChannelMode = errors.New("invalid channel mode")
+	// ErrSideKeyRequired gets returned when a restricted channel is used without a side key.
+	ErrSideKeyRequired = errors.New("restricted mode requires a side key")
+	// ErrInvalidMessage gets returned when message trytes can not be parsed.
+	ErrInvalidMessage = errors.New("invalid message")
+	// ErrInvalidMessageSignature gets returned when the signature of a message does not
+	// verify against the channel root.
+	ErrInvalidMessageSignature = errors.New("invalid message signature")
+	// ErrMerkleLeafIndexOutOfBounds gets returned when an audit path for a non existent leaf is requested.
+	ErrMerkleLeafIndexOutOfBounds = errors.New("merkle leaf index out of bounds")
+)
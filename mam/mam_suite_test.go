@@ -0,0 +1,13 @@
+package mam_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestMam(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Mam Suite")
+}
@@ -0,0 +1,225 @@
+package mam_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/mam"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Mam", func() {
+
+	seed := strings.Repeat("NONSECURESEEDFORTESTS9ONLY9", 3)
+	sideKey := strings.Repeat("KEY9SIDE9", 9)
+
+	Context("MaskTrytes()/UnmaskTrytes()", func() {
+		It("round trips payloads under the same key", func() {
+			payload := "THEQUICKBROWNFOX9JUMPED"
+			masked := MaskTrytes(payload, sideKey)
+			Expect(masked).ToNot(Equal(payload))
+			Expect(UnmaskTrytes(masked, sideKey)).To(Equal(payload))
+		})
+	})
+
+	Context("MerkleTree", func() {
+		It("recomputes the root from every leaf's audit path", func() {
+			tree, err := NewMerkleTree(seed, 0, 3, SecurityLevelLow)
+			Expect(err).ToNot(HaveOccurred())
+			for index := uint64(0); index < tree.Size(); index++ {
+				key, err := LeafKey(seed, index, SecurityLevelLow)
+				Expect(err).ToNot(HaveOccurred())
+				leaf, err := LeafAddress(key)
+				Expect(err).ToNot(HaveOccurred())
+				path, err := tree.AuditPath(index)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(MerkleRoot(leaf, index, tree.Size(), path)).To(Equal(tree.Root()))
+			}
+		})
+
+		It("returns an error for out of bounds leaf indexes", func() {
+			tree, err := NewMerkleTree(seed, 0, 2, SecurityLevelLow)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = tree.AuditPath(2)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("CreateMessage()/ParseMessage()", func() {
+		It("round trips a public channel message", func() {
+			ch, err := NewChannel(seed, ChannelModePublic, SecurityLevelLow, 2)
+			Expect(err).ToNot(HaveOccurred())
+			msg, err := ch.CreateMessage("HELLO9WORLD")
+			Expect(err).ToNot(HaveOccurred())
+			parsed, err := ParseMessage(msg.Trytes, msg.Root, ChannelModePublic)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Payload).To(Equal("HELLO9WORLD"))
+			Expect(parsed.NextRoot).To(Equal(ch.NextRoot()))
+		})
+
+		It("round trips a restricted channel message", func() {
+			ch, err := NewChannel(seed, ChannelModeRestricted, SecurityLevelLow, 2, sideKey)
+			Expect(err).ToNot(HaveOccurred())
+			msg, err := ch.CreateMessage("SECRET9PAYLOAD")
+			Expect(err).ToNot(HaveOccurred())
+			parsed, err := ParseMessage(msg.Trytes, msg.Root, ChannelModeRestricted, sideKey)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Payload).To(Equal("SECRET9PAYLOAD"))
+		})
+
+		It("rejects messages with a tampered payload", func() {
+			ch, err := NewChannel(seed, ChannelModePublic, SecurityLevelLow, 2)
+			Expect(err).ToNot(HaveOccurred())
+			msg, err := ch.CreateMessage("HELLO9WORLD")
+			Expect(err).ToNot(HaveOccurred())
+			replacement := "A"
+			if strings.HasSuffix(string(msg.Trytes), "A") {
+				replacement = "B"
+			}
+			tampered := msg.Trytes[:len(msg.Trytes)-1] + replacement
+			_, err = ParseMessage(tampered, msg.Root, ChannelModePublic)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ExtractProof()", func() {
+		It("rejects malicious length encodings in the header", func() {
+			ch, err := NewChannel(seed, ChannelModePublic, SecurityLevelLow, 2)
+			Expect(err).ToNot(HaveOccurred())
+			msg, err := ch.CreateMessage("HELLO9WORLD")
+			Expect(err).ToNot(HaveOccurred())
+
+			// a section length encoding -1
+			negative := MustTritsToTrytes(PadTrits(IntToTrits(-1), 27))
+			tampered := msg.Trytes[:27] + negative + msg.Trytes[36:]
+			_, err = ExtractProof(tampered, msg.Root)
+			Expect(err).To(Equal(ErrInvalidMessage))
+
+			// an audit path count encoding -1
+			tampered = msg.Trytes[:36] + negative + msg.Trytes[45:]
+			_, err = ExtractProof(tampered, msg.Root)
+			Expect(err).To(Equal(ErrInvalidMessage))
+
+			// an audit path count far exceeding the message
+			huge := MustTritsToTrytes(PadTrits(IntToTrits(1<<40), 27))
+			tampered = msg.Trytes[:36] + huge + msg.Trytes[45:]
+			_, err = ExtractProof(tampered, msg.Root)
+			Expect(err).To(Equal(ErrInvalidMessage))
+		})
+	})
+
+	Context("CreateChunkedMessages()/Reassembler", func() {
+		It("splits and reassembles a large payload", func() {
+			ch, err := NewChannel(seed, ChannelModePublic, SecurityLevelLow, 4)
+			Expect(err).ToNot(HaveOccurred())
+			payload := Trytes(strings.Repeat("LARGE9PAYLOAD9", 6))
+			msgs, err := ch.CreateChunkedMessages(payload, 27)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(msgs)).To(BeNumerically(">", 1))
+
+			reassembler := NewReassembler()
+			// add the chunks out of order
+			for i := len(msgs) - 1; i >= 0; i-- {
+				parsed, err := ParseMessage(msgs[i].Trytes, msgs[i].Root, ChannelModePublic)
+				Expect(err).ToNot(HaveOccurred())
+				assembled, complete, err := reassembler.Add(parsed)
+				Expect(err).ToNot(HaveOccurred())
+				if i == 0 {
+					Expect(complete).To(BeTrue())
+					Expect(assembled).To(Equal(payload))
+				} else {
+					Expect(complete).To(BeFalse())
+				}
+			}
+		})
+
+		It("rejects payloads which are not chunks", func() {
+			_, err := ParseChunk("JUSTSOMETRYTES9")
+			Expect(err).To(Equal(ErrNotAChunk))
+		})
+	})
+
+	Context("Envelope", func() {
+		It("round trips authenticated metadata with the payload", func() {
+			ch, err := NewChannel(seed, ChannelModeRestricted, SecurityLevelLow, 2, sideKey)
+			Expect(err).ToNot(HaveOccurred())
+			env := &Envelope{
+				Timestamp:   time.Unix(1500000000, 0).UTC(),
+				ContentType: "application/json",
+				SchemaID:    "sensor-batch-v1",
+				Payload:     "SENSOR9DATA",
+			}
+			msg, err := ch.CreateEnvelopedMessage(env)
+			Expect(err).ToNot(HaveOccurred())
+			parsed, err := ParseMessage(msg.Trytes, msg.Root, ChannelModeRestricted, sideKey)
+			Expect(err).ToNot(HaveOccurred())
+			decoded, err := parsed.Envelope()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(Equal(env))
+		})
+
+		It("rejects payloads without an envelope", func() {
+			_, err := DecodeEnvelope("JUSTSOMETRYTES9")
+			Expect(err).To(Equal(ErrNotAnEnvelope))
+		})
+
+		It("rejects negative length encodings in the header", func() {
+			env := &Envelope{ContentType: "application/json", SchemaID: "v1", Payload: "PAYLOAD9"}
+			encoded, err := env.Encode()
+			Expect(err).ToNot(HaveOccurred())
+			// overwrite the content type length field with an encoding of -1;
+			// the field sits after the 21 tryte marker and the timestamp
+			negative := MustTritsToTrytes(PadTrits(IntToTrits(-1), 27))
+			tampered := encoded[:30] + negative + encoded[39:]
+			_, err = DecodeEnvelope(tampered)
+			Expect(err).To(Equal(ErrInvalidMessage))
+		})
+	})
+
+	Context("VerifyMessage()", func() {
+		It("verifies a restricted message without the side key", func() {
+			ch, err := NewChannel(seed, ChannelModeRestricted, SecurityLevelLow, 2, sideKey)
+			Expect(err).ToNot(HaveOccurred())
+			msg, err := ch.CreateMessage("SECRET9PAYLOAD")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(VerifyMessage(msg.Trytes, msg.Root)).To(Succeed())
+
+			proof, err := ExtractProof(msg.Trytes, msg.Root)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(proof.Verify(msg.Root)).To(BeTrue())
+			Expect(proof.Verify(strings.Repeat("9", 81))).To(BeFalse())
+		})
+	})
+
+	Context("RotateSideKey()", func() {
+		It("announces the new key and re-keys observing subscribers", func() {
+			newSideKey := strings.Repeat("KEY9FRESH", 9)
+			ch, err := NewChannel(seed, ChannelModeRestricted, SecurityLevelLow, 4, sideKey)
+			Expect(err).ToNot(HaveOccurred())
+			ring := NewSideKeyRing(sideKey, 0)
+
+			announce, err := ch.RotateSideKey(newSideKey)
+			Expect(err).ToNot(HaveOccurred())
+			parsed, err := ring.Parse(announce.Trytes, announce.Root)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ring.Observe(parsed)).To(BeTrue())
+			Expect(ring.Current()).To(Equal(newSideKey))
+
+			msg, err := ch.CreateMessage("AFTER9ROTATION")
+			Expect(err).ToNot(HaveOccurred())
+			parsed, err = ring.Parse(msg.Trytes, msg.Root)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Payload).To(Equal("AFTER9ROTATION"))
+		})
+
+		It("rejects rotation on non restricted channels", func() {
+			ch, err := NewChannel(seed, ChannelModePublic, SecurityLevelLow, 2)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ch.RotateSideKey(sideKey)
+			Expect(err).To(Equal(ErrNotARestrictedChannel))
+		})
+	})
+})
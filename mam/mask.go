@@ -0,0 +1,53 @@
+package mam
+
+import (
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// keystream generates length trits of keystream derived from the given key.
+func keystream(key Trytes, length int) Trits {
+	c := curl.NewCurl()
+	c.Absorb(key)
+	stream := make(Trits, 0, length)
+	for len(stream) < length {
+		stream = append(stream, MustTrytesToTrits(c.Squeeze())...)
+	}
+	return stream[:length]
+}
+
+// MaskTrytes masks the given trytes with a keystream derived from the given
+// key by balanced trit-wise addition. Masking is an involution with
+// UnmaskTrytes under the same key.
+func MaskTrytes(payload Trytes, key Trytes) Trytes {
+	payloadTrits := MustTrytesToTrits(payload)
+	stream := keystream(key, len(payloadTrits))
+	masked := make(Trits, len(payloadTrits))
+	for i := range payloadTrits {
+		masked[i] = addTrit(payloadTrits[i], stream[i])
+	}
+	return MustTritsToTrytes(masked)
+}
+
+// UnmaskTrytes reverses MaskTrytes under the same key.
+func UnmaskTrytes(masked Trytes, key Trytes) Trytes {
+	maskedTrits := MustTrytesToTrits(masked)
+	stream := keystream(key, len(maskedTrits))
+	payload := make(Trits, len(maskedTrits))
+	for i := range maskedTrits {
+		payload[i] = addTrit(maskedTrits[i], -stream[i])
+	}
+	return MustTritsToTrytes(payload)
+}
+
+// addTrit adds two trits in balanced ternary, wrapping around at the domain edges.
+func addTrit(a, b int8) int8 {
+	sum := a + b
+	switch {
+	case sum > 1:
+		return sum - 3
+	case sum < -1:
+		return sum + 3
+	}
+	return sum
+}
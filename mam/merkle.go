@@ -0,0 +1,140 @@
+package mam
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/signing"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// MerkleTree is a Merkle tree over the one-time signing keys of a channel.
+// Leaf i is the address of the key derived from the channel seed at
+// index start+i; the root identifies the channel tree.
+type MerkleTree struct {
+	start  uint64
+	secLvl SecurityLevel
+	// layers[0] holds the leaves, the last layer holds only the root.
+	layers [][]Trytes
+}
+
+// NewMerkleTree computes a Merkle tree of the given size over the keys
+// derived from the given seed, starting at the given key index.
+func NewMerkleTree(seed Trytes, start uint64, count uint64, secLvl SecurityLevel) (*MerkleTree, error) {
+	leaves := make([]Trytes, count)
+	for i := uint64(0); i < count; i++ {
+		key, err := LeafKey(seed, start+i, secLvl)
+		if err != nil {
+			return nil, err
+		}
+		leaf, err := LeafAddress(key)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = leaf
+	}
+
+	layers := [][]Trytes{leaves}
+	for len(layers[len(layers)-1]) > 1 {
+		layer := layers[len(layers)-1]
+		next := make([]Trytes, 0, (len(layer)+1)/2)
+		for i := 0; i < len(layer); i += 2 {
+			if i+1 < len(layer) {
+				next = append(next, hashPair(layer[i], layer[i+1]))
+				continue
+			}
+			// odd node without a sibling is promoted to the next layer
+			next = append(next, layer[i])
+		}
+		layers = append(layers, next)
+	}
+	return &MerkleTree{start: start, secLvl: secLvl, layers: layers}, nil
+}
+
+// Root returns the root of the tree.
+func (t *MerkleTree) Root() Trytes {
+	return t.layers[len(t.layers)-1][0]
+}
+
+// Size returns the amount of leaves of the tree.
+func (t *MerkleTree) Size() uint64 {
+	return uint64(len(t.layers[0]))
+}
+
+// AuditPath returns the sibling hashes needed to recompute the root
+// from the leaf with the given index.
+func (t *MerkleTree) AuditPath(index uint64) (Hashes, error) {
+	if index >= t.Size() {
+		return nil, ErrMerkleLeafIndexOutOfBounds
+	}
+	path := Hashes{}
+	i := int(index)
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		switch {
+		case i%2 == 1:
+			path = append(path, layer[i-1])
+		case i+1 < len(layer):
+			path = append(path, layer[i+1])
+		default:
+			// promoted node, no sibling on this layer
+		}
+		i /= 2
+	}
+	return path, nil
+}
+
+// MerkleRoot recomputes the root of a tree with the given leaf count from the
+// given leaf, its index and its audit path. Lightweight verifiers use it
+// together with the audit path carried inside a message to check membership.
+func MerkleRoot(leaf Trytes, index uint64, count uint64, path Hashes) Trytes {
+	node := leaf
+	i := index
+	width := count
+	p := 0
+	for width > 1 {
+		switch {
+		case i%2 == 1:
+			if p >= len(path) {
+				return ""
+			}
+			node = hashPair(path[p], node)
+			p++
+		case i+1 < width:
+			if p >= len(path) {
+				return ""
+			}
+			node = hashPair(node, path[p])
+			p++
+		default:
+			// promoted node, nothing to combine on this layer
+		}
+		i /= 2
+		width = (width + 1) / 2
+	}
+	return node
+}
+
+func hashPair(left, right Trytes) Trytes {
+	return curl.HashTrytes(left + right)
+}
+
+// LeafKey derives the one-time signing key for the given leaf index.
+func LeafKey(seed Trytes, index uint64, secLvl SecurityLevel) (Trits, error) {
+	subseed, err := signing.Subseed(seed, index)
+	if err != nil {
+		return nil, err
+	}
+	return signing.Key(subseed, secLvl)
+}
+
+// LeafAddress computes the address (Merkle leaf) of the given one-time signing key.
+func LeafAddress(key Trits) (Trytes, error) {
+	digests, err := signing.Digests(key)
+	if err != nil {
+		return "", err
+	}
+	addrTrits, err := signing.Address(digests)
+	if err != nil {
+		return "", err
+	}
+	return TritsToTrytes(addrTrits)
+}
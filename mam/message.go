@@ -0,0 +1,148 @@
+package mam
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/signing"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+const (
+	// the tryte length of an unsigned integer field in the message layout
+	uintFieldTryteSize = 9
+	// the tryte length of one signature fragment
+	sigFragmentTryteSize = SignatureMessageFragmentTrinarySize / 3
+	// the tryte length of a hash
+	hashTryteSize = HashTrinarySize / 3
+)
+
+// PreparedMessage is a message ready to be attached to the Tangle.
+type PreparedMessage struct {
+	// The address on which the message is attached.
+	Address Hash
+	// The root of the tree the message belongs to.
+	Root Trytes
+	// The index of the leaf used for signing the message.
+	Index uint64
+	// The full message trytes placed into the bundle's message fragments.
+	Trytes Trytes
+}
+
+// CreateMessage creates the next message of the channel carrying the given
+// payload: the payload and next root are masked according to the channel
+// mode, signed with the current leaf key and completed with the leaf's
+// Merkle audit path. The channel advances to its next leaf.
+func (ch *Channel) CreateMessage(payload Trytes) (*PreparedMessage, error) {
+	if err := ValidTrytes(payload); err != nil && len(payload) != 0 {
+		return nil, err
+	}
+	root := ch.curTree.Root()
+	index := ch.index
+
+	// masked section: next root plus payload
+	section := ch.nextTree.Root() + payload
+	if key := ch.maskKey(root); key != "" {
+		section = MaskTrytes(section, key)
+	}
+
+	header := encodeUint(index) + encodeUint(ch.curTree.Size()) +
+		encodeUint(uint64(ch.secLvl)) + encodeUint(uint64(len(section)))
+
+	// sign the root, header and masked section with the leaf's one-time key
+	messageHash := curl.HashTrytes(root + header + section)
+	signature, err := ch.sign(messageHash, index)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := ch.curTree.AuditPath(index)
+	if err != nil {
+		return nil, err
+	}
+	pathTrytes := strings.Join(hashesToStrings(path), "")
+
+	msgTrytes := header + encodeUint(uint64(len(path))) + section + signature + Trytes(pathTrytes)
+
+	if err := ch.advance(); err != nil {
+		return nil, err
+	}
+	return &PreparedMessage{
+		Address: MessageAddress(root, ch.mode),
+		Root:    root,
+		Index:   index,
+		Trytes:  msgTrytes,
+	}, nil
+}
+
+// sign signs the given hash with the one-time key of the given leaf.
+func (ch *Channel) sign(hash Hash, index uint64) (Trytes, error) {
+	key, err := LeafKey(ch.seed, ch.start+index, ch.secLvl)
+	if err != nil {
+		return "", err
+	}
+	normalized := signing.NormalizedBundleHash(hash)
+	var signature Trytes
+	for i := 0; i < int(ch.secLvl); i++ {
+		fragment, err := signing.SignatureFragment(
+			Trits(normalized[i*KeySegmentsPerFragment:(i+1)*KeySegmentsPerFragment]),
+			key[i*KeyFragmentLength:(i+1)*KeyFragmentLength],
+		)
+		if err != nil {
+			return "", err
+		}
+		fragmentTrytes, err := TritsToTrytes(fragment)
+		if err != nil {
+			return "", err
+		}
+		signature += fragmentTrytes
+	}
+	return signature, nil
+}
+
+// Attach attaches the given prepared message to the Tangle as a zero-value
+// bundle on the message's address.
+func Attach(a *api.API, msg *PreparedMessage, depth uint64, mwm uint64) (bundle.Bundle, error) {
+	transfers := bundle.Transfers{{
+		Address: msg.Address,
+		Value:   0,
+		Message: msg.Trytes,
+		Tag:     "MAM",
+	}}
+	trytes, err := a.PrepareTransfers(strings.Repeat("9", 81), transfers, api.PrepareTransfersOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return a.SendTrytes(trytes, depth, mwm)
+}
+
+func encodeUint(value uint64) Trytes {
+	return MustTritsToTrytes(PadTrits(IntToTrits(int64(value)), uintFieldTryteSize*3))
+}
+
+func decodeUint(trytes Trytes) uint64 {
+	return uint64(TritsToInt(MustTrytesToTrits(trytes)))
+}
+
+// decodeLen decodes a length or count header field, rejecting encodings
+// which do not represent a non negative integer. Length fields coming from
+// unverified messages must be decoded with this instead of decodeUint, as
+// the latter maps negative encodings to huge unsigned values.
+func decodeLen(trytes Trytes) (int, error) {
+	value, err := TritsToIntChecked(MustTrytesToTrits(trytes))
+	if err != nil || value < 0 {
+		return 0, ErrInvalidMessage
+	}
+	return int(value), nil
+}
+
+func hashesToStrings(hashes Hashes) []string {
+	strs := make([]string, len(hashes))
+	for i, hash := range hashes {
+		strs[i] = string(hash)
+	}
+	return strs
+}
@@ -0,0 +1,88 @@
+package mam
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Proof is a standalone Merkle membership proof extracted from a channel
+// message: the signing leaf together with the data needed to recompute the
+// channel root. It can be verified without any channel state or side key.
+type Proof struct {
+	// The Merkle leaf (the signer's address) recovered from the signature.
+	Leaf Trytes
+	// The index of the leaf within the tree.
+	Index uint64
+	// The amount of leaves of the tree.
+	TreeSize uint64
+	// The audit path from the leaf up to the root.
+	Path Hashes
+}
+
+// ExtractProof extracts the Merkle membership proof from the given message
+// trytes. The signature is verified implicitly: a forged signature recovers a
+// different leaf, for which the proof will not verify against the channel
+// root. Since only the signed (still masked) section is covered, no channel
+// mode or side key is required.
+func ExtractProof(msgTrytes Trytes, root Trytes) (*Proof, error) {
+	if len(msgTrytes) < headerTryteSize {
+		return nil, ErrInvalidMessage
+	}
+	index := decodeUint(msgTrytes[:9])
+	treeSize := decodeUint(msgTrytes[9:18])
+	secLvl := decodeUint(msgTrytes[18:27])
+	sectionLen, err := decodeLen(msgTrytes[27:36])
+	if err != nil {
+		return nil, err
+	}
+	pathCount, err := decodeLen(msgTrytes[36:45])
+	if err != nil {
+		return nil, err
+	}
+
+	if secLvl < 1 || secLvl > 3 || sectionLen < hashTryteSize {
+		return nil, ErrInvalidMessage
+	}
+	// the length fields are attacker controlled, so bound each one against
+	// the remaining trytes before slicing
+	sigLen := int(secLvl) * sigFragmentTryteSize
+	rest := len(msgTrytes) - headerTryteSize
+	if sectionLen > rest-sigLen || pathCount > (rest-sectionLen-sigLen)/hashTryteSize {
+		return nil, ErrInvalidMessage
+	}
+
+	section := msgTrytes[headerTryteSize : headerTryteSize+sectionLen]
+	signature := msgTrytes[headerTryteSize+sectionLen : headerTryteSize+sectionLen+sigLen]
+	path := make(Hashes, pathCount)
+	pathOffset := headerTryteSize + sectionLen + sigLen
+	for i := 0; i < pathCount; i++ {
+		path[i] = msgTrytes[pathOffset+i*hashTryteSize : pathOffset+(i+1)*hashTryteSize]
+	}
+
+	messageHash := curl.HashTrytes(root + msgTrytes[:36] + section)
+	leaf, err := signedLeaf(messageHash, signature, SecurityLevel(secLvl))
+	if err != nil {
+		return nil, err
+	}
+	return &Proof{Leaf: leaf, Index: index, TreeSize: treeSize, Path: path}, nil
+}
+
+// Verify reports whether the proof verifies against the given channel root.
+func (p *Proof) Verify(root Trytes) bool {
+	return MerkleRoot(p.Leaf, p.Index, p.TreeSize, p.Path) == root
+}
+
+// VerifyMessage extracts and verifies the Merkle membership proof of the
+// given message trytes against the given channel root, enabling auditors
+// which only hold roots to validate message authenticity.
+func VerifyMessage(msgTrytes Trytes, root Trytes) error {
+	proof, err := ExtractProof(msgTrytes, root)
+	if err != nil {
+		return err
+	}
+	if !proof.Verify(root) {
+		return ErrInvalidMessageSignature
+	}
+	return nil
+}
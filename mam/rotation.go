@@ -0,0 +1,104 @@
+package mam
+
+import (
+	"strings"
+
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// keyRotationMarker prefixes the payload of a side key rotation
+// announce message.
+const keyRotationMarker = "KEYROTATION9ANNOUNCEMENT9MARKER99"
+
+// ErrNotARestrictedChannel gets returned when a key rotation is attempted
+// on a non restricted channel.
+var ErrNotARestrictedChannel = errors.New("side key rotation requires a restricted channel")
+
+// RotateSideKey rotates the side key of a restricted channel: it creates an
+// announce message carrying the new side key, masked with the still current
+// key, and then switches the channel over to the new key. Subscribers which
+// process the announce message via SideKeyRing.Observe() re-key automatically.
+// The returned message must be attached like any other channel message.
+func (ch *Channel) RotateSideKey(newSideKey Trytes) (*PreparedMessage, error) {
+	if ch.mode != ChannelModeRestricted {
+		return nil, ErrNotARestrictedChannel
+	}
+	if len(newSideKey) == 0 {
+		return nil, ErrSideKeyRequired
+	}
+	if err := ValidTrytes(newSideKey); err != nil {
+		return nil, err
+	}
+	msg, err := ch.CreateMessage(keyRotationMarker + newSideKey)
+	if err != nil {
+		return nil, err
+	}
+	ch.sideKey = newSideKey
+	return msg, nil
+}
+
+// ParseKeyRotation checks whether the given message is a side key rotation
+// announcement and if so returns the announced new side key.
+func ParseKeyRotation(msg *Message) (Trytes, bool) {
+	if !strings.HasPrefix(string(msg.Payload), keyRotationMarker) {
+		return "", false
+	}
+	return msg.Payload[len(keyRotationMarker):], true
+}
+
+// SideKeyRing tracks the side keys of a restricted channel on the subscriber
+// side. It keeps a window of previous keys so that messages published shortly
+// before a rotation (or fetched out of order) can still be unmasked during
+// the overlap period.
+type SideKeyRing struct {
+	// the known side keys, most recent last
+	keys []Trytes
+	// the maximum amount of keys kept
+	window int
+}
+
+// NewSideKeyRing creates a new key ring seeded with the given initial side
+// key, keeping up to window previous keys during rotations. A window of 0
+// defaults to 2 (the current and one previous key).
+func NewSideKeyRing(initialSideKey Trytes, window int) *SideKeyRing {
+	if window == 0 {
+		window = 2
+	}
+	return &SideKeyRing{keys: []Trytes{initialSideKey}, window: window}
+}
+
+// Current returns the most recent side key of the ring.
+func (kr *SideKeyRing) Current() Trytes {
+	return kr.keys[len(kr.keys)-1]
+}
+
+// Observe inspects the given message for a key rotation announcement and
+// adds the announced key to the ring. It reports whether a rotation happened.
+func (kr *SideKeyRing) Observe(msg *Message) bool {
+	newKey, ok := ParseKeyRotation(msg)
+	if !ok {
+		return false
+	}
+	kr.keys = append(kr.keys, newKey)
+	if len(kr.keys) > kr.window {
+		kr.keys = kr.keys[len(kr.keys)-kr.window:]
+	}
+	return true
+}
+
+// Parse tries to parse and verify the given message trytes with every side
+// key of the ring, newest first, returning the first successful result.
+func (kr *SideKeyRing) Parse(msgTrytes Trytes, root Trytes) (*Message, error) {
+	var firstErr error
+	for i := len(kr.keys) - 1; i >= 0; i-- {
+		msg, err := ParseMessage(msgTrytes, root, ChannelModeRestricted, kr.keys[i])
+		if err == nil {
+			return msg, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
@@ -0,0 +1,139 @@
+// Package mobile provides a gomobile friendly facade over the library for
+// embedding it into iOS/Android wallets via gomobile bind. The API surface
+// only uses types supported by the binding generator: strings, signed
+// integers and exported struct pointers with methods, no slices of slices,
+// interfaces or unsigned integers.
+package mobile
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/iotaledger/iota.go/address"
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidArgument gets returned when an argument is out of range.
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// TryteList is a list of trytes strings.
+type TryteList struct {
+	items []Trytes
+}
+
+// Count returns the amount of items of the list.
+func (l *TryteList) Count() int {
+	return len(l.items)
+}
+
+// Get returns the item at the given index.
+func (l *TryteList) Get(index int) string {
+	if index < 0 || index >= len(l.items) {
+		return ""
+	}
+	return string(l.items[index])
+}
+
+// GenerateSeed generates a new cryptographically secure random seed.
+func GenerateSeed() (string, error) {
+	alphabetLength := big.NewInt(int64(len(TryteAlphabet)))
+	seed := make([]byte, HashTrytesSize)
+	for i := range seed {
+		index, err := rand.Int(rand.Reader, alphabetLength)
+		if err != nil {
+			return "", err
+		}
+		seed[i] = TryteAlphabet[index.Int64()]
+	}
+	return string(seed), nil
+}
+
+// NewAddress derives the address with the given index and security level
+// (1-3) from the given seed, optionally including the checksum.
+func NewAddress(seed string, index int64, security int, withChecksum bool) (string, error) {
+	if index < 0 {
+		return "", errors.Wrap(ErrInvalidArgument, "index must not be negative")
+	}
+	addr, err := address.GenerateAddress(seed, uint64(index), SecurityLevel(security), withChecksum)
+	if err != nil {
+		return "", err
+	}
+	return string(addr), nil
+}
+
+// NewAddresses derives count addresses starting at the given index.
+func NewAddresses(seed string, start int64, count int, security int, withChecksum bool) (*TryteList, error) {
+	if start < 0 || count < 1 {
+		return nil, errors.Wrap(ErrInvalidArgument, "start must not be negative and count must be positive")
+	}
+	addrs, err := address.GenerateAddresses(seed, uint64(start), uint64(count), SecurityLevel(security), withChecksum)
+	if err != nil {
+		return nil, err
+	}
+	return &TryteList{items: addrs}, nil
+}
+
+// DoPoW performs proof of work on the given single transaction trytes using
+// the fastest available implementation and returns the transaction trytes
+// with the computed nonce.
+func DoPoW(trytes string, mwm int) (string, error) {
+	_, powFunc := pow.GetFastestProofOfWorkImpl()
+	nonce, err := powFunc(Trytes(trytes), int(mwm))
+	if err != nil {
+		return "", err
+	}
+	return string(trytes[:NonceTrinaryOffset/3]) + string(nonce), nil
+}
+
+// Client talks to a node.
+type Client struct {
+	api *api.API
+}
+
+// NewClient creates a new client using the node at the given URI.
+func NewClient(uri string) (*Client, error) {
+	a, err := api.ComposeAPI(api.HTTPClientSettings{URI: uri})
+	if err != nil {
+		return nil, err
+	}
+	return &Client{api: a}, nil
+}
+
+// PrepareTransfer prepares a transfer of the given value to the given
+// address and returns the signed bundle trytes in attachment order, ready
+// for attachToTangle.
+func (c *Client) PrepareTransfer(seed string, addr string, value int64, tag string, message string) (*TryteList, error) {
+	if value < 0 {
+		return nil, errors.Wrap(ErrInvalidArgument, "value must not be negative")
+	}
+	transfers := bundle.Transfers{{
+		Address: Hash(addr),
+		Value:   uint64(value),
+		Tag:     Trytes(tag),
+		Message: Trytes(message),
+	}}
+	trytes, err := c.api.PrepareTransfers(seed, transfers, api.PrepareTransfersOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &TryteList{items: trytes}, nil
+}
+
+// Send prepares a transfer like PrepareTransfer and attaches, stores and
+// broadcasts it, returning the hash of the resulting bundle.
+func (c *Client) Send(seed string, addr string, value int64, tag string, message string, depth int, mwm int) (string, error) {
+	prepared, err := c.PrepareTransfer(seed, addr, value, tag, message)
+	if err != nil {
+		return "", err
+	}
+	bndl, err := c.api.SendTrytes(prepared.items, uint64(depth), uint64(mwm))
+	if err != nil {
+		return "", err
+	}
+	return string(bndl[0].Bundle), nil
+}
@@ -0,0 +1,157 @@
+// Package paymenturi provides building and parsing of iota: payment URIs
+// for interoperable payment requests between wallets.
+package paymenturi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/iota.go/checksum"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/guards"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// Scheme is the URI scheme of payment URIs.
+const Scheme = "iota"
+
+var (
+	// ErrInvalidPaymentURI gets returned when a payment URI can not be parsed.
+	ErrInvalidPaymentURI = errors.New("invalid payment uri")
+	// ErrInvalidPaymentRequest gets returned when a payment request holds
+	// invalid fields.
+	ErrInvalidPaymentRequest = errors.New("invalid payment request")
+)
+
+// PaymentRequest is a request for a payment to a given address.
+type PaymentRequest struct {
+	// The address to pay to, including its checksum.
+	Address Hash
+	// The requested amount in iotas. Zero requests no specific amount.
+	Amount uint64
+	// The tag to use for the payment. Optional.
+	Tag Trytes
+	// A human readable message describing the payment. Optional and not
+	// part of the compact form.
+	Message string
+}
+
+// Validate checks whether the fields of the payment request are valid.
+func (r *PaymentRequest) Validate() error {
+	if !guards.IsTrytesOfExactLength(r.Address, AddressWithChecksumTrytesSize) {
+		return errors.Wrap(ErrInvalidPaymentRequest, "address must be 90 trytes")
+	}
+	if err := checksum.ValidChecksum(r.Address); err != nil {
+		return errors.Wrap(ErrInvalidPaymentRequest, err.Error())
+	}
+	if r.Tag != "" && !guards.IsTrytesOfMaxLength(r.Tag, TagTrinarySize/3) {
+		return errors.Wrap(ErrInvalidPaymentRequest, "invalid tag")
+	}
+	return nil
+}
+
+// URI renders the payment request as an iota: URI, e.g.
+// "iota:ADDRESS...?amount=1000&tag=EXAMPLE&message=an%20example".
+func (r *PaymentRequest) URI() (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString(Scheme + ":" + string(r.Address))
+	query := url.Values{}
+	if r.Amount != 0 {
+		query.Set("amount", strconv.FormatUint(r.Amount, 10))
+	}
+	if r.Tag != "" {
+		query.Set("tag", string(r.Tag))
+	}
+	if r.Message != "" {
+		query.Set("message", r.Message)
+	}
+	if len(query) != 0 {
+		sb.WriteString("?" + query.Encode())
+	}
+	return sb.String(), nil
+}
+
+// Compact renders the payment request in the QR-friendly compact form
+// "IOTA:<address>:<amount>:<tag>", using only characters of the QR code
+// alphanumeric mode. Trailing empty fields and the message are omitted.
+func (r *PaymentRequest) Compact() (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+	compact := strings.ToUpper(Scheme) + ":" + string(r.Address)
+	if r.Tag != "" {
+		return compact + ":" + strconv.FormatUint(r.Amount, 10) + ":" + string(r.Tag), nil
+	}
+	if r.Amount != 0 {
+		return compact + ":" + strconv.FormatUint(r.Amount, 10), nil
+	}
+	return compact, nil
+}
+
+// Parse parses a payment request from either the URI or the compact form.
+func Parse(s string) (*PaymentRequest, error) {
+	switch {
+	case strings.HasPrefix(s, Scheme+":"):
+		return parseURI(s)
+	case strings.HasPrefix(s, strings.ToUpper(Scheme)+":"):
+		return parseCompact(s)
+	default:
+		return nil, errors.Wrap(ErrInvalidPaymentURI, "unknown scheme")
+	}
+}
+
+func parseURI(s string) (*PaymentRequest, error) {
+	parsed, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidPaymentURI, err.Error())
+	}
+	if parsed.Scheme != Scheme || parsed.Opaque == "" {
+		return nil, ErrInvalidPaymentURI
+	}
+	query, err := url.ParseQuery(parsed.RawQuery)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidPaymentURI, err.Error())
+	}
+	request := &PaymentRequest{
+		Address: Hash(parsed.Opaque),
+		Tag:     Trytes(query.Get("tag")),
+		Message: query.Get("message"),
+	}
+	if amount := query.Get("amount"); amount != "" {
+		request.Amount, err = strconv.ParseUint(amount, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPaymentURI, err.Error())
+		}
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func parseCompact(s string) (*PaymentRequest, error) {
+	fields := strings.Split(s, ":")
+	if len(fields) < 2 || len(fields) > 4 {
+		return nil, ErrInvalidPaymentURI
+	}
+	request := &PaymentRequest{Address: Hash(fields[1])}
+	if len(fields) > 2 && fields[2] != "" {
+		amount, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidPaymentURI, err.Error())
+		}
+		request.Amount = amount
+	}
+	if len(fields) > 3 {
+		request.Tag = Trytes(fields[3])
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
@@ -0,0 +1,13 @@
+package paymenturi_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPaymenturi(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Paymenturi Suite")
+}
@@ -0,0 +1,67 @@
+package paymenturi_test
+
+import (
+	. "github.com/iotaledger/iota.go/paymenturi"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Paymenturi", func() {
+
+	addrWithChecksum := "GEXLJVJNKFPRGZSTOEVTODEEUJDQCFWOSLVBVVMTRVESTCCTPKILEADWUGMMZVUG9YTJSKNYQUNCSCBDYWDUCWPRQW"
+
+	request := &PaymentRequest{
+		Address: addrWithChecksum,
+		Amount:  1000,
+		Tag:     "EXAMPLE9TAG",
+		Message: "an example payment",
+	}
+
+	Context("URI()/Parse()", func() {
+		It("round trips a payment request", func() {
+			uri, err := request.URI()
+			Expect(err).ToNot(HaveOccurred())
+			parsed, err := Parse(uri)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(Equal(request))
+		})
+
+		It("renders a plain URI for an address-only request", func() {
+			uri, err := (&PaymentRequest{Address: addrWithChecksum}).URI()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(uri).To(Equal("iota:" + addrWithChecksum))
+		})
+
+		It("rejects an address with an invalid checksum", func() {
+			_, err := (&PaymentRequest{Address: addrWithChecksum[:89] + "A"}).URI()
+			Expect(errors.Cause(err)).To(Equal(ErrInvalidPaymentRequest))
+		})
+	})
+
+	Context("Compact()/Parse()", func() {
+		It("round trips the compact form without the message", func() {
+			compact, err := request.Compact()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(compact).To(Equal("IOTA:" + addrWithChecksum + ":1000:EXAMPLE9TAG"))
+			parsed, err := Parse(compact)
+			Expect(err).ToNot(HaveOccurred())
+			withoutMessage := *request
+			withoutMessage.Message = ""
+			Expect(parsed).To(Equal(&withoutMessage))
+		})
+
+		It("omits trailing empty fields", func() {
+			compact, err := (&PaymentRequest{Address: addrWithChecksum}).Compact()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(compact).To(Equal("IOTA:" + addrWithChecksum))
+		})
+	})
+
+	Context("Parse()", func() {
+		It("rejects unknown schemes", func() {
+			_, err := Parse("bitcoin:whatever")
+			Expect(errors.Cause(err)).To(Equal(ErrInvalidPaymentURI))
+		})
+	})
+})
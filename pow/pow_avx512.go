@@ -0,0 +1,354 @@
+// +build cgo
+// +build pow_avx512
+// +build amd64
+
+package pow
+
+import (
+	"math"
+	"sync"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// #cgo LDFLAGS:
+// #cgo CFLAGS: -mavx512f -Wall
+/*
+#include <stdio.h>
+#include <string.h>
+
+#ifdef _MSC_VER
+#include <intrin.h>
+#else
+#include <immintrin.h>
+#endif
+
+#define HASH_LENGTH 243              //trits
+#define NONCE_LENGTH 81              //trits
+#define STATE_LENGTH 3 * HASH_LENGTH //trits
+#define TX_LENGTH 2673               //trytes
+
+// 512 parallel lanes need 6 lane trits (3^6 = 729 >= 512), followed by the
+// 27 trits reserved for per-goroutine offsets, after which the nonce counter
+// starts.
+#define LANE_TRITS_512 6
+#define INIT_START_512 (HASH_LENGTH - NONCE_LENGTH + LANE_TRITS_512)
+#define INCR_START_512 (INIT_START_512 + 27)
+
+int has_avx512f()
+{
+  return __builtin_cpu_supports("avx512f");
+}
+
+const int indices512[] = {
+    0, 364, 728, 363, 727, 362, 726, 361, 725, 360, 724, 359, 723, 358, 722, 357, 721, 356, 720, 355, 719, 354, 718, 353, 717, 352, 716, 351, 715, 350, 714, 349, 713, 348, 712, 347, 711, 346, 710, 345, 709, 344, 708, 343, 707, 342, 706, 341, 705, 340, 704, 339, 703, 338, 702, 337, 701, 336, 700, 335, 699, 334, 698, 333, 697, 332, 696, 331, 695, 330, 694, 329, 693, 328, 692, 327, 691, 326, 690, 325, 689, 324, 688, 323, 687, 322, 686, 321, 685, 320, 684, 319, 683, 318, 682, 317, 681, 316, 680, 315, 679, 314, 678, 313, 677, 312, 676, 311, 675, 310, 674, 309, 673, 308, 672, 307, 671, 306, 670, 305, 669, 304, 668, 303, 667, 302, 666, 301, 665, 300, 664, 299, 663, 298, 662, 297, 661, 296, 660, 295, 659, 294, 658, 293, 657, 292, 656, 291, 655, 290, 654, 289, 653, 288, 652, 287, 651, 286, 650, 285, 649, 284, 648, 283, 647, 282, 646, 281, 645, 280, 644, 279, 643, 278, 642, 277, 641, 276, 640, 275, 639, 274, 638, 273, 637, 272, 636, 271, 635, 270, 634, 269, 633, 268, 632, 267, 631, 266, 630, 265, 629, 264, 628, 263, 627, 262, 626, 261, 625, 260, 624, 259, 623, 258, 622, 257, 621, 256, 620, 255, 619, 254, 618, 253, 617, 252, 616, 251, 615, 250, 614, 249, 613, 248, 612, 247, 611, 246, 610, 245, 609, 244, 608, 243, 607, 242, 606, 241, 605, 240, 604, 239, 603, 238, 602, 237, 601, 236, 600, 235, 599, 234, 598, 233, 597, 232, 596, 231, 595, 230, 594, 229, 593, 228, 592, 227, 591, 226, 590, 225, 589, 224, 588, 223, 587, 222, 586, 221, 585, 220, 584, 219, 583, 218, 582, 217, 581, 216, 580, 215, 579, 214, 578, 213, 577, 212, 576, 211, 575, 210, 574, 209, 573, 208, 572, 207, 571, 206, 570, 205, 569, 204, 568, 203, 567, 202, 566, 201, 565, 200, 564, 199, 563, 198, 562, 197, 561, 196, 560, 195, 559, 194, 558, 193, 557, 192, 556, 191, 555, 190, 554, 189, 553, 188, 552, 187, 551, 186, 550, 185, 549, 184, 548, 183, 547, 182, 546, 181, 545, 180, 544, 179, 543, 178, 542, 177, 541, 176, 540, 175, 539, 174, 538, 173, 537, 172, 536, 171, 535, 170, 534, 169, 533, 168, 532, 167, 531, 166, 530, 165, 529, 164, 528, 163, 527, 162, 526, 161, 525, 160, 524, 159, 523, 158, 522, 157, 521, 156, 520, 155, 519, 154, 518, 153, 517, 152, 516, 151, 515, 150, 514, 149, 513, 148, 512, 147, 511, 146, 510, 145, 509, 144, 508, 143, 507, 142, 506, 141, 505, 140, 504, 139, 503, 138, 502, 137, 501, 136, 500, 135, 499, 134, 498, 133, 497, 132, 496, 131, 495, 130, 494, 129, 493, 128, 492, 127, 491, 126, 490, 125, 489, 124, 488, 123, 487, 122, 486, 121, 485, 120, 484, 119, 483, 118, 482, 117, 481, 116, 480, 115, 479, 114, 478, 113, 477, 112, 476, 111, 475, 110, 474, 109, 473, 108, 472, 107, 471, 106, 470, 105, 469, 104, 468, 103, 467, 102, 466, 101, 465, 100, 464, 99, 463, 98, 462, 97, 461, 96, 460, 95, 459, 94, 458, 93, 457, 92, 456, 91, 455, 90, 454, 89, 453, 88, 452, 87, 451, 86, 450, 85, 449, 84, 448, 83, 447, 82, 446, 81, 445, 80, 444, 79, 443, 78, 442, 77, 441, 76, 440, 75, 439, 74, 438, 73, 437, 72, 436, 71, 435, 70, 434, 69, 433, 68, 432, 67, 431, 66, 430, 65, 429, 64, 428, 63, 427, 62, 426, 61, 425, 60, 424, 59, 423, 58, 422, 57, 421, 56, 420, 55, 419, 54, 418, 53, 417, 52, 416, 51, 415, 50, 414, 49, 413, 48, 412, 47, 411, 46, 410, 45, 409, 44, 408, 43, 407, 42, 406, 41, 405, 40, 404, 39, 403, 38, 402, 37, 401, 36, 400, 35, 399, 34, 398, 33, 397, 32, 396, 31, 395, 30, 394, 29, 393, 28, 392, 27, 391, 26, 390, 25, 389, 24, 388, 23, 387, 22, 386, 21, 385, 20, 384, 19, 383, 18, 382, 17, 381, 16, 380, 15, 379, 14, 378, 13, 377, 12, 376, 11, 375, 10, 374, 9, 373, 8, 372, 7, 371, 6, 370, 5, 369, 4, 368, 3, 367, 2, 366, 1, 365, 0};
+
+// fills the lane constants giving each of the 512 bit positions a distinct
+// combination of LANE_TRITS_512 trits.
+static void lane_constants512(unsigned long long llane[LANE_TRITS_512][8], unsigned long long hlane[LANE_TRITS_512][8])
+{
+  int k, p;
+  memset(llane, 0, LANE_TRITS_512 * 8 * sizeof(unsigned long long));
+  memset(hlane, 0, LANE_TRITS_512 * 8 * sizeof(unsigned long long));
+  for (k = 0; k < 512; k++)
+  {
+    int v = k;
+    for (p = 0; p < LANE_TRITS_512; p++)
+    {
+      int d = v % 3;
+      v /= 3;
+      // 01:-1 11:0 10:1
+      int l = (d != 1), h = (d != 2);
+      if (l)
+        llane[p][k / 64] |= 1uLL << (k % 64);
+      if (h)
+        hlane[p][k / 64] |= 1uLL << (k % 64);
+    }
+  }
+}
+
+static void transform512(__m512i *lmid, __m512i *hmid)
+{
+  int j, r, t1, t2;
+  __m512i alpha, beta, gamma, delta, ones;
+  __m512i *lfrom = lmid, *hfrom = hmid;
+  __m512i *lto = lmid + STATE_LENGTH, *hto = hmid + STATE_LENGTH;
+
+  ones = _mm512_set1_epi64((long long)0xFFFFFFFFFFFFFFFFuLL);
+
+  for (r = 0; r < 80; r++)
+  {
+    for (j = 0; j < STATE_LENGTH; j++)
+    {
+      t1 = indices512[j];
+      t2 = indices512[j + 1];
+
+      alpha = lfrom[t1];
+      beta = hfrom[t1];
+      gamma = hfrom[t2];
+      // delta = (alpha | (~gamma)) & (lfrom[t2] ^ beta)
+      delta = _mm512_and_si512(_mm512_or_si512(alpha, _mm512_xor_si512(gamma, ones)),
+                               _mm512_xor_si512(lfrom[t2], beta));
+
+      lto[j] = _mm512_xor_si512(delta, ones);
+      hto[j] = _mm512_or_si512(_mm512_xor_si512(alpha, gamma), delta);
+    }
+    __m512i *lswap = lfrom, *hswap = hfrom;
+    lfrom = lto;
+    hfrom = hto;
+    lto = lswap;
+    hto = hswap;
+  }
+
+  for (j = 0; j < HASH_LENGTH; j++)
+  {
+    t1 = indices512[j];
+    t2 = indices512[j + 1];
+
+    alpha = lfrom[t1];
+    beta = hfrom[t1];
+    gamma = hfrom[t2];
+    delta = _mm512_and_si512(_mm512_or_si512(alpha, _mm512_xor_si512(gamma, ones)),
+                             _mm512_xor_si512(lfrom[t2], beta));
+
+    lto[j] = _mm512_xor_si512(delta, ones);
+    hto[j] = _mm512_or_si512(_mm512_xor_si512(alpha, gamma), delta);
+  }
+}
+
+static int incr512(__m512i *lmid, __m512i *hmid)
+{
+  int i;
+  __m512i carry = _mm512_set1_epi64((long long)0xFFFFFFFFFFFFFFFFuLL);
+  __m512i ones = carry;
+  for (i = INCR_START_512; i < HASH_LENGTH && _mm512_test_epi64_mask(carry, carry); i++)
+  {
+    __m512i low = lmid[i], high = hmid[i];
+    lmid[i] = _mm512_xor_si512(high, low);
+    hmid[i] = low;
+    carry = _mm512_and_si512(high, _mm512_xor_si512(low, ones));
+  }
+  return i == HASH_LENGTH;
+}
+
+static void incrN512(int n, __m512i *lmid, __m512i *hmid)
+{
+  int i, j;
+  __m512i ones = _mm512_set1_epi64((long long)0xFFFFFFFFFFFFFFFFuLL);
+  for (j = 0; j < n; j++)
+  {
+    __m512i carry = ones;
+    for (i = INIT_START_512; i < INCR_START_512 && _mm512_test_epi64_mask(carry, carry); i++)
+    {
+      __m512i low = lmid[i], high = hmid[i];
+      lmid[i] = _mm512_xor_si512(high, low);
+      hmid[i] = low;
+      carry = _mm512_and_si512(high, _mm512_xor_si512(low, ones));
+    }
+  }
+}
+
+static void seri512(__m512i *l, __m512i *h, int n, signed char *r)
+{
+  int i, word = n / 64, bit = n % 64;
+  unsigned long long lw[8], hw[8];
+  for (i = HASH_LENGTH - NONCE_LENGTH; i < HASH_LENGTH; i++)
+  {
+    _mm512_storeu_si512((__m512i *)lw, l[i]);
+    _mm512_storeu_si512((__m512i *)hw, h[i]);
+    int ll = (int)((lw[word] >> bit) & 1);
+    int hh = (int)((hw[word] >> bit) & 1);
+    if (hh == 0 && ll == 1)
+    {
+      r[i - HASH_LENGTH + NONCE_LENGTH] = -1;
+    }
+    if (hh == 1 && ll == 1)
+    {
+      r[i - HASH_LENGTH + NONCE_LENGTH] = 0;
+    }
+    if (hh == 1 && ll == 0)
+    {
+      r[i - HASH_LENGTH + NONCE_LENGTH] = 1;
+    }
+  }
+}
+
+static int check512(__m512i *l, __m512i *h, int m)
+{
+  int i, j;
+  unsigned long long probe[8];
+  __m512i ones = _mm512_set1_epi64((long long)0xFFFFFFFFFFFFFFFFuLL);
+  __m512i nonce_probe = ones;
+  for (i = HASH_LENGTH - m; i < HASH_LENGTH; i++)
+  {
+    nonce_probe = _mm512_and_si512(nonce_probe, _mm512_xor_si512(_mm512_xor_si512(l[i], h[i]), ones));
+    if (!_mm512_test_epi64_mask(nonce_probe, nonce_probe))
+      return -1;
+  }
+  _mm512_storeu_si512((__m512i *)probe, nonce_probe);
+  for (i = 0; i < 8; i++)
+  {
+    for (j = 0; j < 64; j++)
+    {
+      if ((probe[i] >> j) & 1)
+      {
+        return i * 64 + j;
+      }
+    }
+  }
+  return -1;
+}
+
+static long long loop512(__m512i *lmid, __m512i *hmid, int m, signed char *nonce, int *stop)
+{
+  int n = 0;
+  long long i = 0;
+  __m512i lcpy[STATE_LENGTH * 2], hcpy[STATE_LENGTH * 2];
+
+  for (i = 0; !incr512(lmid, hmid) && !*stop; i++)
+  {
+    memcpy(lcpy, lmid, STATE_LENGTH * sizeof(__m512i));
+    memcpy(hcpy, hmid, STATE_LENGTH * sizeof(__m512i));
+    transform512(lcpy, hcpy);
+    if ((n = check512(lcpy + STATE_LENGTH, hcpy + STATE_LENGTH, m)) >= 0)
+    {
+      seri512(lmid, hmid, n, nonce);
+      return i * 512;
+    }
+  }
+  return -i * 512 + 1;
+}
+
+// 01:-1 11:0 10:1
+static void para512(signed char in[], __m512i l[], __m512i h[])
+{
+  int i = 0;
+  __m512i ones = _mm512_set1_epi64((long long)0xFFFFFFFFFFFFFFFFuLL);
+  __m512i zeros = _mm512_setzero_si512();
+  for (i = 0; i < STATE_LENGTH; i++)
+  {
+    switch (in[i])
+    {
+    case 0:
+      l[i] = ones;
+      h[i] = ones;
+      break;
+    case 1:
+      l[i] = zeros;
+      h[i] = ones;
+      break;
+    case -1:
+      l[i] = ones;
+      h[i] = zeros;
+      break;
+    }
+  }
+}
+
+long long pwork512(signed char mid[], int mwm, signed char nonce[], int n, int *stop)
+{
+  __m512i lmid[STATE_LENGTH], hmid[STATE_LENGTH];
+  unsigned long long llane[LANE_TRITS_512][8], hlane[LANE_TRITS_512][8];
+  int offset = HASH_LENGTH - NONCE_LENGTH;
+  int p;
+
+  para512(mid, lmid, hmid);
+  lane_constants512(llane, hlane);
+  for (p = 0; p < LANE_TRITS_512; p++)
+  {
+    lmid[offset + p] = _mm512_loadu_si512((__m512i *)llane[p]);
+    hmid[offset + p] = _mm512_loadu_si512((__m512i *)hlane[p]);
+  }
+
+  incrN512(n, lmid, hmid);
+  return loop512(lmid, hmid, mwm, nonce, stop);
+}
+*/
+import "C"
+import (
+	"unsafe"
+)
+
+func init() {
+	if C.has_avx512f() == 0 {
+		return
+	}
+	proofOfWorkFuncs["AVX512"] = AVX512ProofOfWork
+	proofOfWorkFuncs["SyncAVX512"] = SyncAVX512ProofOfWork
+}
+
+// AVX512ProofOfWork does Proof-of-Work on the given trytes using AVX-512 instructions.
+func AVX512ProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+	return avx512ProofOfWork(trytes, mwm, nil, parallelism...)
+}
+
+var syncAVX512ProofOfWork = sync.Mutex{}
+
+// SyncAVX512ProofOfWork is like AVX512ProofOfWork() but only runs one ongoing Proof-of-Work task at a time.
+func SyncAVX512ProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+	syncAVX512ProofOfWork.Lock()
+	defer syncAVX512ProofOfWork.Unlock()
+	nonce, err := avx512ProofOfWork(trytes, mwm, nil, parallelism...)
+	if err != nil {
+		return "", err
+	}
+	return nonce, nil
+}
+
+func avx512ProofOfWork(trytes Trytes, mwm int, optRate chan int64, parallelism ...int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	numGoroutines := proofOfWorkParallelism(parallelism...)
+	var result Trytes
+	var rate chan int64
+	if optRate != nil {
+		rate = make(chan int64, numGoroutines)
+	}
+	exit := make(chan struct{})
+	nonceChan := make(chan Trytes)
+
+	var cancelled C.int
+	for n := 0; n < numGoroutines; n++ {
+		go func(n int) {
+			nonce := make(Trits, NonceTrinarySize)
+
+			r := C.pwork512((*C.schar)(
+				unsafe.Pointer(&c.State[0])), C.int(mwm), (*C.schar)(unsafe.Pointer(&nonce[0])),
+				C.int(n), &cancelled)
+
+			if rate != nil {
+				rate <- int64(math.Abs(float64(r)))
+			}
+
+			if r >= 0 {
+				select {
+				case <-exit:
+				case nonceChan <- MustTritsToTrytes(nonce):
+					cancelled = 1
+				}
+			}
+		}(n)
+	}
+
+	if rate != nil {
+		var rateSum int64
+		for i := 0; i < numGoroutines; i++ {
+			rateSum += <-rate
+		}
+		optRate <- rateSum
+	}
+
+	result = <-nonceChan
+	close(exit)
+	cancelled = 1
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package pow
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// DefaultBenchmarkDuration is the default duration for which each Proof-of-Work
+// implementation is measured during a benchmark.
+const DefaultBenchmarkDuration = time.Duration(2) * time.Second
+
+// the MWM used during benchmarks; low enough for every implementation to finish
+// several searches within the benchmark duration.
+const benchmarkMWM = 9
+
+// PoWBenchmarkResult describes the measured performance of one Proof-of-Work
+// implementation.
+type PoWBenchmarkResult struct {
+	// The name of the implementation.
+	Name string
+	// The amount of nonce searches completed within the benchmark duration.
+	Solves int
+	// The estimated hash rate in hashes per second.
+	HashRate float64
+	// The time spent benchmarking the implementation.
+	Elapsed time.Duration
+}
+
+// BenchmarkProofOfWorkImpls measures the performance of every registered
+// Proof-of-Work implementation on the current machine by letting each solve the
+// same deterministic workload for at least the given duration
+// (DefaultBenchmarkDuration if zero) and returns the results ranked fastest first.
+// Implementations failing to execute (for example a GPU backend without a device)
+// are left out of the result.
+func BenchmarkProofOfWorkImpls(duration time.Duration) []PoWBenchmarkResult {
+	if duration <= 0 {
+		duration = DefaultBenchmarkDuration
+	}
+	trytes := Trytes(strings.Repeat("9", TransactionTrinarySize/3))
+	results := make([]PoWBenchmarkResult, 0, len(proofOfWorkFuncs))
+	for name, powFunc := range proofOfWorkFuncs {
+		solves := 0
+		start := time.Now()
+		for time.Since(start) < duration {
+			if _, err := powFunc(trytes, benchmarkMWM); err != nil {
+				break
+			}
+			solves++
+		}
+		if solves == 0 {
+			continue
+		}
+		elapsed := time.Since(start)
+		results = append(results, PoWBenchmarkResult{
+			Name:     name,
+			Solves:   solves,
+			HashRate: float64(solves) * math.Pow(3, benchmarkMWM) / elapsed.Seconds(),
+			Elapsed:  elapsed,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].HashRate != results[j].HashRate {
+			return results[i].HashRate > results[j].HashRate
+		}
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+var (
+	measuredFastestOnce sync.Once
+	measuredFastestName string
+	measuredFastestFunc ProofOfWorkFunc
+)
+
+// GetMeasuredFastestProofOfWorkImpl returns the Proof-of-Work implementation which
+// performed best in an actual benchmark on the current machine, instead of picking
+// by the hard-coded preference order of GetFastestProofOfWorkImpl(). The benchmark
+// runs once on the first call and its outcome is cached for subsequent calls.
+func GetMeasuredFastestProofOfWorkImpl() (string, ProofOfWorkFunc) {
+	measuredFastestOnce.Do(func() {
+		results := BenchmarkProofOfWorkImpls(DefaultBenchmarkDuration)
+		if len(results) == 0 {
+			measuredFastestName, measuredFastestFunc = GetFastestProofOfWorkImpl()
+			return
+		}
+		measuredFastestName = results[0].Name
+		measuredFastestFunc = proofOfWorkFuncs[results[0].Name]
+	})
+	return measuredFastestName, measuredFastestFunc
+}
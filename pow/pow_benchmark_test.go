@@ -0,0 +1,39 @@
+package pow_test
+
+import (
+	"time"
+
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BenchmarkProofOfWorkImpls", func() {
+	Context("BenchmarkProofOfWorkImpls()", func() {
+		It("measures every registered implementation and ranks fastest first", func() {
+			results := pow.BenchmarkProofOfWorkImpls(time.Duration(100) * time.Millisecond)
+			Expect(len(results)).To(BeNumerically(">", 0))
+			names := make([]string, len(results))
+			for i, result := range results {
+				Expect(result.Solves).To(BeNumerically(">", 0))
+				Expect(result.HashRate).To(BeNumerically(">", 0.0))
+				names[i] = result.Name
+			}
+			Expect(names).To(ContainElement("Go"))
+			for i := 1; i < len(results); i++ {
+				Expect(results[i-1].HashRate).To(BeNumerically(">=", results[i].HashRate))
+			}
+		})
+	})
+
+	Context("GetMeasuredFastestProofOfWorkImpl()", func() {
+		It("returns a registered implementation and caches the outcome", func() {
+			name, powFunc := pow.GetMeasuredFastestProofOfWorkImpl()
+			Expect(powFunc).ToNot(BeNil())
+			Expect(pow.GetProofOfWorkImplementations()).To(ContainElement(name))
+			nameAgain, _ := pow.GetMeasuredFastestProofOfWorkImpl()
+			Expect(nameAgain).To(Equal(name))
+		})
+	})
+
+})
@@ -1,7 +1,7 @@
 // +build cgo
 // +build pow_c128
 // +build linux darwin windows
-// +build amd64
+// +build amd64 arm64 ppc64le riscv64
 
 package pow
 
@@ -290,8 +290,9 @@ func init() {
 	proofOfWorkFuncs["SyncC128"] = SyncC128ProofOfWork
 }
 
-// PoWC128 does Proof-of-Work on the given trytes using native C code and __int128 C type.
-// This implementation follows common C standards and does not rely on SSE which is AMD64 specific.
+// C128ProofOfWork does Proof-of-Work on the given trytes using native C code and the __int128 C type.
+// This implementation follows common C standards and does not rely on SIMD intrinsics, making it
+// available on any 64-bit platform where the C compiler supports 128-bit arithmetic.
 func C128ProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
 	return c128ProofOfWork(trytes, mwm, nil, parallelism...)
 }
@@ -1,7 +1,7 @@
 // +build cgo
 // +build pow_c128
 // +build linux darwin windows
-// +build amd64
+// +build amd64 arm64 ppc64le riscv64
 
 package pow_test
 
@@ -1,6 +1,6 @@
 // +build cgo
-// +build pow_arm_c128
-// +build linux
+// +build pow_arm_c128 pow_neon
+// +build linux darwin
 // +build arm64
 
 package pow
@@ -277,8 +277,10 @@ func init() {
 	proofOfWorkFuncs["SyncCARM64"] = SyncCARM64ProofOfWork
 }
 
-// CARM64ProofOfWork does proof of work on the given trytes using native C code and __int128 C type (ARM adjusted).
-// This implementation follows common C standards and does not rely on SSE which is AMD64 specific.
+// CARM64ProofOfWork does proof of work on the given trytes using NEON vector instructions
+// (uint64x2_t intrinsics) available on every arm64 CPU, such as the Raspberry Pi 4,
+// Apple Silicon and AWS Graviton. Build with the pow_neon (or legacy pow_arm_c128) tag
+// to enable it.
 func CARM64ProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
 	return cARM64ProofOfWork(trytes, mwm, nil, parallelism)
 }
@@ -1,6 +1,6 @@
 // +build cgo
-// +build pow_arm_c128
-// +build linux
+// +build pow_arm_c128 pow_neon
+// +build linux darwin
 // +build arm64
 
 package pow_test
@@ -0,0 +1,419 @@
+// +build cgo
+// +build pow_cuda
+// +build linux windows
+
+package pow
+
+// #cgo CFLAGS: -Wall -I/usr/local/cuda/include
+// #cgo LDFLAGS: -L/usr/local/cuda/lib64 -lcuda -lnvrtc
+/*
+#include <stdio.h>
+#include <string.h>
+#include <stdlib.h>
+#include <cuda.h>
+#include <nvrtc.h>
+
+#define HBITS 0xFFFFFFFFFFFFFFFFuLL
+#define LBITS 0x0000000000000000uLL
+#define HASH_LENGTH 243              //trits
+#define NONCE_LENGTH 81              //trits
+#define STATE_LENGTH 3 * HASH_LENGTH //trits
+
+#define LOW0 0xDB6DB6DB6DB6DB6DuLL
+#define HIGH0 0xB6DB6DB6DB6DB6DBuLL
+#define LOW1 0xF1F8FC7E3F1F8FC7uLL
+#define HIGH1 0x8FC7E3F1F8FC7E3FuLL
+#define LOW2 0x7FFFE00FFFFC01FFuLL
+#define HIGH2 0xFFC01FFFF803FFFFuLL
+#define LOW3 0xFFC0000007FFFFFFuLL
+#define HIGH3 0x003FFFFFFFFFFFFFuLL
+
+#define CUDA_LOOP_COUNT 32
+
+// the nonce search kernels, compiled at runtime via NVRTC. k_init fans the
+// bit-sliced mid state out into one lane per GPU thread (each lane offset in
+// the mid nonce trytes, mirroring incrN() of the CPU backends), k_search then
+// advances every lane by CUDA_LOOP_COUNT increments per launch, keeping the
+// lane states in device memory between launches.
+static const char *cuda_kernel_src =
+"#define HASH_LENGTH 243\n"
+"#define NONCE_LENGTH 81\n"
+"#define STATE_LENGTH (3 * HASH_LENGTH)\n"
+"#define INCR_START (HASH_LENGTH - NONCE_LENGTH + 4 + 27)\n"
+"#define HBITS 0xFFFFFFFFFFFFFFFFULL\n"
+"\n"
+"extern \"C\" __global__ void k_init(unsigned long long *lstates, unsigned long long *hstates,\n"
+"                                    const unsigned long long *lmid, const unsigned long long *hmid)\n"
+"{\n"
+"  int gid = blockIdx.x * blockDim.x + threadIdx.x;\n"
+"  unsigned long long *l = lstates + (size_t)gid * STATE_LENGTH;\n"
+"  unsigned long long *h = hstates + (size_t)gid * STATE_LENGTH;\n"
+"  int i, j;\n"
+"  for (i = 0; i < STATE_LENGTH; i++)\n"
+"  {\n"
+"    l[i] = lmid[i];\n"
+"    h[i] = hmid[i];\n"
+"  }\n"
+"  for (j = 0; j < gid; j++)\n"
+"  {\n"
+"    unsigned long long carry = 1;\n"
+"    for (i = INCR_START - 27; i < INCR_START && carry; i++)\n"
+"    {\n"
+"      unsigned long long low = l[i], high = h[i];\n"
+"      l[i] = high ^ low;\n"
+"      h[i] = low;\n"
+"      carry = high & (~low);\n"
+"    }\n"
+"  }\n"
+"}\n"
+"\n"
+"extern \"C\" __global__ void k_search(unsigned long long *lstates, unsigned long long *hstates,\n"
+"                                      const int *indices, int mwm, int loopcount,\n"
+"                                      volatile int *found, signed char *nonce_out,\n"
+"                                      unsigned long long *counts)\n"
+"{\n"
+"  int gid = blockIdx.x * blockDim.x + threadIdx.x;\n"
+"  unsigned long long *lmid = lstates + (size_t)gid * STATE_LENGTH;\n"
+"  unsigned long long *hmid = hstates + (size_t)gid * STATE_LENGTH;\n"
+"  unsigned long long lcpy[STATE_LENGTH * 2], hcpy[STATE_LENGTH * 2];\n"
+"  int iter, i, j, r;\n"
+"  for (iter = 0; iter < loopcount; iter++)\n"
+"  {\n"
+"    if (*found)\n"
+"      return;\n"
+"    unsigned long long carry = 1;\n"
+"    for (i = INCR_START; i < HASH_LENGTH && carry; i++)\n"
+"    {\n"
+"      unsigned long long low = lmid[i], high = hmid[i];\n"
+"      lmid[i] = high ^ low;\n"
+"      hmid[i] = low;\n"
+"      carry = high & (~low);\n"
+"    }\n"
+"    if (i == HASH_LENGTH)\n"
+"      return; // lane exhausted\n"
+"    for (j = 0; j < STATE_LENGTH; j++)\n"
+"    {\n"
+"      lcpy[j] = lmid[j];\n"
+"      hcpy[j] = hmid[j];\n"
+"    }\n"
+"    unsigned long long *lfrom = lcpy, *hfrom = hcpy;\n"
+"    unsigned long long *lto = lcpy + STATE_LENGTH, *hto = hcpy + STATE_LENGTH;\n"
+"    for (r = 0; r < 80; r++)\n"
+"    {\n"
+"      for (j = 0; j < STATE_LENGTH; j++)\n"
+"      {\n"
+"        int t1 = indices[j];\n"
+"        int t2 = indices[j + 1];\n"
+"        unsigned long long alpha = lfrom[t1];\n"
+"        unsigned long long beta = hfrom[t1];\n"
+"        unsigned long long gamma = hfrom[t2];\n"
+"        unsigned long long delta = (alpha | (~gamma)) & (lfrom[t2] ^ beta);\n"
+"        lto[j] = ~delta;\n"
+"        hto[j] = (alpha ^ gamma) | delta;\n"
+"      }\n"
+"      unsigned long long *lswap = lfrom, *hswap = hfrom;\n"
+"      lfrom = lto;\n"
+"      hfrom = hto;\n"
+"      lto = lswap;\n"
+"      hto = hswap;\n"
+"    }\n"
+"    for (j = 0; j < HASH_LENGTH; j++)\n"
+"    {\n"
+"      int t1 = indices[j];\n"
+"      int t2 = indices[j + 1];\n"
+"      unsigned long long alpha = lfrom[t1];\n"
+"      unsigned long long beta = hfrom[t1];\n"
+"      unsigned long long gamma = hfrom[t2];\n"
+"      unsigned long long delta = (alpha | (~gamma)) & (lfrom[t2] ^ beta);\n"
+"      lto[j] = ~delta;\n"
+"      hto[j] = (alpha ^ gamma) | delta;\n"
+"    }\n"
+"    counts[gid] += 64;\n"
+"    unsigned long long nonce_probe = HBITS;\n"
+"    for (j = HASH_LENGTH - mwm; j < HASH_LENGTH; j++)\n"
+"    {\n"
+"      nonce_probe &= ~(lto[j] ^ hto[j]);\n"
+"      if (nonce_probe == 0)\n"
+"        break;\n"
+"    }\n"
+"    if (nonce_probe == 0)\n"
+"      continue;\n"
+"    int n = -1;\n"
+"    for (j = 0; j < 64; j++)\n"
+"    {\n"
+"      if ((nonce_probe >> j) & 1)\n"
+"      {\n"
+"        n = j;\n"
+"        break;\n"
+"      }\n"
+"    }\n"
+"    if (atomicCAS((int *)found, 0, 1) == 0)\n"
+"    {\n"
+"      for (j = HASH_LENGTH - NONCE_LENGTH; j < HASH_LENGTH; j++)\n"
+"      {\n"
+"        int ll = (int)((lmid[j] >> n) & 1);\n"
+"        int hh = (int)((hmid[j] >> n) & 1);\n"
+"        signed char v = 0;\n"
+"        if (hh == 0 && ll == 1)\n"
+"          v = -1;\n"
+"        else if (hh == 1 && ll == 0)\n"
+"          v = 1;\n"
+"        nonce_out[j - (HASH_LENGTH - NONCE_LENGTH)] = v;\n"
+"      }\n"
+"    }\n"
+"    return;\n"
+"  }\n"
+"}\n";
+
+// 01:-1 11:0 10:1
+static void cuda_para(signed char in[], unsigned long long l[], unsigned long long h[])
+{
+  int i = 0;
+  for (i = 0; i < STATE_LENGTH; i++)
+  {
+    switch (in[i])
+    {
+    case 0:
+      l[i] = HBITS;
+      h[i] = HBITS;
+      break;
+    case 1:
+      l[i] = LBITS;
+      h[i] = HBITS;
+      break;
+    case -1:
+      l[i] = HBITS;
+      h[i] = LBITS;
+      break;
+    }
+  }
+}
+
+#define CUDA_CHECK(call)     \
+  if ((call) != CUDA_SUCCESS) \
+  {                          \
+    goto fail;               \
+  }
+
+long long cuda_pwork(signed char mid[], int mwm, signed char nonce[], int *stop)
+{
+  unsigned long long lmid[STATE_LENGTH] = {0}, hmid[STATE_LENGTH] = {0};
+  int indices[STATE_LENGTH + 1];
+  int i, smCount = 0, threadsPerBlock = 128, blocks, lanes;
+  long long count = -2;
+  int found = 0;
+  size_t stateBytes;
+  char *ptx = NULL;
+  size_t ptxSize;
+  unsigned long long *counts = NULL;
+  nvrtcProgram prog = NULL;
+  CUdevice dev;
+  CUcontext ctx = NULL;
+  CUmodule mod = NULL;
+  CUfunction kInit, kSearch;
+  CUdeviceptr dLStates = 0, dHStates = 0, dLMid = 0, dHMid = 0, dIndices = 0, dFound = 0, dNonce = 0, dCounts = 0;
+  int deviceCount = 0;
+  int loopcount = CUDA_LOOP_COUNT;
+
+  cuda_para(mid, lmid, hmid);
+  int offset = HASH_LENGTH - NONCE_LENGTH;
+  lmid[offset] = LOW0;
+  hmid[offset] = HIGH0;
+  lmid[offset + 1] = LOW1;
+  hmid[offset + 1] = HIGH1;
+  lmid[offset + 2] = LOW2;
+  hmid[offset + 2] = HIGH2;
+  lmid[offset + 3] = LOW3;
+  hmid[offset + 3] = HIGH3;
+
+  indices[0] = 0;
+  for (i = 0; i < STATE_LENGTH; i++)
+  {
+    indices[i + 1] = indices[i] + (indices[i] < 365 ? 364 : -365);
+  }
+
+  if (cuInit(0) != CUDA_SUCCESS || cuDeviceGetCount(&deviceCount) != CUDA_SUCCESS || deviceCount == 0)
+  {
+    return -1;
+  }
+  CUDA_CHECK(cuDeviceGet(&dev, 0));
+  CUDA_CHECK(cuDeviceGetAttribute(&smCount, CU_DEVICE_ATTRIBUTE_MULTIPROCESSOR_COUNT, dev));
+  CUDA_CHECK(cuCtxCreate(&ctx, 0, dev));
+
+  blocks = smCount * 8;
+  lanes = blocks * threadsPerBlock;
+  stateBytes = (size_t)lanes * STATE_LENGTH * sizeof(unsigned long long);
+
+  if (nvrtcCreateProgram(&prog, cuda_kernel_src, "pow_cuda.cu", 0, NULL, NULL) != NVRTC_SUCCESS)
+  {
+    goto fail;
+  }
+  if (nvrtcCompileProgram(prog, 0, NULL) != NVRTC_SUCCESS)
+  {
+    goto fail;
+  }
+  if (nvrtcGetPTXSize(prog, &ptxSize) != NVRTC_SUCCESS)
+  {
+    goto fail;
+  }
+  ptx = (char *)malloc(ptxSize);
+  if (ptx == NULL || nvrtcGetPTX(prog, ptx) != NVRTC_SUCCESS)
+  {
+    goto fail;
+  }
+
+  CUDA_CHECK(cuModuleLoadData(&mod, ptx));
+  CUDA_CHECK(cuModuleGetFunction(&kInit, mod, "k_init"));
+  CUDA_CHECK(cuModuleGetFunction(&kSearch, mod, "k_search"));
+
+  CUDA_CHECK(cuMemAlloc(&dLStates, stateBytes));
+  CUDA_CHECK(cuMemAlloc(&dHStates, stateBytes));
+  CUDA_CHECK(cuMemAlloc(&dLMid, STATE_LENGTH * sizeof(unsigned long long)));
+  CUDA_CHECK(cuMemAlloc(&dHMid, STATE_LENGTH * sizeof(unsigned long long)));
+  CUDA_CHECK(cuMemAlloc(&dIndices, sizeof(indices)));
+  CUDA_CHECK(cuMemAlloc(&dFound, sizeof(int)));
+  CUDA_CHECK(cuMemAlloc(&dNonce, NONCE_LENGTH));
+  CUDA_CHECK(cuMemAlloc(&dCounts, (size_t)lanes * sizeof(unsigned long long)));
+
+  CUDA_CHECK(cuMemcpyHtoD(dLMid, lmid, STATE_LENGTH * sizeof(unsigned long long)));
+  CUDA_CHECK(cuMemcpyHtoD(dHMid, hmid, STATE_LENGTH * sizeof(unsigned long long)));
+  CUDA_CHECK(cuMemcpyHtoD(dIndices, indices, sizeof(indices)));
+  CUDA_CHECK(cuMemsetD8(dFound, 0, sizeof(int)));
+  CUDA_CHECK(cuMemsetD8(dNonce, 0, NONCE_LENGTH));
+  CUDA_CHECK(cuMemsetD8(dCounts, 0, (size_t)lanes * sizeof(unsigned long long)));
+
+  {
+    void *initArgs[] = {&dLStates, &dHStates, &dLMid, &dHMid};
+    CUDA_CHECK(cuLaunchKernel(kInit, blocks, 1, 1, threadsPerBlock, 1, 1, 0, NULL, initArgs, NULL));
+    CUDA_CHECK(cuCtxSynchronize());
+  }
+
+  while (!found && !*stop)
+  {
+    void *searchArgs[] = {&dLStates, &dHStates, &dIndices, &mwm, &loopcount, &dFound, &dNonce, &dCounts};
+    CUDA_CHECK(cuLaunchKernel(kSearch, blocks, 1, 1, threadsPerBlock, 1, 1, 0, NULL, searchArgs, NULL));
+    CUDA_CHECK(cuCtxSynchronize());
+    CUDA_CHECK(cuMemcpyDtoH(&found, dFound, sizeof(int)));
+  }
+
+  counts = (unsigned long long *)malloc((size_t)lanes * sizeof(unsigned long long));
+  if (counts == NULL)
+  {
+    goto fail;
+  }
+  CUDA_CHECK(cuMemcpyDtoH(counts, dCounts, (size_t)lanes * sizeof(unsigned long long)));
+  count = 0;
+  for (i = 0; i < lanes; i++)
+  {
+    count += (long long)counts[i];
+  }
+
+  if (found)
+  {
+    if (cuMemcpyDtoH(nonce, dNonce, NONCE_LENGTH) != CUDA_SUCCESS)
+    {
+      count = -2;
+    }
+  }
+  else
+  {
+    // cancelled before a nonce was found
+    count = -count - 1;
+  }
+
+fail:
+  free(counts);
+  free(ptx);
+  if (prog != NULL)
+  {
+    nvrtcDestroyProgram(&prog);
+  }
+  if (dLStates)
+    cuMemFree(dLStates);
+  if (dHStates)
+    cuMemFree(dHStates);
+  if (dLMid)
+    cuMemFree(dLMid);
+  if (dHMid)
+    cuMemFree(dHMid);
+  if (dIndices)
+    cuMemFree(dIndices);
+  if (dFound)
+    cuMemFree(dFound);
+  if (dNonce)
+    cuMemFree(dNonce);
+  if (dCounts)
+    cuMemFree(dCounts);
+  if (mod != NULL)
+    cuModuleUnload(mod);
+  if (ctx != NULL)
+    cuCtxDestroy(ctx);
+  return count;
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrCUDANoDeviceFound gets returned when no CUDA capable device could be found.
+	ErrCUDANoDeviceFound = errors.New("no CUDA device found")
+	// ErrCUDARuntimeError gets returned when a CUDA driver or kernel compilation call fails.
+	ErrCUDARuntimeError = errors.New("CUDA runtime error")
+)
+
+func init() {
+	proofOfWorkFuncs["CUDA"] = CUDAProofOfWork
+	proofOfWorkFuncs["SyncCUDA"] = SyncCUDAProofOfWork
+}
+
+// CUDAProofOfWork does Proof-of-Work on the given trytes on a CUDA capable GPU.
+// The parallelism option is ignored, as the amount of parallel search lanes is
+// derived from the GPU's multiprocessor count.
+func CUDAProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+	return cudaProofOfWork(trytes, mwm)
+}
+
+var syncCUDAProofOfWork = sync.Mutex{}
+
+// SyncCUDAProofOfWork is like CUDAProofOfWork() but only runs one ongoing Proof-of-Work task at a time.
+func SyncCUDAProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+	syncCUDAProofOfWork.Lock()
+	defer syncCUDAProofOfWork.Unlock()
+	nonce, err := cudaProofOfWork(trytes, mwm)
+	if err != nil {
+		return "", err
+	}
+	return nonce, nil
+}
+
+func cudaProofOfWork(trytes Trytes, mwm int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	nonce := make(Trits, NonceTrinarySize)
+	var cancelled C.int
+	r := C.cuda_pwork((*C.schar)(unsafe.Pointer(&c.State[0])), C.int(mwm),
+		(*C.schar)(unsafe.Pointer(&nonce[0])), &cancelled)
+	switch {
+	case r == -1:
+		return "", ErrCUDANoDeviceFound
+	case r < 0:
+		return "", ErrCUDARuntimeError
+	}
+	return MustTritsToTrytes(nonce), nil
+}
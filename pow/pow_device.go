@@ -0,0 +1,142 @@
+package pow
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+// ErrPoWDeviceFailure gets returned when a hardware Proof-of-Work device
+// misbehaves, for example by returning a malformed nonce frame.
+var ErrPoWDeviceFailure = errors.New("Proof-of-Work device failure")
+
+// PoWDevice abstracts a hardware Proof-of-Work accelerator, such as a
+// PiDiver-style FPGA attached via USB/serial. The driver sends the Curl
+// mid-state of the transaction and the MWM to the device and reads back the
+// found nonce.
+type PoWDevice interface {
+	// Name returns a human readable name of the device.
+	Name() string
+	// PoW does the nonce search on the device, given the Curl mid-state of the
+	// transaction (with the last block loaded) and the MWM, and returns the
+	// found nonce trits.
+	PoW(mid Trits, mwm int) (Trits, error)
+	// Close releases the underlying device handle.
+	Close() error
+}
+
+// DeviceProofOfWork wraps the given device into a normal ProofOfWorkFunc.
+// The parallelism option is ignored, as the parallelism is defined by the hardware.
+func DeviceProofOfWork(device PoWDevice) ProofOfWorkFunc {
+	return func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+		if trytes == "" {
+			return "", ErrInvalidTrytesForProofOfWork
+		}
+
+		c := curl.NewCurl()
+		c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+		tr := MustTrytesToTrits(trytes)
+		copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+		nonce, err := device.PoW(c.State, mwm)
+		if err != nil {
+			return "", err
+		}
+		if len(nonce) != NonceTrinarySize {
+			return "", errors.Wrapf(ErrPoWDeviceFailure, "expected %d nonce trits, got %d", NonceTrinarySize, len(nonce))
+		}
+		return MustTritsToTrytes(nonce), nil
+	}
+}
+
+// RegisterDeviceProofOfWork registers the given device under its name as a
+// normal Proof-of-Work implementation, plus a "Sync" prefixed variant only
+// running one Proof-of-Work task at a time. It must be called during program
+// initialization, before the Proof-of-Work implementations are queried.
+func RegisterDeviceProofOfWork(device PoWDevice) {
+	powFunc := DeviceProofOfWork(device)
+	proofOfWorkFuncs[device.Name()] = powFunc
+
+	var mu sync.Mutex
+	proofOfWorkFuncs["Sync"+device.Name()] = func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return powFunc(trytes, mwm, parallelism...)
+	}
+}
+
+// the framing of the reference serial protocol: a request frame is the header
+// "POW", one byte MWM and the mid-state trits, each encoded as trit+1; the
+// response frame is the nonce trits in the same encoding.
+const serialDeviceHeader = "POW"
+
+// SerialDevice is a reference driver for USB/serial attached Proof-of-Work
+// accelerators speaking a simple framed protocol. It is safe for concurrent
+// use, requests are serialized onto the device.
+type SerialDevice struct {
+	name string
+	mu   sync.Mutex
+	rw   io.ReadWriteCloser
+}
+
+// NewSerialDevice creates a new SerialDevice with the given name on the given
+// connection, which is usually an opened serial port device file.
+func NewSerialDevice(name string, rw io.ReadWriteCloser) *SerialDevice {
+	return &SerialDevice{name: name, rw: rw}
+}
+
+// OpenSerialDevice opens the serial port device file at the given path, e.g.
+// /dev/ttyUSB0, and creates a new SerialDevice with the given name on it.
+// The port must already be configured (baud rate etc.), for example via stty.
+func OpenSerialDevice(name string, path string) (*SerialDevice, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	return NewSerialDevice(name, f), nil
+}
+
+// Name implements PoWDevice.
+func (d *SerialDevice) Name() string {
+	return d.name
+}
+
+// PoW implements PoWDevice.
+func (d *SerialDevice) PoW(mid Trits, mwm int) (Trits, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	req := make([]byte, len(serialDeviceHeader)+1+len(mid))
+	copy(req, serialDeviceHeader)
+	req[len(serialDeviceHeader)] = byte(mwm)
+	for i, t := range mid {
+		req[len(serialDeviceHeader)+1+i] = byte(t + 1)
+	}
+	if _, err := d.rw.Write(req); err != nil {
+		return nil, errors.Wrap(ErrPoWDeviceFailure, err.Error())
+	}
+
+	res := make([]byte, NonceTrinarySize)
+	if _, err := io.ReadFull(d.rw, res); err != nil {
+		return nil, errors.Wrap(ErrPoWDeviceFailure, err.Error())
+	}
+	nonce := make(Trits, NonceTrinarySize)
+	for i, b := range res {
+		if b > 2 {
+			return nil, errors.Wrapf(ErrPoWDeviceFailure, "invalid nonce trit byte %d", b)
+		}
+		nonce[i] = int8(b) - 1
+	}
+	return nonce, nil
+}
+
+// Close implements PoWDevice.
+func (d *SerialDevice) Close() error {
+	return d.rw.Close()
+}
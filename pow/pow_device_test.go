@@ -0,0 +1,73 @@
+package pow_test
+
+import (
+	"io"
+	"net"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SerialDevice", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	// serveDevice emulates the device side of the serial protocol: it reads one
+	// request frame, verifies it and answers with the given nonce trit bytes.
+	serveDevice := func(conn net.Conn, mwm int, res []byte) {
+		defer GinkgoRecover()
+		req := make([]byte, 3+1+curl.StateSize)
+		_, err := io.ReadFull(conn, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(req[:3])).To(Equal("POW"))
+		Expect(int(req[3])).To(Equal(mwm))
+		_, err = conn.Write(res)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("computes a correct nonce through the device", func() {
+		// emulate an accelerator by answering with a nonce computed in software
+		nonce, err := pow.GoProofOfWork(rawTx, 9)
+		Expect(err).ToNot(HaveOccurred())
+		nonceTrits := MustTrytesToTrits(nonce)
+		res := make([]byte, len(nonceTrits))
+		for i, t := range nonceTrits {
+			res[i] = byte(t + 1)
+		}
+
+		hostConn, deviceConn := net.Pipe()
+		go serveDevice(deviceConn, 9, res)
+
+		device := pow.NewSerialDevice("TestFPGA", hostConn)
+		defer device.Close()
+		powFunc := pow.DeviceProofOfWork(device)
+
+		deviceNonce, err := powFunc(rawTx, 9)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deviceNonce).To(Equal(nonce))
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + deviceNonce
+		hashedTrytes := curl.HashTrytes(powedTx)
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+
+	It("returns an error for a malformed nonce frame", func() {
+		res := make([]byte, NonceTrinarySize)
+		for i := range res {
+			res[i] = 9
+		}
+
+		hostConn, deviceConn := net.Pipe()
+		go serveDevice(deviceConn, 9, res)
+
+		device := pow.NewSerialDevice("TestFPGA", hostConn)
+		defer device.Close()
+		powFunc := pow.DeviceProofOfWork(device)
+
+		_, err := powFunc(rawTx, 9)
+		Expect(err).To(HaveOccurred())
+	})
+})
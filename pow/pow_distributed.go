@@ -0,0 +1,206 @@
+package pow
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNoPoWWorkers gets returned when a distributed Proof-of-Work is started without any worker address.
+	ErrNoPoWWorkers = errors.New("no Proof-of-Work worker addresses supplied")
+	// ErrAllPoWWorkersFailed gets returned when no Proof-of-Work worker returned a nonce.
+	ErrAllPoWWorkersFailed = errors.New("all Proof-of-Work workers failed")
+)
+
+// distributedJob is sent by the coordinator to a worker: the Curl mid-state of
+// the transaction (with the last block loaded), the MWM and the slice of the
+// nonce search space the worker should explore via its incrN() offsets.
+type distributedJob struct {
+	Mid    Trits `json:"mid"`
+	MWM    int   `json:"mwm"`
+	Offset int   `json:"offset"`
+	Stride int   `json:"stride"`
+}
+
+type distributedResult struct {
+	Nonce Trytes `json:"nonce,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// DistributedWorker serves nonce searches to a distributed Proof-of-Work
+// coordinator over plain TCP. A search is cancelled as soon as the coordinator
+// closes the connection, for example because another worker found a nonce first.
+type DistributedWorker struct {
+	listener net.Listener
+}
+
+// NewDistributedWorker creates a new DistributedWorker serving nonce searches
+// on the given listener.
+func NewDistributedWorker(listener net.Listener) *DistributedWorker {
+	w := &DistributedWorker{listener: listener}
+	go w.acceptLoop()
+	return w
+}
+
+// Close stops accepting new jobs.
+func (w *DistributedWorker) Close() error {
+	return w.listener.Close()
+}
+
+func (w *DistributedWorker) acceptLoop() {
+	for {
+		conn, err := w.listener.Accept()
+		if err != nil {
+			return
+		}
+		go w.handle(conn)
+	}
+}
+
+func (w *DistributedWorker) handle(conn net.Conn) {
+	defer conn.Close()
+
+	job := &distributedJob{}
+	if err := json.NewDecoder(conn).Decode(job); err != nil {
+		json.NewEncoder(conn).Encode(distributedResult{Error: err.Error()})
+		return
+	}
+	if len(job.Mid) != curl.StateSize || job.Stride < 1 {
+		json.NewEncoder(conn).Encode(distributedResult{Error: "malformed job"})
+		return
+	}
+
+	// the coordinator closing the connection cancels the search
+	var cancelled int32
+	go func() {
+		var b [1]byte
+		conn.Read(b[:])
+		atomic.StoreInt32(&cancelled, 1)
+	}()
+
+	// like goProofOfWorkWithCurlState(), but the lanes of the local goroutines
+	// are spaced by the job stride so that the workers start on disjoint offsets
+	numGoroutines := defaultProofOfWorkParallelism
+	nonceChan := make(chan Trytes, 1)
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			lmid, hmid := para(job.Mid)
+			lmid[nonceOffset] = low0
+			hmid[nonceOffset] = high0
+			lmid[nonceOffset+1] = low1
+			hmid[nonceOffset+1] = high1
+			lmid[nonceOffset+2] = low2
+			hmid[nonceOffset+2] = high2
+			lmid[nonceOffset+3] = low3
+			hmid[nonceOffset+3] = high3
+
+			incrN(job.Offset+i*job.Stride, lmid, hmid)
+			nonce, r := loop(lmid, hmid, job.MWM, &cancelled)
+
+			if r >= 0 && len(nonce) > 0 {
+				select {
+				case nonceChan <- MustTritsToTrytes(nonce):
+					atomic.StoreInt32(&cancelled, 1)
+				default:
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	select {
+	case nonce := <-nonceChan:
+		json.NewEncoder(conn).Encode(distributedResult{Nonce: nonce})
+	default:
+		json.NewEncoder(conn).Encode(distributedResult{Error: "search cancelled"})
+	}
+}
+
+// DistributedProofOfWork returns a ProofOfWorkFunc which splits the nonce
+// search space across the workers listening on the given TCP addresses. The
+// first valid nonce wins and the searches of the remaining workers are
+// cancelled by closing their connections. The parallelism option is ignored,
+// the parallelism is defined by the workers.
+func DistributedProofOfWork(workerAddrs ...string) ProofOfWorkFunc {
+	return func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+		if len(workerAddrs) == 0 {
+			return "", ErrNoPoWWorkers
+		}
+		if trytes == "" {
+			return "", ErrInvalidTrytesForProofOfWork
+		}
+
+		c := curl.NewCurl()
+		c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+		tr := MustTrytesToTrits(trytes)
+		copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+		results := make(chan distributedResult, len(workerAddrs))
+		conns := make([]net.Conn, 0, len(workerAddrs))
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+
+		for k, addr := range workerAddrs {
+			conn, err := net.Dial("tcp", addr)
+			if err != nil {
+				results <- distributedResult{Error: err.Error()}
+				continue
+			}
+			conns = append(conns, conn)
+
+			job := distributedJob{Mid: c.State, MWM: mwm, Offset: k, Stride: len(workerAddrs)}
+			if err := json.NewEncoder(conn).Encode(job); err != nil {
+				results <- distributedResult{Error: err.Error()}
+				continue
+			}
+			go func(conn net.Conn) {
+				res := distributedResult{}
+				if err := json.NewDecoder(conn).Decode(&res); err != nil {
+					res = distributedResult{Error: err.Error()}
+				}
+				results <- res
+			}(conn)
+		}
+
+		var lastErr string
+		for i := 0; i < len(workerAddrs); i++ {
+			res := <-results
+			if len(res.Nonce) > 0 {
+				// workers are not trusted, only a verified nonce may win
+				if !validNonce(trytes, res.Nonce, mwm) {
+					lastErr = "worker returned an invalid nonce"
+					continue
+				}
+				return res.Nonce, nil
+			}
+			lastErr = res.Error
+		}
+		return "", errors.Wrap(ErrAllPoWWorkersFailed, lastErr)
+	}
+}
+
+// validNonce tells whether the nonce solves the Proof-of-Work of the
+// transaction at the given MWM, guarding the coordinator against buggy or
+// malicious workers poisoning the attachment.
+func validNonce(trytes Trytes, nonce Trytes, mwm int) bool {
+	const nonceTryteSize = NonceTrinarySize / 3
+	if len(nonce) != nonceTryteSize || ValidTrytes(nonce) != nil {
+		return false
+	}
+	hash := curl.HashTrytes(trytes[:len(trytes)-nonceTryteSize] + nonce)
+	return TrailingZeros(MustTrytesToTrits(hash)) >= int64(mwm)
+}
@@ -0,0 +1,80 @@
+package pow_test
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DistributedProofOfWork", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	newWorker := func() (*pow.DistributedWorker, string) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		return pow.NewDistributedWorker(listener), listener.Addr().String()
+	}
+
+	It("computes a correct nonce across multiple workers", func() {
+		worker1, addr1 := newWorker()
+		defer worker1.Close()
+		worker2, addr2 := newWorker()
+		defer worker2.Close()
+
+		powFunc := pow.DistributedProofOfWork(addr1, addr2)
+		nonce, err := powFunc(rawTx, 9)
+		Expect(err).ToNot(HaveOccurred())
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		hashedTrytes := curl.HashTrytes(powedTx)
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+
+	It("skips workers returning invalid nonces", func() {
+		// a malicious worker instantly answering every job with a bogus nonce
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func(conn net.Conn) {
+					defer conn.Close()
+					var job map[string]interface{}
+					json.NewDecoder(conn).Decode(&job)
+					json.NewEncoder(conn).Encode(map[string]string{
+						"nonce": strings.Repeat("9", NonceTrinarySize/3),
+					})
+				}(conn)
+			}
+		}()
+
+		honest, addr := newWorker()
+		defer honest.Close()
+
+		powFunc := pow.DistributedProofOfWork(listener.Addr().String(), addr)
+		nonce, err := powFunc(rawTx, 9)
+		Expect(err).ToNot(HaveOccurred())
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		Expect(guards.IsTransactionHashWithMWM(curl.HashTrytes(powedTx), 9)).To(BeTrue())
+	})
+
+	It("returns an error without worker addresses", func() {
+		_, err := pow.DistributedProofOfWork()(rawTx, 9)
+		Expect(err).To(Equal(pow.ErrNoPoWWorkers))
+	})
+
+	It("returns an error when all workers are unreachable", func() {
+		_, err := pow.DistributedProofOfWork("127.0.0.1:1")(rawTx, 9)
+		Expect(err).To(HaveOccurred())
+	})
+})
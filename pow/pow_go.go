@@ -6,13 +6,13 @@
 // If no parallelism option is supplied, then the number of CPU cores - 1 is used.
 package pow
 
-import "C"
 import (
 	. "github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/curl"
 	. "github.com/iotaledger/iota.go/transaction"
 	. "github.com/iotaledger/iota.go/trinary"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 	"math"
@@ -41,6 +41,40 @@ type ProofOfWorkFunc = func(trytes Trytes, mwm int, parallelism ...int) (Trytes,
 // This function expects the passed in transaction trytes from highest to lowest index, meaning the transaction
 // with current index 0 at the last position.
 func DoPoW(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64, pow ProofOfWorkFunc) ([]Trytes, error) {
+	return DoPoWWithOptions(trunkTx, branchTx, trytes, mwm, pow, DoPoWOptions{})
+}
+
+// DefaultNonceSearchRetries is the default amount of times the nonce search of one
+// transaction is restarted with a bumped attachment timestamp after the nonce space
+// was exhausted without a solution (possible at very high MWM).
+const DefaultNonceSearchRetries = 3
+
+// ErrNonceSearchRetriesExceeded gets returned when the nonce space was exhausted and
+// the search still found no nonce after the configured amount of timestamp bumps.
+var ErrNonceSearchRetriesExceeded = errors.New("nonce search retries exceeded")
+
+// DoPoWOptions allow to customize how DoPoWWithOptions() stamps the attachment
+// timestamp fields of the transactions.
+type DoPoWOptions struct {
+	// The attachment timestamp in milliseconds to stamp on every transaction.
+	// If nil, the current time at the Proof-of-Work of each transaction is used.
+	AttachmentTimestamp *int64
+	// The lower bound to stamp instead of LowerBoundAttachmentTimestamp.
+	AttachmentTimestampLowerBound *int64
+	// The upper bound to stamp instead of UpperBoundAttachmentTimestamp.
+	AttachmentTimestampUpperBound *int64
+	// Whether to leave the attachment timestamp fields of the transactions
+	// untouched, for example for deterministic replays of an attachment.
+	SkipTimestampStamping bool
+	// The amount of times the nonce search of one transaction is restarted with
+	// a bumped attachment timestamp after the nonce space was exhausted.
+	// Defaults to DefaultNonceSearchRetries.
+	NonceSearchRetries *int
+}
+
+// DoPoWWithOptions is like DoPoW() but allows to customize how the attachment
+// timestamp fields are stamped through the given options.
+func DoPoWWithOptions(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64, pow ProofOfWorkFunc, opts DoPoWOptions) ([]Trytes, error) {
 	txs, err := AsTransactionObjects(trytes, nil)
 	if err != nil {
 		return nil, err
@@ -57,14 +91,41 @@ func DoPoW(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64, pow ProofOfWor
 			txs[i].BranchTransaction = trunkTx
 		}
 
-		txs[i].AttachmentTimestamp = time.Now().UnixNano() / 1000000
-		txs[i].AttachmentTimestampLowerBound = LowerBoundAttachmentTimestamp
-		txs[i].AttachmentTimestampUpperBound = UpperBoundAttachmentTimestamp
+		if !opts.SkipTimestampStamping {
+			switch {
+			case opts.AttachmentTimestamp != nil:
+				txs[i].AttachmentTimestamp = *opts.AttachmentTimestamp
+			default:
+				txs[i].AttachmentTimestamp = time.Now().UnixNano() / 1000000
+			}
+			txs[i].AttachmentTimestampLowerBound = LowerBoundAttachmentTimestamp
+			txs[i].AttachmentTimestampUpperBound = UpperBoundAttachmentTimestamp
+			if opts.AttachmentTimestampLowerBound != nil {
+				txs[i].AttachmentTimestampLowerBound = *opts.AttachmentTimestampLowerBound
+			}
+			if opts.AttachmentTimestampUpperBound != nil {
+				txs[i].AttachmentTimestampUpperBound = *opts.AttachmentTimestampUpperBound
+			}
+		}
 
-		var err error
-		txs[i].Nonce, err = pow(MustTransactionToTrytes(&txs[i]), int(mwm))
-		if err != nil {
-			return nil, err
+		retries := DefaultNonceSearchRetries
+		if opts.NonceSearchRetries != nil {
+			retries = *opts.NonceSearchRetries
+		}
+		for attempt := 0; ; attempt++ {
+			nonce, err := pow(MustTransactionToTrytes(&txs[i]), int(mwm))
+			if err == nil {
+				txs[i].Nonce = nonce
+				break
+			}
+			if errors.Cause(err) != ErrNonceNotFound {
+				return nil, err
+			}
+			if attempt >= retries {
+				return nil, errors.Wrapf(ErrNonceSearchRetriesExceeded, "after %d attempts", attempt+1)
+			}
+			// bump the attachment timestamp to change the mid-state and search again
+			txs[i].AttachmentTimestamp++
 		}
 
 		// set new transaction hash
@@ -115,7 +176,9 @@ func GetProofOfWorkImplementations() (powFuncNames []string) {
 
 // GetFastestProofOfWorkImpl returns the fastest Proof-of-Work implementation.
 func GetFastestProofOfWorkImpl() (string, ProofOfWorkFunc) {
-	orderPreference := []string{"SyncAVX", "SyncSSE", "SyncCARM64", "SyncC128", "SyncC"}
+	// SyncGoYield only exists under js/wasm, where yielding to the event loop
+	// trumps raw speed.
+	orderPreference := []string{"SyncCUDA", "SyncAVX512", "SyncAVX", "SyncSSE", "SyncCARM64", "SyncC128", "SyncC", "SyncGoYield"}
 
 	for _, impl := range orderPreference {
 		if p, exist := proofOfWorkFuncs[impl]; exist {
@@ -206,13 +269,17 @@ func incrN(n int, lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64) {
 }
 
 func transform64(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64) {
+	transform64Rounds(lmid, hmid, curl.NumberOfRounds)
+}
+
+func transform64Rounds(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64, rounds int) {
 	var ltmp, htmp [curl.StateSize]uint64
 	lfrom := lmid
 	hfrom := hmid
 	lto := &ltmp
 	hto := &htmp
 
-	for r := 0; r < curl.NumberOfRounds-1; r++ {
+	for r := 0; r < rounds-1; r++ {
 		for j := 0; j < curl.StateSize; j++ {
 			t1 := curl.Indices[j]
 			t2 := curl.Indices[j+1]
@@ -242,8 +309,12 @@ func transform64(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64) {
 		hto[j] = (alpha ^ gamma) | delta
 	}
 
-	copy(lmid[:], ltmp[:])
-	copy(hmid[:], htmp[:])
+	// after an even number of buffer swaps the final round already wrote
+	// into the mid-state, otherwise the result must be copied back.
+	if lto != lmid {
+		copy(lmid[:], lto[:])
+		copy(hmid[:], hto[:])
+	}
 }
 
 func incr(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64) bool {
@@ -297,13 +368,17 @@ func check(l *[curl.StateSize]uint64, h *[curl.StateSize]uint64, m int) int {
 	return -1
 }
 
-func loop(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64, m int, cancelled *bool) (Trits, int64) {
+func loop(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64, m int, cancelled *int32) (Trits, int64) {
+	return loopRounds(lmid, hmid, m, cancelled, curl.NumberOfRounds)
+}
+
+func loopRounds(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64, m int, cancelled *int32, rounds int) (Trits, int64) {
 	var lcpy, hcpy [curl.StateSize]uint64
 	var i int64
-	for i = 0; !incr(lmid, hmid) && !*cancelled; i++ {
+	for i = 0; !incr(lmid, hmid) && atomic.LoadInt32(cancelled) == 0; i++ {
 		copy(lcpy[:], lmid[:])
 		copy(hcpy[:], hmid[:])
-		transform64(&lcpy, &hcpy)
+		transform64Rounds(&lcpy, &hcpy, rounds)
 
 		if n := check(&lcpy, &hcpy, m); n >= 0 {
 			nonce := seri(lmid, hmid, uint(n))
@@ -319,26 +394,56 @@ func goProofOfWork(trytes Trytes, mwm int, optRate chan int64, parallelism ...in
 		return "", ErrInvalidTrytesForProofOfWork
 	}
 
-	// if any goroutine finds a nonce, then the cancel flag is set to true
+	// if any goroutine finds a nonce, then the cancel flag is set
 	// and thereby all other ongoing Proof-of-Work tasks will halt.
-	cancelled := false
-
+	// the flag is only accessed through sync/atomic to keep the
+	// cancellation path race free.
 	c := curl.NewCurl()
 	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
-	tr := MustTrytesToTrits(trytes)
+	tr := GetTritsBuffer(len(trytes) * 3)
+	if err := TrytesToTritsInto(trytes, tr); err != nil {
+		PutTritsBuffer(tr)
+		return "", err
+	}
 	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+	PutTritsBuffer(tr)
+
+	return goProofOfWorkWithCurlState(c, mwm, optRate, parallelism...)
+}
+
+// searches a nonce on the given Curl state, where the state must hold the
+// mid-state of the transaction with the last 243 trits loaded as the pending block.
+func goProofOfWorkWithCurlState(c *curl.Curl, mwm int, optRate chan int64, parallelism ...int) (Trytes, error) {
+	nonce, err := goProofOfWorkTritsWithCurlState(c, mwm, optRate, parallelism...)
+	if err != nil {
+		return "", err
+	}
+	return MustTritsToTrytes(nonce), nil
+}
+
+// like goProofOfWorkWithCurlState() but returns the raw nonce trits.
+func goProofOfWorkTritsWithCurlState(c *curl.Curl, mwm int, optRate chan int64, parallelism ...int) (Trits, error) {
+	// if any goroutine finds a nonce, then the cancel flag is set
+	// and thereby all other ongoing Proof-of-Work tasks will halt.
+	// the flag is only accessed through sync/atomic to keep the
+	// cancellation path race free.
+	var cancelled int32
 
 	numGoroutines := proofOfWorkParallelism(parallelism...)
-	var result Trytes
 	var rate chan int64
 	if optRate != nil {
 		rate = make(chan int64, numGoroutines)
 	}
-	exit := make(chan struct{})
-	nonceChan := make(chan Trytes)
+	// the first goroutine finding a nonce places it into the buffered channel
+	// and cancels the search, all other goroutines then exit on their next
+	// cancellation check without blocking on a send.
+	nonceChan := make(chan Trits, 1)
 
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
 	for i := 0; i < numGoroutines; i++ {
 		go func(i int) {
+			defer wg.Done()
 			lmid, hmid := para(c.State)
 			lmid[nonceOffset] = low0
 			hmid[nonceOffset] = high0
@@ -357,14 +462,18 @@ func goProofOfWork(trytes Trytes, mwm int, optRate chan int64, parallelism ...in
 			}
 			if r >= 0 && len(nonce) > 0 {
 				select {
-				case <-exit:
-				case nonceChan <- MustTritsToTrytes(nonce):
-					cancelled = true
+				case nonceChan <- nonce:
+					atomic.StoreInt32(&cancelled, 1)
+				default:
 				}
 			}
 		}(i)
 	}
 
+	// guarantee that all goroutines exited (and thereby sent their hash counts)
+	// before returning
+	wg.Wait()
+
 	if rate != nil {
 		var rateSum int64
 		for i := 0; i < numGoroutines; i++ {
@@ -373,8 +482,28 @@ func goProofOfWork(trytes Trytes, mwm int, optRate chan int64, parallelism ...in
 		optRate <- rateSum
 	}
 
-	result = <-nonceChan
-	close(exit)
-	cancelled = true
-	return result, nil
+	select {
+	case result := <-nonceChan:
+		return result, nil
+	default:
+		// only happens when every goroutine exhausted its nonce space
+		return nil, ErrNonceNotFound
+	}
+}
+
+// GoProofOfWorkWithRate is like GoProofOfWork() but additionally reports the
+// effective throughput of the search in hashes per second.
+func GoProofOfWorkWithRate(trytes Trytes, mwm int, parallelism ...int) (Trytes, int64, error) {
+	optRate := make(chan int64, 1)
+	start := time.Now()
+	nonce, err := goProofOfWork(trytes, mwm, optRate, parallelism...)
+	if err != nil {
+		return "", 0, err
+	}
+	hashes := <-optRate
+	var hashesPerSec int64
+	if elapsed := time.Since(start).Seconds(); elapsed > 0 {
+		hashesPerSec = int64(float64(hashes) / elapsed)
+	}
+	return nonce, hashesPerSec, nil
 }
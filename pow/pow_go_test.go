@@ -1,12 +1,19 @@
 package pow_test
 
 import (
+	"strings"
+	"sync"
+
 	. "github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/curl"
 	"github.com/iotaledger/iota.go/guards"
 	"github.com/iotaledger/iota.go/pow"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
 )
 
 var _ = Describe("GoProofOfWork", func() {
@@ -19,6 +26,109 @@ var _ = Describe("GoProofOfWork", func() {
 			hashedTrytes := curl.HashTrytes(rawTx)
 			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 14)).To(BeTrue())
 		})
+
+		// run with -race to verify the cancellation path is race free
+		It("computes correct nonces for concurrent invocations", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					nonce, err := pow.GoProofOfWork(rawTx, 9)
+					Expect(err).ToNot(HaveOccurred())
+					powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+					hashedTrytes := curl.HashTrytes(powedTx)
+					Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+				}()
+			}
+			wg.Wait()
+		})
+	})
+
+	Context("GoProofOfWorkWithRate()", func() {
+		rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+		It("computes a correct nonce and reports the hash rate", func() {
+			nonce, hashesPerSec, err := pow.GoProofOfWorkWithRate(rawTx, 9)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hashesPerSec).To(BeNumerically(">", 0))
+			powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+			hashedTrytes := curl.HashTrytes(powedTx)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+		})
+
+		It("returns an error for empty trytes", func() {
+			_, _, err := pow.GoProofOfWorkWithRate("", 9)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("DoPoWWithOptions()", func() {
+		rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+		trunk := strings.Repeat("9", 81)
+		branch := strings.Repeat("9", 81)
+
+		It("stamps the supplied attachment timestamps", func() {
+			timestamp := int64(1234567890)
+			lowerBound := int64(1)
+			upperBound := int64(9876543210)
+			powedTrytes, err := pow.DoPoWWithOptions(trunk, branch, []Trytes{rawTx}, 9, pow.GoProofOfWork, pow.DoPoWOptions{
+				AttachmentTimestamp:           &timestamp,
+				AttachmentTimestampLowerBound: &lowerBound,
+				AttachmentTimestampUpperBound: &upperBound,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			tx, err := transaction.AsTransactionObject(powedTrytes[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tx.AttachmentTimestamp).To(Equal(timestamp))
+			Expect(tx.AttachmentTimestampLowerBound).To(Equal(lowerBound))
+			Expect(tx.AttachmentTimestampUpperBound).To(Equal(upperBound))
+			Expect(guards.IsTransactionHashWithMWM(curl.HashTrytes(powedTrytes[0]), 9)).To(BeTrue())
+		})
+
+		It("leaves the attachment timestamps untouched when stamping is skipped", func() {
+			powedTrytes, err := pow.DoPoWWithOptions(trunk, branch, []Trytes{rawTx}, 9, pow.GoProofOfWork, pow.DoPoWOptions{
+				SkipTimestampStamping: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			tx, err := transaction.AsTransactionObject(powedTrytes[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tx.AttachmentTimestamp).To(Equal(int64(0)))
+			Expect(tx.AttachmentTimestampLowerBound).To(Equal(int64(0)))
+			Expect(tx.AttachmentTimestampUpperBound).To(Equal(int64(0)))
+			Expect(guards.IsTransactionHashWithMWM(curl.HashTrytes(powedTrytes[0]), 9)).To(BeTrue())
+		})
+
+		It("bumps the attachment timestamp and restarts an exhausted nonce search", func() {
+			var stampedTimestamps []int64
+			powFunc := func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+				tx, err := transaction.AsTransactionObject(trytes)
+				Expect(err).ToNot(HaveOccurred())
+				stampedTimestamps = append(stampedTimestamps, tx.AttachmentTimestamp)
+				if len(stampedTimestamps) <= 2 {
+					return "", pow.ErrNonceNotFound
+				}
+				return pow.GoProofOfWork(trytes, mwm)
+			}
+			timestamp := int64(1000)
+			powedTrytes, err := pow.DoPoWWithOptions(trunk, branch, []Trytes{rawTx}, 9, powFunc, pow.DoPoWOptions{
+				AttachmentTimestamp: &timestamp,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stampedTimestamps).To(Equal([]int64{1000, 1001, 1002}))
+			Expect(guards.IsTransactionHashWithMWM(curl.HashTrytes(powedTrytes[0]), 9)).To(BeTrue())
+		})
+
+		It("fails once the nonce search retry budget is exceeded", func() {
+			powFunc := func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+				return "", pow.ErrNonceNotFound
+			}
+			retries := 1
+			_, err := pow.DoPoWWithOptions(trunk, branch, []Trytes{rawTx}, 9, powFunc, pow.DoPoWOptions{
+				NonceSearchRetries: &retries,
+			})
+			Expect(errors.Cause(err)).To(Equal(pow.ErrNonceSearchRetriesExceeded))
+		})
 	})
 
 })
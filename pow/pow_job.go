@@ -0,0 +1,154 @@
+package pow
+
+import (
+	"sync"
+	"sync/atomic"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// PoWJob is a handle to an asynchronously running Proof-of-Work search started
+// via StartPoW(). The search can be paused and resumed at any time: pausing
+// keeps the current lmid/hmid nonce counters of all search goroutines, so no
+// progress is lost while the CPU is yielded.
+type PoWJob struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	pauseRequested int32
+	cancelled      int32
+
+	done  chan struct{}
+	nonce Trytes
+	err   error
+}
+
+// StartPoW starts an asynchronous Proof-of-Work search on the given trytes and
+// returns a handle to it. The search runs with the given parallelism, like GoProofOfWork().
+func StartPoW(trytes Trytes, mwm int, parallelism ...int) (*PoWJob, error) {
+	if trytes == "" {
+		return nil, ErrInvalidTrytesForProofOfWork
+	}
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	job := &PoWJob{done: make(chan struct{})}
+	job.cond = sync.NewCond(&job.mu)
+
+	numGoroutines := proofOfWorkParallelism(parallelism...)
+	nonceChan := make(chan Trytes, 1)
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			lmid, hmid := para(c.State)
+			lmid[nonceOffset] = low0
+			hmid[nonceOffset] = high0
+			lmid[nonceOffset+1] = low1
+			hmid[nonceOffset+1] = high1
+			lmid[nonceOffset+2] = low2
+			hmid[nonceOffset+2] = high2
+			lmid[nonceOffset+3] = low3
+			hmid[nonceOffset+3] = high3
+
+			incrN(i, lmid, hmid)
+			nonce, r := job.loop(lmid, hmid, mwm)
+
+			if r >= 0 && len(nonce) > 0 {
+				select {
+				case nonceChan <- MustTritsToTrytes(nonce):
+					// the store happens under the lock so that a goroutine
+					// about to pause cannot miss the wake up
+					job.mu.Lock()
+					atomic.StoreInt32(&job.cancelled, 1)
+					job.mu.Unlock()
+					job.cond.Broadcast()
+				default:
+				}
+			}
+		}(i)
+	}
+
+	go func() {
+		wg.Wait()
+		select {
+		case job.nonce = <-nonceChan:
+		default:
+			job.err = ErrNonceNotFound
+		}
+		close(job.done)
+	}()
+	return job, nil
+}
+
+// Pause requests the search goroutines to halt at their next checkpoint,
+// keeping their current nonce counters. Pausing an already paused or finished
+// job has no effect.
+func (j *PoWJob) Pause() {
+	atomic.StoreInt32(&j.pauseRequested, 1)
+}
+
+// Resume continues a paused search where it left off.
+func (j *PoWJob) Resume() {
+	j.mu.Lock()
+	atomic.StoreInt32(&j.pauseRequested, 0)
+	j.mu.Unlock()
+	j.cond.Broadcast()
+}
+
+// Done returns a channel which is closed once the search is finished.
+func (j *PoWJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Result returns the found nonce. It must only be called after the Done()
+// channel was closed.
+func (j *PoWJob) Result() (Trytes, error) {
+	return j.nonce, j.err
+}
+
+// Wait blocks until the search is finished and returns the found nonce.
+// A paused job must be resumed for Wait to return.
+func (j *PoWJob) Wait() (Trytes, error) {
+	<-j.done
+	return j.nonce, j.err
+}
+
+// loop is like loop() but additionally halts at a pause request.
+func (j *PoWJob) loop(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64, m int) (Trits, int64) {
+	var lcpy, hcpy [curl.StateSize]uint64
+	var i int64
+	for i = 0; !incr(lmid, hmid) && atomic.LoadInt32(&j.cancelled) == 0; i++ {
+		j.waitWhilePaused()
+
+		copy(lcpy[:], lmid[:])
+		copy(hcpy[:], hmid[:])
+		transform64(&lcpy, &hcpy)
+
+		if n := check(&lcpy, &hcpy, m); n >= 0 {
+			nonce := seri(lmid, hmid, uint(n))
+			return nonce, i * 64
+		}
+	}
+	return nil, i * 64
+}
+
+// waitWhilePaused blocks while the job is paused. A cancelled job is never
+// blocked, so that all goroutines can exit after a nonce was found.
+func (j *PoWJob) waitWhilePaused() {
+	// fast path without taking the lock
+	if atomic.LoadInt32(&j.pauseRequested) == 0 {
+		return
+	}
+	j.mu.Lock()
+	for atomic.LoadInt32(&j.pauseRequested) == 1 && atomic.LoadInt32(&j.cancelled) == 0 {
+		j.cond.Wait()
+	}
+	j.mu.Unlock()
+}
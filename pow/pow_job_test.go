@@ -0,0 +1,56 @@
+package pow_test
+
+import (
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StartPoW", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	It("computes a correct nonce asynchronously", func() {
+		job, err := pow.StartPoW(rawTx, 9)
+		Expect(err).ToNot(HaveOccurred())
+		nonce, err := job.Wait()
+		Expect(err).ToNot(HaveOccurred())
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		hashedTrytes := curl.HashTrytes(powedTx)
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+
+	It("pauses and resumes the search without losing the result", func() {
+		job, err := pow.StartPoW(rawTx, 14)
+		Expect(err).ToNot(HaveOccurred())
+		job.Pause()
+
+		select {
+		case <-job.Done():
+			// the nonce was found before the pause could take effect
+		case <-time.After(500 * time.Millisecond):
+			// the paused search must not finish
+			select {
+			case <-job.Done():
+				Fail("paused search finished")
+			default:
+			}
+		}
+
+		job.Resume()
+		nonce, err := job.Wait()
+		Expect(err).ToNot(HaveOccurred())
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		hashedTrytes := curl.HashTrytes(powedTx)
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 14)).To(BeTrue())
+	})
+
+	It("returns an error for empty trytes", func() {
+		_, err := pow.StartPoW("", 9)
+		Expect(err).To(Equal(pow.ErrInvalidTrytesForProofOfWork))
+	})
+})
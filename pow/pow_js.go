@@ -0,0 +1,77 @@
+// +build js
+// +build wasm
+
+package pow
+
+import (
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// DefaultYieldBatchSize is the default amount of transform iterations (64 hashes each)
+// done between two yields to the event loop during a yielding Proof-of-Work search.
+const DefaultYieldBatchSize = 64
+
+// the sleep between two batches; sleeping hands control back to the JavaScript
+// event loop, as the Go runtime schedules timers via setTimeout under js/wasm.
+const yieldSleepDuration = time.Duration(1) * time.Millisecond
+
+var yieldBatchSize = DefaultYieldBatchSize
+
+// SetYieldBatchSize sets the amount of transform iterations done between two yields
+// to the event loop during a yielding Proof-of-Work search. Higher values search
+// faster but block the UI thread for longer.
+func SetYieldBatchSize(n int) {
+	if n <= 0 {
+		n = DefaultYieldBatchSize
+	}
+	yieldBatchSize = n
+}
+
+func init() {
+	proofOfWorkFuncs["GoYield"] = YieldingGoProofOfWork
+	proofOfWorkFuncs["SyncGoYield"] = YieldingGoProofOfWork
+}
+
+// YieldingGoProofOfWork is like GoProofOfWork() but periodically yields to the
+// JavaScript event loop during the nonce search, so browser-based wallets don't
+// freeze the UI thread. As js/wasm binaries execute on a single thread, the search
+// runs sequentially and the parallelism option is ignored.
+func YieldingGoProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	lmid, hmid := para(c.State)
+	lmid[nonceOffset] = low0
+	hmid[nonceOffset] = high0
+	lmid[nonceOffset+1] = low1
+	hmid[nonceOffset+1] = high1
+	lmid[nonceOffset+2] = low2
+	hmid[nonceOffset+2] = high2
+	lmid[nonceOffset+3] = low3
+	hmid[nonceOffset+3] = high3
+
+	var lcpy, hcpy [curl.StateSize]uint64
+	for i := 0; !incr(lmid, hmid); i++ {
+		copy(lcpy[:], lmid[:])
+		copy(hcpy[:], hmid[:])
+		transform64(&lcpy, &hcpy)
+
+		if n := check(&lcpy, &hcpy, mwm); n >= 0 {
+			return MustTritsToTrytes(seri(lmid, hmid, uint(n))), nil
+		}
+		if (i+1)%yieldBatchSize == 0 {
+			time.Sleep(yieldSleepDuration)
+		}
+	}
+	return "", ErrInvalidTrytesForProofOfWork
+}
@@ -0,0 +1,148 @@
+package pow
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Metrics receives observations about executed Proof-of-Work searches.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// JobStarted is called when a Proof-of-Work search starts.
+	JobStarted()
+	// JobFinished is called when a Proof-of-Work search finished successfully,
+	// with the wall-clock duration of the search and its effective hash rate.
+	JobFinished(duration time.Duration, hashesPerSec float64)
+	// JobFailed is called when a Proof-of-Work search returned an error.
+	JobFailed()
+}
+
+// InstrumentProofOfWork wraps the given Proof-of-Work implementation so that
+// every invocation is reported into the given metrics. As the wrapped
+// implementation does not report its tried hashes, the hash rate is estimated
+// from the expected amount of hashes (3^mwm) for a search of the given difficulty.
+func InstrumentProofOfWork(powFunc ProofOfWorkFunc, metrics Metrics) ProofOfWorkFunc {
+	if metrics == nil {
+		return powFunc
+	}
+	return func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+		metrics.JobStarted()
+		start := time.Now()
+		nonce, err := powFunc(trytes, mwm, parallelism...)
+		if err != nil {
+			metrics.JobFailed()
+			return "", err
+		}
+		duration := time.Since(start)
+		var hashesPerSec float64
+		if secs := duration.Seconds(); secs > 0 {
+			hashesPerSec = math.Pow(3, float64(mwm)) / secs
+		}
+		metrics.JobFinished(duration, hashesPerSec)
+		return nonce, nil
+	}
+}
+
+// DefaultDurationBuckets are the default histogram buckets in seconds for the
+// duration of a Proof-of-Work search.
+var DefaultDurationBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// DefaultHashRateBuckets are the default histogram buckets for the effective
+// hash rate of a Proof-of-Work search.
+var DefaultHashRateBuckets = []float64{1e3, 1e4, 1e5, 1e6, 1e7, 1e8}
+
+// PrometheusMetrics implements Metrics and exposes the collected observations
+// in the Prometheus text exposition format via its http.Handler implementation,
+// without requiring the Prometheus client library.
+type PrometheusMetrics struct {
+	mu sync.Mutex
+
+	jobs     int64
+	failed   int64
+	inFlight int64
+
+	durationBuckets []float64
+	durationCounts  []int64
+	durationSum     float64
+	durationCount   int64
+
+	rateBuckets []float64
+	rateCounts  []int64
+	rateSum     float64
+	rateCount   int64
+}
+
+// NewPrometheusMetrics creates a new PrometheusMetrics with the default histogram buckets.
+func NewPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		durationBuckets: DefaultDurationBuckets,
+		durationCounts:  make([]int64, len(DefaultDurationBuckets)),
+		rateBuckets:     DefaultHashRateBuckets,
+		rateCounts:      make([]int64, len(DefaultHashRateBuckets)),
+	}
+}
+
+// JobStarted implements Metrics.
+func (pm *PrometheusMetrics) JobStarted() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.jobs++
+	pm.inFlight++
+}
+
+// JobFinished implements Metrics.
+func (pm *PrometheusMetrics) JobFinished(duration time.Duration, hashesPerSec float64) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.inFlight--
+	observe(pm.durationBuckets, pm.durationCounts, duration.Seconds())
+	pm.durationSum += duration.Seconds()
+	pm.durationCount++
+	observe(pm.rateBuckets, pm.rateCounts, hashesPerSec)
+	pm.rateSum += hashesPerSec
+	pm.rateCount++
+}
+
+// JobFailed implements Metrics.
+func (pm *PrometheusMetrics) JobFailed() {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.inFlight--
+	pm.failed++
+}
+
+func observe(buckets []float64, counts []int64, v float64) {
+	i := sort.SearchFloat64s(buckets, v)
+	for ; i < len(buckets); i++ {
+		counts[i]++
+	}
+}
+
+// ServeHTTP writes the collected observations in the Prometheus text exposition format.
+func (pm *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprintf(w, "# TYPE iota_pow_jobs_total counter\niota_pow_jobs_total %d\n", pm.jobs)
+	fmt.Fprintf(w, "# TYPE iota_pow_jobs_failed_total counter\niota_pow_jobs_failed_total %d\n", pm.failed)
+	fmt.Fprintf(w, "# TYPE iota_pow_jobs_in_flight gauge\niota_pow_jobs_in_flight %d\n", pm.inFlight)
+	writeHistogram(w, "iota_pow_duration_seconds", pm.durationBuckets, pm.durationCounts, pm.durationSum, pm.durationCount)
+	writeHistogram(w, "iota_pow_hash_rate", pm.rateBuckets, pm.rateCounts, pm.rateSum, pm.rateCount)
+}
+
+func writeHistogram(w http.ResponseWriter, name string, buckets []float64, counts []int64, sum float64, count int64) {
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for i, bound := range buckets {
+		fmt.Fprintf(w, "%s_bucket{le=\"%g\"} %d\n", name, bound, counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, count)
+	fmt.Fprintf(w, "%s_sum %g\n", name, sum)
+	fmt.Fprintf(w, "%s_count %d\n", name, count)
+}
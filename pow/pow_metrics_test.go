@@ -0,0 +1,89 @@
+package pow_test
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+)
+
+type recordingMetrics struct {
+	mu       sync.Mutex
+	started  int
+	finished int
+	failed   int
+	lastRate float64
+}
+
+func (m *recordingMetrics) JobStarted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started++
+}
+
+func (m *recordingMetrics) JobFinished(duration time.Duration, hashesPerSec float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished++
+	m.lastRate = hashesPerSec
+}
+
+func (m *recordingMetrics) JobFailed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failed++
+}
+
+var _ = Describe("Metrics", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	Context("InstrumentProofOfWork()", func() {
+		It("reports started and finished jobs", func() {
+			metrics := &recordingMetrics{}
+			powFunc := pow.InstrumentProofOfWork(pow.GoProofOfWork, metrics)
+			_, err := powFunc(rawTx, 9)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metrics.started).To(Equal(1))
+			Expect(metrics.finished).To(Equal(1))
+			Expect(metrics.failed).To(BeZero())
+			Expect(metrics.lastRate).To(BeNumerically(">", 0))
+		})
+
+		It("reports failed jobs", func() {
+			metrics := &recordingMetrics{}
+			powErr := errors.New("pow failed")
+			powFunc := pow.InstrumentProofOfWork(func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+				return "", powErr
+			}, metrics)
+			_, err := powFunc(rawTx, 9)
+			Expect(err).To(Equal(powErr))
+			Expect(metrics.started).To(Equal(1))
+			Expect(metrics.failed).To(Equal(1))
+		})
+	})
+
+	Context("PrometheusMetrics", func() {
+		It("exposes the collected observations in the text exposition format", func() {
+			metrics := pow.NewPrometheusMetrics()
+			powFunc := pow.InstrumentProofOfWork(pow.GoProofOfWork, metrics)
+			_, err := powFunc(rawTx, 9)
+			Expect(err).ToNot(HaveOccurred())
+
+			rec := httptest.NewRecorder()
+			metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+			body, err := ioutil.ReadAll(rec.Result().Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(ContainSubstring("iota_pow_jobs_total 1"))
+			Expect(string(body)).To(ContainSubstring("iota_pow_jobs_in_flight 0"))
+			Expect(string(body)).To(ContainSubstring("iota_pow_duration_seconds_count 1"))
+			Expect(string(body)).To(ContainSubstring("iota_pow_hash_rate_count 1"))
+		})
+	})
+})
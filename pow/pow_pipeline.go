@@ -0,0 +1,80 @@
+package pow
+
+import (
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// preparedPoWTx holds the chain independent precomputations for one transaction
+// of a bundle: the Curl state after absorbing everything up to the trunk
+// transaction field and the trits of the last block holding tag, attachment
+// timestamps and nonce.
+type preparedPoWTx struct {
+	prefix *curl.Curl
+	tail   Trits
+}
+
+// DoPoWPipelined is like DoPoW() using the Go Proof-of-Work implementation, but
+// overlaps the preparation of the next transaction with the ongoing nonce search.
+// Only the trunk and branch transaction fields depend on the hash of the previously
+// attached transaction, so while goroutines search the nonce for transaction i, the
+// trytes-to-trits conversion and the Curl mid-state up to the trunk transaction field
+// of transaction i+1 are computed concurrently, cutting wall-clock time for large bundles.
+func DoPoWPipelined(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64, parallelism ...int) ([]Trytes, error) {
+	txs, err := AsTransactionObjects(trytes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the preparer only touches transaction i before sending its precomputations,
+	// while the search loop only touches it after receiving them, so the channel
+	// keeps the pipeline race free.
+	prepared := make(chan preparedPoWTx)
+	go func() {
+		for i := 0; i < len(txs); i++ {
+			txs[i].AttachmentTimestamp = time.Now().UnixNano() / 1000000
+			txs[i].AttachmentTimestampLowerBound = LowerBoundAttachmentTimestamp
+			txs[i].AttachmentTimestampUpperBound = UpperBoundAttachmentTimestamp
+
+			txTrytes := MustTransactionToTrytes(&txs[i])
+			c := curl.NewCurl()
+			c.Absorb(txTrytes[:TrunkTransactionTrinaryOffset/3])
+			tail := MustTrytesToTrits(txTrytes[(TransactionTrinarySize-HashTrinarySize)/3:])
+			prepared <- preparedPoWTx{prefix: c, tail: tail}
+		}
+	}()
+
+	var prev Trytes
+	for i := 0; i < len(txs); i++ {
+		p := <-prepared
+
+		switch {
+		case i == 0:
+			txs[i].TrunkTransaction = trunkTx
+			txs[i].BranchTransaction = branchTx
+		default:
+			txs[i].TrunkTransaction = prev
+			txs[i].BranchTransaction = trunkTx
+		}
+
+		// absorb the two chain dependent blocks and load the last block for the search
+		p.prefix.Absorb(txs[i].TrunkTransaction + txs[i].BranchTransaction)
+		copy(p.prefix.State, p.tail)
+
+		nonce, err := goProofOfWorkWithCurlState(p.prefix, int(mwm), nil, parallelism...)
+		if err != nil {
+			return nil, err
+		}
+		txs[i].Nonce = nonce
+
+		// set new transaction hash
+		txs[i].Hash = TransactionHash(&txs[i])
+		prev = txs[i].Hash
+	}
+	powedTxTrytes := MustTransactionsToTrytes(txs)
+	return powedTxTrytes, nil
+}
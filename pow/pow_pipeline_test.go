@@ -0,0 +1,50 @@
+package pow_test
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DoPoWPipelined", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+	trunk := strings.Repeat("9", 81)
+	branch := strings.Repeat("9", 81)
+
+	It("computes correct nonces and chains the bundle transactions", func() {
+		powedTrytes, err := pow.DoPoWPipelined(trunk, branch, []Trytes{rawTx, rawTx, rawTx}, 9)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(powedTrytes).To(HaveLen(3))
+
+		var prevHash Trytes
+		for i, powedTx := range powedTrytes {
+			hashedTrytes := curl.HashTrytes(powedTx)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+
+			tx, err := transaction.AsTransactionObject(powedTx)
+			Expect(err).ToNot(HaveOccurred())
+			switch {
+			case i == 0:
+				Expect(tx.TrunkTransaction).To(Equal(trunk))
+				Expect(tx.BranchTransaction).To(Equal(branch))
+			default:
+				Expect(tx.TrunkTransaction).To(Equal(prevHash))
+				Expect(tx.BranchTransaction).To(Equal(trunk))
+			}
+			prevHash = tx.Hash
+		}
+	})
+
+	It("computes the same attachment as DoPoW for a single transaction", func() {
+		powedTrytes, err := pow.DoPoWPipelined(trunk, branch, []Trytes{rawTx}, 9)
+		Expect(err).ToNot(HaveOccurred())
+		hashedTrytes := curl.HashTrytes(powedTrytes[0])
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+})
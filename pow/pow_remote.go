@@ -0,0 +1,131 @@
+package pow
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	. "github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultRemoteProofOfWorkTimeout is the default timeout used for requests against a remote PoW service.
+const DefaultRemoteProofOfWorkTimeout = time.Duration(10) * time.Second
+
+// ErrRemoteProofOfWorkFailed gets returned when the remote PoW service could not be
+// reached or returned an error.
+var ErrRemoteProofOfWorkFailed = errors.New("remote Proof-of-Work request failed")
+
+// remoteAttachToTangleRequest is the attachToTangle command understood by
+// powsrv.io compatible PoW services.
+type remoteAttachToTangleRequest struct {
+	Command            string   `json:"command"`
+	TrunkTransaction   Trytes   `json:"trunkTransaction"`
+	BranchTransaction  Trytes   `json:"branchTransaction"`
+	MinWeightMagnitude int      `json:"minWeightMagnitude"`
+	Trytes             []Trytes `json:"trytes"`
+}
+
+type remoteAttachToTangleResponse struct {
+	Trytes    []Trytes `json:"trytes"`
+	Error     string   `json:"error"`
+	Exception string   `json:"exception"`
+}
+
+// RemoteProofOfWork creates a ProofOfWorkFunc which delegates the nonce search to a
+// remote PoW service speaking the powsrv.io protocol (an attachToTangle compatible
+// HTTP endpoint authenticated via the powsrv-token header). Requests time out after
+// DefaultRemoteProofOfWorkTimeout. When the remote service can not be reached or
+// returns an error, the Proof-of-Work falls back to the given local implementation,
+// or to the fastest available local implementation if none is given. The parallelism
+// option is only used by the fallback implementation.
+func RemoteProofOfWork(endpoint string, apiKey string, fallback ...ProofOfWorkFunc) ProofOfWorkFunc {
+	client := &http.Client{Timeout: DefaultRemoteProofOfWorkTimeout}
+	var fallbackPoW ProofOfWorkFunc
+	if len(fallback) > 0 && fallback[0] != nil {
+		fallbackPoW = fallback[0]
+	} else {
+		_, fallbackPoW = GetFastestProofOfWorkImpl()
+	}
+	return func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+		nonce, err := remoteProofOfWork(client, endpoint, apiKey, trytes, mwm)
+		if err != nil {
+			if err == ErrInvalidTrytesForProofOfWork {
+				return "", err
+			}
+			return fallbackPoW(trytes, mwm, parallelism...)
+		}
+		return nonce, nil
+	}
+}
+
+func remoteProofOfWork(client *http.Client, endpoint string, apiKey string, trytes Trytes, mwm int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+
+	// the trunk and branch to approve were already placed inside the
+	// transaction by the caller (see DoPoW())
+	tx, err := AsTransactionObject(trytes)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(&remoteAttachToTangleRequest{
+		Command:            "attachToTangle",
+		TrunkTransaction:   tx.TrunkTransaction,
+		BranchTransaction:  tx.BranchTransaction,
+		MinWeightMagnitude: mwm,
+		Trytes:             []Trytes{trytes},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-IOTA-API-Version", "1")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "powsrv-token "+apiKey)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, err.Error())
+	}
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Wrapf(ErrRemoteProofOfWorkFailed, "http code %d", resp.StatusCode)
+	}
+
+	res := &remoteAttachToTangleResponse{}
+	if err := json.Unmarshal(bs, res); err != nil {
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, err.Error())
+	}
+	switch {
+	case res.Error != "":
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, res.Error)
+	case res.Exception != "":
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, res.Exception)
+	case len(res.Trytes) == 0:
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, "empty trytes in response")
+	}
+
+	powedTx, err := AsTransactionObject(res.Trytes[0])
+	if err != nil {
+		return "", errors.Wrap(ErrRemoteProofOfWorkFailed, err.Error())
+	}
+	return powedTx.Nonce, nil
+}
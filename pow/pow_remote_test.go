@@ -0,0 +1,69 @@
+package pow_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RemoteProofOfWork", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	Context("RemoteProofOfWork()", func() {
+		It("computes a correct nonce via the remote service", func() {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("powsrv-token test-key"))
+				req := struct {
+					Command string   `json:"command"`
+					Trytes  []Trytes `json:"trytes"`
+				}{}
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				Expect(req.Command).To(Equal("attachToTangle"))
+				Expect(req.Trytes).To(HaveLen(1))
+				nonce, err := pow.GoProofOfWork(req.Trytes[0], 14)
+				Expect(err).ToNot(HaveOccurred())
+				powed := req.Trytes[0][:len(req.Trytes[0])-NonceTrinarySize/3] + nonce
+				json.NewEncoder(w).Encode(struct {
+					Trytes []Trytes `json:"trytes"`
+				}{Trytes: []Trytes{powed}})
+			}))
+			defer srv.Close()
+
+			powFunc := pow.RemoteProofOfWork(srv.URL, "test-key")
+			nonce, err := powFunc(rawTx, 14)
+			Expect(err).ToNot(HaveOccurred())
+			powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+			hashedTrytes := curl.HashTrytes(powedTx)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 14)).To(BeTrue())
+		})
+
+		It("falls back to the local implementation when the service errors", func() {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer srv.Close()
+
+			powFunc := pow.RemoteProofOfWork(srv.URL, "", pow.GoProofOfWork)
+			nonce, err := powFunc(rawTx, 14)
+			Expect(err).ToNot(HaveOccurred())
+			powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+			hashedTrytes := curl.HashTrytes(powedTx)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 14)).To(BeTrue())
+		})
+
+		It("returns an error for empty trytes", func() {
+			powFunc := pow.RemoteProofOfWork("http://localhost:0", "")
+			_, err := powFunc("", 14)
+			Expect(err).To(Equal(pow.ErrInvalidTrytesForProofOfWork))
+		})
+	})
+
+})
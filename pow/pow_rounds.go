@@ -0,0 +1,91 @@
+package pow
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// ErrInvalidRoundsForProofOfWork gets returned when the given number of Curl rounds is smaller than one.
+var ErrInvalidRoundsForProofOfWork = errors.New("invalid number of Curl rounds for Proof-of-Work")
+
+// GoProofOfWorkWithRounds is like GoProofOfWork() but runs the nonce search against
+// a Curl with the given number of rounds instead of the default Curl-P-81. Private
+// tangles and research networks which use a reduced-round Curl such as Curl-P-27
+// can thereby reuse this package. Note that a nonce computed with a non-default
+// round count does not validate against the regular Curl-P-81 transaction hash.
+func GoProofOfWorkWithRounds(trytes Trytes, mwm int, rounds int, parallelism ...int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+	if rounds < 1 {
+		return "", ErrInvalidRoundsForProofOfWork
+	}
+
+	// absorb all blocks but the last one with the reduced-round transform.
+	tr := MustTrytesToTrits(trytes)
+	state := make(Trits, curl.StateSize)
+	for i := 0; i < TransactionTrinarySize-HashTrinarySize; i += HashTrinarySize {
+		copy(state, tr[i:i+HashTrinarySize])
+		transformRounds(state, rounds)
+	}
+	copy(state, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	var cancelled int32
+	numGoroutines := proofOfWorkParallelism(parallelism...)
+	nonceChan := make(chan Trits, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			lmid, hmid := para(state)
+			lmid[nonceOffset] = low0
+			hmid[nonceOffset] = high0
+			lmid[nonceOffset+1] = low1
+			hmid[nonceOffset+1] = high1
+			lmid[nonceOffset+2] = low2
+			hmid[nonceOffset+2] = high2
+			lmid[nonceOffset+3] = low3
+			hmid[nonceOffset+3] = high3
+
+			incrN(i, lmid, hmid)
+			nonce, r := loopRounds(lmid, hmid, mwm, &cancelled, rounds)
+			if r >= 0 && len(nonce) > 0 {
+				select {
+				case nonceChan <- nonce:
+					atomic.StoreInt32(&cancelled, 1)
+				default:
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	select {
+	case nonce := <-nonceChan:
+		return MustTritsToTrytes(nonce), nil
+	default:
+		return "", ErrNonceNotFound
+	}
+}
+
+// transformRounds does the Curl transformation on the given state with the given number of rounds.
+func transformRounds(state Trits, rounds int) {
+	var cpy [curl.StateSize]int8
+
+	for r := rounds; r > 0; r-- {
+		copy(cpy[:], state)
+		for i := 0; i < curl.StateSize; i++ {
+			t1 := curl.Indices[i]
+			t2 := curl.Indices[i+1]
+			state[i] = curl.TruthTable[cpy[t1]+(cpy[t2]<<2)+5]
+		}
+	}
+}
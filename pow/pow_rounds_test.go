@@ -0,0 +1,66 @@
+package pow_test
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// hashTrytesWithRounds computes the Curl hash of the given trytes with the given
+// number of rounds using the naive trit-by-trit transformation. It serves as an
+// independent cross-check of the bit-sliced reduced-round implementation.
+func hashTrytesWithRounds(trytes Trytes, rounds int) Trits {
+	state := make(Trits, curl.StateSize)
+	tr := MustTrytesToTrits(trytes)
+	var cpy [curl.StateSize]int8
+	for i := 0; i < len(tr); i += HashTrinarySize {
+		copy(state, tr[i:i+HashTrinarySize])
+		for r := rounds; r > 0; r-- {
+			copy(cpy[:], state)
+			for j := 0; j < curl.StateSize; j++ {
+				t1 := curl.Indices[j]
+				t2 := curl.Indices[j+1]
+				state[j] = curl.TruthTable[cpy[t1]+(cpy[t2]<<2)+5]
+			}
+		}
+	}
+	return state[:HashTrinarySize]
+}
+
+var _ = Describe("GoProofOfWorkWithRounds", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	It("computes a nonce which is valid under Curl-P-27", func() {
+		nonce, err := pow.GoProofOfWorkWithRounds(rawTx, 9, 27)
+		Expect(err).ToNot(HaveOccurred())
+
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		hash := hashTrytesWithRounds(powedTx, 27)
+		for _, trit := range hash[HashTrinarySize-9:] {
+			Expect(trit).To(Equal(int8(0)))
+		}
+	})
+
+	It("matches the default Curl-P-81 for the default round count", func() {
+		nonce, err := pow.GoProofOfWorkWithRounds(rawTx, 9, curl.NumberOfRounds)
+		Expect(err).ToNot(HaveOccurred())
+
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		hashedTrytes := curl.HashTrytes(powedTx)
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+
+	It("returns an error for an invalid round count", func() {
+		_, err := pow.GoProofOfWorkWithRounds(rawTx, 9, 0)
+		Expect(err).To(Equal(pow.ErrInvalidRoundsForProofOfWork))
+	})
+
+	It("returns an error for empty trytes", func() {
+		_, err := pow.GoProofOfWorkWithRounds("", 9, 27)
+		Expect(err).To(Equal(pow.ErrInvalidTrytesForProofOfWork))
+	})
+})
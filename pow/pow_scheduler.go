@@ -0,0 +1,207 @@
+package pow
+
+import (
+	"container/heap"
+	"sync"
+
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultSchedulerQueueSize is the default amount of jobs a Scheduler queues up
+// before Submit() starts to block.
+const DefaultSchedulerQueueSize = 100
+
+// ErrSchedulerShutdown gets returned for jobs which could not be executed because
+// the Scheduler was shut down.
+var ErrSchedulerShutdown = errors.New("Proof-of-Work scheduler was shut down")
+
+// JobPriority defines the priority with which a queued Proof-of-Work job is
+// scheduled. Higher priorities are executed first; jobs of equal priority are
+// executed in submission order.
+type JobPriority int
+
+const (
+	// PriorityLow is suited for background work such as reattachments.
+	PriorityLow JobPriority = iota
+	// PriorityNormal is the default priority of Submit().
+	PriorityNormal
+	// PriorityHigh is suited for user-facing value transfers.
+	PriorityHigh
+)
+
+// SchedulerJob represents one queued DoPoW job. The job is finished once its
+// Done() channel is closed, after which Result() returns the Proof-of-Work
+// result of the job.
+type SchedulerJob struct {
+	trunkTx  Trytes
+	branchTx Trytes
+	trytes   []Trytes
+	mwm      uint64
+	priority JobPriority
+	seq      uint64
+
+	done   chan struct{}
+	result []Trytes
+	err    error
+}
+
+// Priority returns the priority with which the job was submitted.
+func (job *SchedulerJob) Priority() JobPriority {
+	return job.priority
+}
+
+// Done returns a channel which is closed once the job is finished.
+func (job *SchedulerJob) Done() <-chan struct{} {
+	return job.done
+}
+
+// Result returns the Proof-of-Work result of the job. It must only be called
+// after the Done() channel was closed.
+func (job *SchedulerJob) Result() ([]Trytes, error) {
+	return job.result, job.err
+}
+
+// Wait blocks until the job is finished and returns its result.
+func (job *SchedulerJob) Wait() ([]Trytes, error) {
+	<-job.done
+	return job.result, job.err
+}
+
+// jobQueue is a priority queue ordering jobs by priority first and submission
+// order second.
+type jobQueue []*SchedulerJob
+
+func (q jobQueue) Len() int { return len(q) }
+func (q jobQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+func (q jobQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *jobQueue) Push(x interface{}) { *q = append(*q, x.(*SchedulerJob)) }
+func (q *jobQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return job
+}
+
+// Scheduler executes many DoPoW jobs against a single bounded worker pool, so
+// that concurrent bundles do not oversubscribe the CPU with each spinning up
+// its own Proof-of-Work goroutines. Jobs are scheduled by priority, allowing
+// user-facing transfers to overtake queued background work. Each worker executes
+// one job at a time with a parallelism of one, hence the worker count bounds the
+// total parallelism.
+type Scheduler struct {
+	powFunc ProofOfWorkFunc
+	wg      sync.WaitGroup
+
+	mu       sync.Mutex
+	cond     *sync.Cond
+	queue    jobQueue
+	seq      uint64
+	shutdown bool
+}
+
+// NewScheduler creates and starts a new Scheduler executing jobs with the given
+// Proof-of-Work implementation against a worker pool of the given size. A worker
+// count <= 0 defaults to the number of CPU cores - 1.
+func NewScheduler(powFunc ProofOfWorkFunc, workers int) *Scheduler {
+	if workers <= 0 {
+		workers = defaultProofOfWorkParallelism
+	}
+	s := &Scheduler{powFunc: powFunc}
+	s.cond = sync.NewCond(&s.mu)
+	s.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go s.worker()
+	}
+	return s
+}
+
+// Submit queues a new DoPoW job with PriorityNormal and returns it.
+func (s *Scheduler) Submit(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64) *SchedulerJob {
+	return s.SubmitWithPriority(trunkTx, branchTx, trytes, mwm, PriorityNormal)
+}
+
+// SubmitWithPriority queues a new DoPoW job with the given priority and returns
+// it. SubmitWithPriority blocks while the job queue is full. Jobs submitted after
+// Shutdown() finish immediately with ErrSchedulerShutdown.
+func (s *Scheduler) SubmitWithPriority(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64, priority JobPriority) *SchedulerJob {
+	job := &SchedulerJob{
+		trunkTx:  trunkTx,
+		branchTx: branchTx,
+		trytes:   trytes,
+		mwm:      mwm,
+		priority: priority,
+		done:     make(chan struct{}),
+	}
+	s.mu.Lock()
+	for !s.shutdown && len(s.queue) >= DefaultSchedulerQueueSize {
+		s.cond.Wait()
+	}
+	if s.shutdown {
+		s.mu.Unlock()
+		job.err = ErrSchedulerShutdown
+		close(job.done)
+		return job
+	}
+	job.seq = s.seq
+	s.seq++
+	heap.Push(&s.queue, job)
+	s.mu.Unlock()
+	s.cond.Broadcast()
+	return job
+}
+
+// Shutdown stops the workers after their currently running job and fails all
+// queued but not yet started jobs with ErrSchedulerShutdown.
+func (s *Scheduler) Shutdown() {
+	s.mu.Lock()
+	if s.shutdown {
+		s.mu.Unlock()
+		return
+	}
+	s.shutdown = true
+	s.mu.Unlock()
+	s.cond.Broadcast()
+	s.wg.Wait()
+	s.mu.Lock()
+	for s.queue.Len() > 0 {
+		job := heap.Pop(&s.queue).(*SchedulerJob)
+		job.err = ErrSchedulerShutdown
+		close(job.done)
+	}
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) worker() {
+	defer s.wg.Done()
+	for {
+		s.mu.Lock()
+		for !s.shutdown && s.queue.Len() == 0 {
+			s.cond.Wait()
+		}
+		if s.shutdown {
+			s.mu.Unlock()
+			return
+		}
+		job := heap.Pop(&s.queue).(*SchedulerJob)
+		s.mu.Unlock()
+		s.cond.Broadcast()
+
+		job.result, job.err = DoPoW(job.trunkTx, job.branchTx, job.trytes, job.mwm, s.singleLanePoW)
+		close(job.done)
+	}
+}
+
+// singleLanePoW runs the scheduler's Proof-of-Work implementation with a
+// parallelism of one, so the worker count bounds the total parallelism.
+func (s *Scheduler) singleLanePoW(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+	return s.powFunc(trytes, mwm, 1)
+}
@@ -0,0 +1,87 @@
+package pow_test
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scheduler", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+	trunk := strings.Repeat("9", 81)
+	branch := strings.Repeat("9", 81)
+
+	Context("Submit()", func() {
+		It("executes many jobs against the shared worker pool", func() {
+			scheduler := pow.NewScheduler(pow.GoProofOfWork, 2)
+			defer scheduler.Shutdown()
+
+			jobs := make([]*pow.SchedulerJob, 3)
+			for i := range jobs {
+				jobs[i] = scheduler.Submit(trunk, branch, []Trytes{rawTx}, 9)
+			}
+			for _, job := range jobs {
+				powedTrytes, err := job.Wait()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(powedTrytes).To(HaveLen(1))
+				hashedTrytes := curl.HashTrytes(powedTrytes[0])
+				Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+			}
+		})
+	})
+
+	Context("SubmitWithPriority()", func() {
+		It("executes higher priority jobs before queued lower priority ones", func() {
+			var mu sync.Mutex
+			var order []int
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var once sync.Once
+			powFunc := func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+				once.Do(func() {
+					close(started)
+					<-release
+				})
+				mu.Lock()
+				order = append(order, mwm)
+				mu.Unlock()
+				return pow.GoProofOfWork(trytes, mwm)
+			}
+
+			scheduler := pow.NewScheduler(powFunc, 1)
+			defer scheduler.Shutdown()
+
+			// occupy the single worker, then queue a normal and a high priority job
+			occupier := scheduler.Submit(trunk, branch, []Trytes{rawTx}, 9)
+			<-started
+			normal := scheduler.SubmitWithPriority(trunk, branch, []Trytes{rawTx}, 7, pow.PriorityNormal)
+			high := scheduler.SubmitWithPriority(trunk, branch, []Trytes{rawTx}, 8, pow.PriorityHigh)
+			close(release)
+
+			for _, job := range []*pow.SchedulerJob{occupier, normal, high} {
+				_, err := job.Wait()
+				Expect(err).ToNot(HaveOccurred())
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(order).To(Equal([]int{9, 8, 7}))
+		})
+	})
+
+	Context("Shutdown()", func() {
+		It("fails jobs submitted after the shutdown", func() {
+			scheduler := pow.NewScheduler(pow.GoProofOfWork, 1)
+			scheduler.Shutdown()
+			job := scheduler.Submit(trunk, branch, []Trytes{rawTx}, 9)
+			_, err := job.Wait()
+			Expect(err).To(Equal(pow.ErrSchedulerShutdown))
+		})
+	})
+
+})
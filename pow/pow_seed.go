@@ -0,0 +1,53 @@
+package pow
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNonceNotFound gets returned when a deterministic search exhausted its nonce
+// space without finding a nonce fulfilling the difficulty requirement.
+var ErrNonceNotFound = errors.New("no nonce found in the searched nonce space")
+
+// GoProofOfWorkWithSeed is like GoProofOfWork() but runs a single search lane
+// whose nonce counter starts at the given seed offset. Given the same transaction
+// trytes, MWM and seed, the search explores the nonce space in the same order and
+// therefore always yields the same nonce, making Proof-of-Work dependent
+// integration tests and golden files reproducible.
+func GoProofOfWorkWithSeed(trytes Trytes, mwm int, seed int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+	if seed < 0 {
+		seed = 0
+	}
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	lmid, hmid := para(c.State)
+	lmid[nonceOffset] = low0
+	hmid[nonceOffset] = high0
+	lmid[nonceOffset+1] = low1
+	hmid[nonceOffset+1] = high1
+	lmid[nonceOffset+2] = low2
+	hmid[nonceOffset+2] = high2
+	lmid[nonceOffset+3] = low3
+	hmid[nonceOffset+3] = high3
+
+	incrN(seed, lmid, hmid)
+
+	// never cancelled, the single lane runs until a nonce is found
+	// or the nonce space is exhausted
+	var cancelled int32
+	nonce, r := loop(lmid, hmid, mwm, &cancelled)
+	if r < 0 || len(nonce) == 0 {
+		return "", ErrNonceNotFound
+	}
+	return MustTritsToTrytes(nonce), nil
+}
@@ -0,0 +1,41 @@
+package pow_test
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoProofOfWorkWithSeed", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+
+	It("computes the same nonce for the same seed", func() {
+		nonce, err := pow.GoProofOfWorkWithSeed(rawTx, 9, 3)
+		Expect(err).ToNot(HaveOccurred())
+		nonceAgain, err := pow.GoProofOfWorkWithSeed(rawTx, 9, 3)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nonce).To(Equal(nonceAgain))
+
+		powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+		hashedTrytes := curl.HashTrytes(powedTx)
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+
+	It("computes correct nonces for different seeds", func() {
+		for _, seed := range []int{0, 7} {
+			nonce, err := pow.GoProofOfWorkWithSeed(rawTx, 9, seed)
+			Expect(err).ToNot(HaveOccurred())
+			powedTx := rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+			hashedTrytes := curl.HashTrytes(powedTx)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+		}
+	})
+
+	It("returns an error for empty trytes", func() {
+		_, err := pow.GoProofOfWorkWithSeed("", 9, 0)
+		Expect(err).To(Equal(pow.ErrInvalidTrytesForProofOfWork))
+	})
+})
@@ -260,7 +260,7 @@ func init() {
 
 // SSEProofOfWork does proof of work on the given trytes using SSE2 instructions.
 func SSEProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
-	return sseProofOfwork(trytes, mwm, nil, parallelism...)
+	return sseProofOfWork(trytes, mwm, nil, parallelism...)
 }
 
 var syncSSEProofOfWork = sync.Mutex{}
@@ -269,14 +269,14 @@ var syncSSEProofOfWork = sync.Mutex{}
 func SyncSSEProofOfWork(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
 	syncSSEProofOfWork.Lock()
 	defer syncSSEProofOfWork.Unlock()
-	nonce, err := sseProofOfwork(trytes, mwm, nil, parallelism...)
+	nonce, err := sseProofOfWork(trytes, mwm, nil, parallelism...)
 	if err != nil {
 		return "", err
 	}
 	return nonce, nil
 }
 
-func sseProofOfwork(trytes Trytes, mwm int, optRate chan int64, parallelism ...int) (Trytes, error) {
+func sseProofOfWork(trytes Trytes, mwm int, optRate chan int64, parallelism ...int) (Trytes, error) {
 	if trytes == "" {
 		return "", ErrInvalidTrytesForProofOfWork
 	}
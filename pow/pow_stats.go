@@ -0,0 +1,156 @@
+package pow
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// DefaultStatsInterval is the default interval in which progress updates are emitted
+// during a Proof-of-Work search with stats.
+const DefaultStatsInterval = time.Duration(1) * time.Second
+
+// PoWStats describes a snapshot of an ongoing Proof-of-Work search.
+type PoWStats struct {
+	// The amount of hashes tried since the search started.
+	HashesTried int64
+	// The average amount of hashes per second since the search started.
+	HashRate float64
+	// The time elapsed since the search started.
+	Elapsed time.Duration
+}
+
+// StatsCallback is a function receiving periodic progress updates during a
+// Proof-of-Work search. It is called from a separate goroutine.
+type StatsCallback = func(PoWStats)
+
+// GoProofOfWorkWithStats is like GoProofOfWork() but additionally emits periodic
+// progress updates to the given callback while the search runs. Updates are emitted
+// in the given interval (DefaultStatsInterval if zero) and one final update is
+// emitted once the nonce was found.
+func GoProofOfWorkWithStats(trytes Trytes, mwm int, onProgress StatsCallback, interval time.Duration, parallelism ...int) (Trytes, error) {
+	if trytes == "" {
+		return "", ErrInvalidTrytesForProofOfWork
+	}
+	if interval <= 0 {
+		interval = DefaultStatsInterval
+	}
+
+	// if any goroutine finds a nonce, then the cancel flag is set
+	// and thereby all other ongoing Proof-of-Work tasks will halt.
+	var cancelled int32
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	numGoroutines := proofOfWorkParallelism(parallelism...)
+	exit := make(chan struct{})
+	// buffered so that the finding goroutine never blocks on the send,
+	// see goProofOfWorkWithCurlState()
+	nonceChan := make(chan Trytes, 1)
+
+	var hashesTried int64
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			lmid, hmid := para(c.State)
+			lmid[nonceOffset] = low0
+			hmid[nonceOffset] = high0
+			lmid[nonceOffset+1] = low1
+			hmid[nonceOffset+1] = high1
+			lmid[nonceOffset+2] = low2
+			hmid[nonceOffset+2] = high2
+			lmid[nonceOffset+3] = low3
+			hmid[nonceOffset+3] = high3
+
+			incrN(i, lmid, hmid)
+			nonce, r := loopStats(lmid, hmid, mwm, &cancelled, &hashesTried)
+
+			if r >= 0 && len(nonce) > 0 {
+				select {
+				case nonceChan <- MustTritsToTrytes(nonce):
+					atomic.StoreInt32(&cancelled, 1)
+				default:
+				}
+			}
+		}(i)
+	}
+
+	// sample the hash counter in the given interval until the search is done
+	samplerDone := make(chan struct{})
+	go func() {
+		defer close(samplerDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-exit:
+				return
+			case <-ticker.C:
+				if onProgress != nil {
+					onProgress(snapshotStats(&hashesTried, start))
+				}
+			}
+		}
+	}()
+
+	// all goroutines exit before the search returns: either one found a nonce
+	// or they were cancelled on their next check
+	wg.Wait()
+	close(exit)
+	<-samplerDone
+
+	var result Trytes
+	select {
+	case result = <-nonceChan:
+	default:
+		// only happens when every goroutine exhausted its nonce space
+		return "", ErrNonceNotFound
+	}
+	if onProgress != nil {
+		onProgress(snapshotStats(&hashesTried, start))
+	}
+	return result, nil
+}
+
+// loopStats is like loop() but counts the tried hashes in the given counter.
+func loopStats(lmid *[curl.StateSize]uint64, hmid *[curl.StateSize]uint64, m int, cancelled *int32, counter *int64) (Trits, int64) {
+	var lcpy, hcpy [curl.StateSize]uint64
+	var i int64
+	for i = 0; !incr(lmid, hmid) && atomic.LoadInt32(cancelled) == 0; i++ {
+		copy(lcpy[:], lmid[:])
+		copy(hcpy[:], hmid[:])
+		transform64(&lcpy, &hcpy)
+		atomic.AddInt64(counter, 64)
+
+		if n := check(&lcpy, &hcpy, m); n >= 0 {
+			nonce := seri(lmid, hmid, uint(n))
+			return nonce, i * 64
+		}
+	}
+	return nil, i * 64
+}
+
+func snapshotStats(hashesTried *int64, start time.Time) PoWStats {
+	tried := atomic.LoadInt64(hashesTried)
+	elapsed := time.Since(start)
+	var hashRate float64
+	if elapsed > 0 {
+		hashRate = float64(tried) / elapsed.Seconds()
+	}
+	return PoWStats{
+		HashesTried: tried,
+		HashRate:    hashRate,
+		Elapsed:     elapsed,
+	}
+}
@@ -0,0 +1,46 @@
+package pow_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GoProofOfWorkWithStats", func() {
+	Context("GoProofOfWorkWithStats()", func() {
+		rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+		It("computes a correct nonce and emits progress updates", func() {
+			var mu sync.Mutex
+			var updates []pow.PoWStats
+			nonce, err := pow.GoProofOfWorkWithStats(rawTx, 14, func(stats pow.PoWStats) {
+				mu.Lock()
+				updates = append(updates, stats)
+				mu.Unlock()
+			}, time.Duration(10)*time.Millisecond)
+			rawTx = rawTx[:len(rawTx)-NonceTrinarySize/3] + nonce
+			Expect(err).ToNot(HaveOccurred())
+			hashedTrytes := curl.HashTrytes(rawTx)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 14)).To(BeTrue())
+
+			mu.Lock()
+			defer mu.Unlock()
+			Expect(len(updates)).To(BeNumerically(">", 0))
+			final := updates[len(updates)-1]
+			Expect(final.HashesTried).To(BeNumerically(">", 0))
+			Expect(final.HashRate).To(BeNumerically(">", 0.0))
+			Expect(final.Elapsed).To(BeNumerically(">", time.Duration(0)))
+		})
+
+		It("returns an error for empty trytes", func() {
+			_, err := pow.GoProofOfWorkWithStats("", 14, nil, 0)
+			Expect(err).To(Equal(pow.ErrInvalidTrytesForProofOfWork))
+		})
+	})
+
+})
@@ -0,0 +1,68 @@
+package pow
+
+import (
+	"time"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// StreamedPoWTx is emitted by DoPoWStream() for each attached transaction, in
+// attachment order.
+type StreamedPoWTx struct {
+	// The index of the transaction within the passed in trytes.
+	Index int
+	// The attached transaction trytes including the computed nonce.
+	Trytes Trytes
+	// The error which aborted the attachment. It is only set on the last
+	// emitted element, after which no further transactions are emitted.
+	Err error
+}
+
+// DoPoWStream is like DoPoW() but emits each attached transaction on the returned
+// channel as soon as its nonce was found, instead of returning the full bundle at
+// the end. This allows broadcast pipelines to start storing or broadcasting the
+// tail transactions while the head of the bundle is still being mined.
+// The channel is closed once all transactions were emitted or an error occurred.
+func DoPoWStream(trunkTx, branchTx Trytes, trytes []Trytes, mwm uint64, pow ProofOfWorkFunc) <-chan StreamedPoWTx {
+	out := make(chan StreamedPoWTx)
+	go func() {
+		defer close(out)
+
+		txs, err := AsTransactionObjects(trytes, nil)
+		if err != nil {
+			out <- StreamedPoWTx{Err: err}
+			return
+		}
+
+		var prev Trytes
+		for i := 0; i < len(txs); i++ {
+			switch {
+			case i == 0:
+				txs[i].TrunkTransaction = trunkTx
+				txs[i].BranchTransaction = branchTx
+			default:
+				txs[i].TrunkTransaction = prev
+				txs[i].BranchTransaction = trunkTx
+			}
+
+			txs[i].AttachmentTimestamp = time.Now().UnixNano() / 1000000
+			txs[i].AttachmentTimestampLowerBound = LowerBoundAttachmentTimestamp
+			txs[i].AttachmentTimestampUpperBound = UpperBoundAttachmentTimestamp
+
+			txs[i].Nonce, err = pow(MustTransactionToTrytes(&txs[i]), int(mwm))
+			if err != nil {
+				out <- StreamedPoWTx{Index: i, Err: err}
+				return
+			}
+
+			// set new transaction hash
+			txs[i].Hash = TransactionHash(&txs[i])
+			prev = txs[i].Hash
+
+			out <- StreamedPoWTx{Index: i, Trytes: MustTransactionToTrytes(&txs[i])}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,47 @@
+package pow_test
+
+import (
+	"strings"
+
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("DoPoWStream", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+	trunk := strings.Repeat("9", 81)
+	branch := strings.Repeat("9", 81)
+
+	It("emits each attached transaction as soon as its nonce was found", func() {
+		var emitted []pow.StreamedPoWTx
+		for streamedTx := range pow.DoPoWStream(trunk, branch, []Trytes{rawTx, rawTx}, 9, pow.GoProofOfWork) {
+			emitted = append(emitted, streamedTx)
+		}
+		Expect(emitted).To(HaveLen(2))
+		for i, streamedTx := range emitted {
+			Expect(streamedTx.Err).ToNot(HaveOccurred())
+			Expect(streamedTx.Index).To(Equal(i))
+			hashedTrytes := curl.HashTrytes(streamedTx.Trytes)
+			Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+		}
+	})
+
+	It("emits the error of a failing Proof-of-Work func and stops", func() {
+		powErr := errors.New("pow failed")
+		failingPoW := func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+			return "", powErr
+		}
+		var emitted []pow.StreamedPoWTx
+		for streamedTx := range pow.DoPoWStream(trunk, branch, []Trytes{rawTx, rawTx}, 9, failingPoW) {
+			emitted = append(emitted, streamedTx)
+		}
+		Expect(emitted).To(HaveLen(1))
+		Expect(emitted[0].Err).To(Equal(powErr))
+	})
+})
@@ -0,0 +1,48 @@
+package pow
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	"github.com/pkg/errors"
+)
+
+// ErrInvalidTritsForProofOfWork gets returned when invalid transaction trits are supplied for PoW.
+var ErrInvalidTritsForProofOfWork = errors.New("invalid trits supplied to Proof-of-Work func")
+
+// GoProofOfWorkOnTrits is like GoProofOfWork() but operates directly on the given
+// transaction trits, skipping the trytes conversion round trips. Besides the found
+// nonce trits it also returns the hash trits of the attached transaction, so batch
+// attachers holding trits pay no conversion overhead per transaction.
+// The given transaction trits are not mutated.
+func GoProofOfWorkOnTrits(txTrits Trits, mwm int, parallelism ...int) (Trits, Trits, error) {
+	if len(txTrits) != TransactionTrinarySize {
+		return nil, nil, ErrInvalidTritsForProofOfWork
+	}
+	if err := ValidTrits(txTrits); err != nil {
+		return nil, nil, err
+	}
+
+	// absorb all blocks up to the last one and load the last block for the search
+	c := curl.NewCurl()
+	for i := 0; i < TransactionTrinarySize-HashTrinarySize; i += HashTrinarySize {
+		copy(c.State, txTrits[i:i+HashTrinarySize])
+		c.Transform()
+	}
+	copy(c.State, txTrits[TransactionTrinarySize-HashTrinarySize:])
+
+	nonce, err := goProofOfWorkTritsWithCurlState(c, mwm, nil, parallelism...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the mid-state still holds the last block without the nonce, so absorbing
+	// the found nonce into it directly yields the transaction hash
+	copy(c.State[HashTrinarySize-NonceTrinarySize:HashTrinarySize], nonce)
+	c.Transform()
+	hash := make(Trits, HashTrinarySize)
+	copy(hash, c.State[:HashTrinarySize])
+
+	return nonce, hash, nil
+}
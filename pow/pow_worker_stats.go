@@ -0,0 +1,85 @@
+package pow
+
+import (
+	"sync"
+	"sync/atomic"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/curl"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// WorkerStats describes the contribution of one search goroutine to a Proof-of-Work run.
+type WorkerStats struct {
+	// The index of the worker, which is also the initial incrN() offset of its nonce range.
+	Worker int
+	// The amount of hashes the worker evaluated.
+	HashesEvaluated int64
+	// Whether this worker found the nonce.
+	Winner bool
+}
+
+// GoProofOfWorkWithWorkerStats is like GoProofOfWork() but additionally returns
+// statistics about every search goroutine of the run: which nonce range offset it
+// was started on, how many hashes it evaluated and which worker won. This allows
+// tuning the parallelism on heterogeneous machines.
+func GoProofOfWorkWithWorkerStats(trytes Trytes, mwm int, parallelism ...int) (Trytes, []WorkerStats, error) {
+	if trytes == "" {
+		return "", nil, ErrInvalidTrytesForProofOfWork
+	}
+
+	c := curl.NewCurl()
+	c.Absorb(trytes[:(TransactionTrinarySize-HashTrinarySize)/3])
+	tr := MustTrytesToTrits(trytes)
+	copy(c.State, tr[TransactionTrinarySize-HashTrinarySize:])
+
+	var cancelled int32
+	numGoroutines := proofOfWorkParallelism(parallelism...)
+	stats := make([]WorkerStats, numGoroutines)
+
+	type workerNonce struct {
+		nonce  Trits
+		worker int
+	}
+	nonceChan := make(chan workerNonce, 1)
+
+	var wg sync.WaitGroup
+	wg.Add(numGoroutines)
+	for i := 0; i < numGoroutines; i++ {
+		go func(i int) {
+			defer wg.Done()
+			lmid, hmid := para(c.State)
+			lmid[nonceOffset] = low0
+			hmid[nonceOffset] = high0
+			lmid[nonceOffset+1] = low1
+			hmid[nonceOffset+1] = high1
+			lmid[nonceOffset+2] = low2
+			hmid[nonceOffset+2] = high2
+			lmid[nonceOffset+3] = low3
+			hmid[nonceOffset+3] = high3
+
+			incrN(i, lmid, hmid)
+			nonce, r := loop(lmid, hmid, mwm, &cancelled)
+
+			// every worker owns its slot, no synchronization needed
+			stats[i] = WorkerStats{Worker: i, HashesEvaluated: r}
+
+			if r >= 0 && len(nonce) > 0 {
+				select {
+				case nonceChan <- workerNonce{nonce: nonce, worker: i}:
+					atomic.StoreInt32(&cancelled, 1)
+				default:
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	select {
+	case result := <-nonceChan:
+		stats[result.worker].Winner = true
+		return MustTritsToTrytes(result.nonce), stats, nil
+	default:
+		return "", stats, ErrNonceNotFound
+	}
+}
@@ -0,0 +1,150 @@
+// Package powserver provides an embeddable, IRI compatible attachToTangle HTTP
+// server which serves the local Proof-of-Work implementations to light clients.
+// This way a single beefy machine can do the Proof-of-Work for a fleet of light
+// clients without running a full node.
+package powserver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// DefaultMaxMinWeightMagnitude is the default upper limit for the accepted minWeightMagnitude.
+const DefaultMaxMinWeightMagnitude = 14
+
+// DefaultMaxConcurrentRequests is the default amount of concurrently executed
+// attachToTangle requests. Further requests wait until a slot becomes free.
+const DefaultMaxConcurrentRequests = 1
+
+// Settings defines a set of settings for when constructing a new Server.
+type Settings struct {
+	// The Proof-of-Work implementation function. Defaults to the fastest available implementation.
+	PoWFunc pow.ProofOfWorkFunc
+	// The upper limit for the accepted minWeightMagnitude. Defaults to DefaultMaxMinWeightMagnitude.
+	MaxMinWeightMagnitude int
+	// The maximum amount of concurrently executed attachToTangle requests.
+	// Further requests wait until a slot becomes free. Defaults to DefaultMaxConcurrentRequests.
+	MaxConcurrentRequests int
+}
+
+// Server serves the configured Proof-of-Work implementation behind an IRI
+// compatible attachToTangle HTTP endpoint. It implements http.Handler and can
+// thereby be mounted into any HTTP server.
+type Server struct {
+	powFunc pow.ProofOfWorkFunc
+	maxMWM  int
+	sem     chan struct{}
+}
+
+// NewServer creates a new Server with the given settings.
+func NewServer(settings Settings) *Server {
+	powFunc := settings.PoWFunc
+	if powFunc == nil {
+		_, powFunc = pow.GetFastestProofOfWorkImpl()
+	}
+	maxMWM := settings.MaxMinWeightMagnitude
+	if maxMWM <= 0 {
+		maxMWM = DefaultMaxMinWeightMagnitude
+	}
+	maxConcurrent := settings.MaxConcurrentRequests
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentRequests
+	}
+	return &Server{
+		powFunc: powFunc,
+		maxMWM:  maxMWM,
+		sem:     make(chan struct{}, maxConcurrent),
+	}
+}
+
+type attachToTangleRequest struct {
+	Command            string   `json:"command"`
+	TrunkTransaction   Trytes   `json:"trunkTransaction"`
+	BranchTransaction  Trytes   `json:"branchTransaction"`
+	MinWeightMagnitude uint64   `json:"minWeightMagnitude"`
+	Trytes             []Trytes `json:"trytes"`
+}
+
+type attachToTangleResponse struct {
+	Trytes []Trytes `json:"trytes"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP handles one IRI API call, where attachToTangle is the only supported command.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "only POST requests are supported")
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cmd := &attachToTangleRequest{}
+	if err := json.Unmarshal(body, cmd); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if cmd.Command != "attachToTangle" {
+		writeError(w, http.StatusBadRequest, "command "+cmd.Command+" is not supported")
+		return
+	}
+
+	switch {
+	case int(cmd.MinWeightMagnitude) > s.maxMWM:
+		writeError(w, http.StatusBadRequest, "minWeightMagnitude exceeds the limit of this server")
+		return
+	case cmd.MinWeightMagnitude == 0:
+		writeError(w, http.StatusBadRequest, "invalid minWeightMagnitude")
+		return
+	case !guards.IsTransactionHash(cmd.TrunkTransaction):
+		writeError(w, http.StatusBadRequest, "invalid trunk transaction hash")
+		return
+	case !guards.IsTransactionHash(cmd.BranchTransaction):
+		writeError(w, http.StatusBadRequest, "invalid branch transaction hash")
+		return
+	case len(cmd.Trytes) == 0:
+		writeError(w, http.StatusBadRequest, "no transaction trytes supplied")
+		return
+	}
+	for _, txTrytes := range cmd.Trytes {
+		if !guards.IsTransactionTrytes(txTrytes) {
+			writeError(w, http.StatusBadRequest, "invalid transaction trytes")
+			return
+		}
+	}
+
+	// bound the amount of concurrently executed Proof-of-Work tasks
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+
+	powedTrytes, err := pow.DoPoW(cmd.TrunkTransaction, cmd.BranchTransaction, cmd.Trytes, cmd.MinWeightMagnitude, s.powFunc)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, attachToTangleResponse{Trytes: powedTrytes})
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
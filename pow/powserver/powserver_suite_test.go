@@ -0,0 +1,13 @@
+package powserver_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPowServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PoWServer Suite")
+}
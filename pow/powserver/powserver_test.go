@@ -0,0 +1,62 @@
+package powserver_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/guards"
+	"github.com/iotaledger/iota.go/pow"
+	"github.com/iotaledger/iota.go/pow/powserver"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	rawTx := "999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999A9RGRKVGWMWMKOLVMDFWJUHNUNYWZTJADGGPZGXNLERLXYWJE9WQHWWBMCPZMVVMJUMWWBLZLNMLDCGDJ999999999999999999999999999999999999999999999999999999YGYQIVD99999999999999999999TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999999"
+	trunk := strings.Repeat("9", 81)
+	branch := strings.Repeat("9", 81)
+
+	newTestServer := func(settings powserver.Settings) *httptest.Server {
+		return httptest.NewServer(powserver.NewServer(settings))
+	}
+
+	It("serves attachToTangle requests to the IRI API client", func() {
+		srv := newTestServer(powserver.Settings{PoWFunc: pow.GoProofOfWork})
+		defer srv.Close()
+
+		iotaAPI, err := api.ComposeAPI(api.HTTPClientSettings{URI: srv.URL})
+		Expect(err).ToNot(HaveOccurred())
+
+		powedTrytes, err := iotaAPI.AttachToTangle(trunk, branch, 9, []Trytes{rawTx})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(powedTrytes).To(HaveLen(1))
+		hashedTrytes := curl.HashTrytes(powedTrytes[0])
+		Expect(guards.IsTransactionHashWithMWM(hashedTrytes, 9)).To(BeTrue())
+	})
+
+	It("rejects requests exceeding the minWeightMagnitude limit", func() {
+		srv := newTestServer(powserver.Settings{PoWFunc: pow.GoProofOfWork, MaxMinWeightMagnitude: 9})
+		defer srv.Close()
+
+		req := `{"command":"attachToTangle","trunkTransaction":"` + trunk + `","branchTransaction":"` + branch + `","minWeightMagnitude":14,"trytes":["` + rawTx + `"]}`
+		res, err := http.Post(srv.URL, "application/json", bytes.NewBufferString(req))
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+
+	It("rejects unsupported commands", func() {
+		srv := newTestServer(powserver.Settings{PoWFunc: pow.GoProofOfWork})
+		defer srv.Close()
+
+		res, err := http.Post(srv.URL, "application/json", bytes.NewBufferString(`{"command":"getNodeInfo"}`))
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+		Expect(res.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
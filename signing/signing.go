@@ -5,12 +5,20 @@ package signing
 import (
 	. "github.com/iotaledger/iota.go/consts"
 	"github.com/iotaledger/iota.go/kerl"
+	"github.com/iotaledger/iota.go/sponge"
 	. "github.com/iotaledger/iota.go/trinary"
 	"math"
 )
 
+// returns the optionally given sponge function or a new Kerl, the default
+// hash function of the mainnet.
+func getSpongeFunc(spongeFunc []sponge.SpongeFunction) sponge.SpongeFunction {
+	return sponge.GetSpongeFunc(spongeFunc, kerl.NewKerl())
+}
+
 // Subseed takes a seed and an index and returns the given subseed.
-func Subseed(seed Trytes, index uint64) (Trits, error) {
+// The optional sponge function defaults to Kerl.
+func Subseed(seed Trytes, index uint64, spongeFunc ...sponge.SpongeFunction) (Trits, error) {
 	if err := ValidTrytes(seed); err != nil {
 		return nil, err
 	} else if len(seed) != HashTrinarySize/TrinaryRadix {
@@ -19,12 +27,12 @@ func Subseed(seed Trytes, index uint64) (Trits, error) {
 
 	incrementedSeed := AddTrits(MustTrytesToTrits(seed), IntToTrits(int64(index)))
 
-	k := kerl.NewKerl()
-	err := k.Absorb(incrementedSeed)
-	if err != nil {
+	h := getSpongeFunc(spongeFunc)
+	h.Reset()
+	if err := h.AbsorbTrits(incrementedSeed); err != nil {
 		return nil, err
 	}
-	subseed, err := k.Squeeze(HashTrinarySize)
+	subseed, err := h.SqueezeTrits(HashTrinarySize)
 	if err != nil {
 		return nil, err
 	}
@@ -32,9 +40,11 @@ func Subseed(seed Trytes, index uint64) (Trits, error) {
 }
 
 // Key computes a new private key from the given subseed using the given security level.
-func Key(subseed Trits, securityLevel SecurityLevel) (Trits, error) {
-	k := kerl.NewKerl()
-	if err := k.Absorb(subseed); err != nil {
+// The optional sponge function defaults to Kerl.
+func Key(subseed Trits, securityLevel SecurityLevel, spongeFunc ...sponge.SpongeFunction) (Trits, error) {
+	h := getSpongeFunc(spongeFunc)
+	h.Reset()
+	if err := h.AbsorbTrits(subseed); err != nil {
 		return nil, err
 	}
 
@@ -42,7 +52,7 @@ func Key(subseed Trits, securityLevel SecurityLevel) (Trits, error) {
 
 	for i := 0; i < int(securityLevel); i++ {
 		for j := 0; j < KeySegmentsPerFragment; j++ {
-			b, err := k.Squeeze(HashTrinarySize)
+			b, err := h.SqueezeTrits(HashTrinarySize)
 			if err != nil {
 				return nil, err
 			}
@@ -54,8 +64,10 @@ func Key(subseed Trits, securityLevel SecurityLevel) (Trits, error) {
 }
 
 // Digests hashes each segment of each key fragment 26 times and returns them.
-func Digests(key Trits) (Trits, error) {
+// The optional sponge function defaults to Kerl.
+func Digests(key Trits, spongeFunc ...sponge.SpongeFunction) (Trits, error) {
 	var err error
+	h := getSpongeFunc(spongeFunc)
 	fragments := int(math.Floor(float64(len(key)) / KeyFragmentLength))
 	digests := make(Trits, fragments*HashTrinarySize)
 	buf := make(Trits, HashTrinarySize)
@@ -70,9 +82,11 @@ func Digests(key Trits) (Trits, error) {
 
 			// hash each segment 26 times
 			for k := 0; k < KeySegmentHashRounds; k++ {
-				k := kerl.NewKerl()
-				k.Absorb(buf)
-				buf, err = k.Squeeze(HashTrinarySize)
+				h.Reset()
+				if err := h.AbsorbTrits(buf); err != nil {
+					return nil, err
+				}
+				buf, err = h.SqueezeTrits(HashTrinarySize)
 				if err != nil {
 					return nil, err
 				}
@@ -84,12 +98,12 @@ func Digests(key Trits) (Trits, error) {
 		}
 
 		// hash the key fragment (which now consists of hashed segments)
-		k := kerl.NewKerl()
-		if err := k.Absorb(keyFragment); err != nil {
+		h.Reset()
+		if err := h.AbsorbTrits(keyFragment); err != nil {
 			return nil, err
 		}
 
-		buf, err := k.Squeeze(HashTrinarySize)
+		buf, err := h.SqueezeTrits(HashTrinarySize)
 		if err != nil {
 			return nil, err
 		}
@@ -102,12 +116,14 @@ func Digests(key Trits) (Trits, error) {
 }
 
 // Address generates the address trits from the given digests.
-func Address(digests Trits) (Trits, error) {
-	k := kerl.NewKerl()
-	if err := k.Absorb(digests); err != nil {
+// The optional sponge function defaults to Kerl.
+func Address(digests Trits, spongeFunc ...sponge.SpongeFunction) (Trits, error) {
+	h := getSpongeFunc(spongeFunc)
+	h.Reset()
+	if err := h.AbsorbTrits(digests); err != nil {
 		return nil, err
 	}
-	return k.Squeeze(HashTrinarySize)
+	return h.SqueezeTrits(HashTrinarySize)
 }
 
 // NormalizedBundleHash normalizes the given bundle hash, with resulting digits summing to zero.
@@ -145,23 +161,24 @@ func NormalizedBundleHash(bundleHash Hash) []int8 {
 }
 
 // SignatureFragment returns signed fragments using the given bundle hash and key fragment.
-func SignatureFragment(normalizedBundleHashFragment Trits, keyFragment Trits) (Trits, error) {
+// The optional sponge function defaults to Kerl.
+func SignatureFragment(normalizedBundleHashFragment Trits, keyFragment Trits, spongeFunc ...sponge.SpongeFunction) (Trits, error) {
 	sigFrag := make(Trits, len(keyFragment))
 	copy(sigFrag, keyFragment)
 
-	k := kerl.NewKerl()
+	h := getSpongeFunc(spongeFunc)
 
 	for i := 0; i < KeySegmentsPerFragment; i++ {
 		hash := sigFrag[i*HashTrinarySize : (i+1)*HashTrinarySize]
 
 		to := MaxTryteValue - normalizedBundleHashFragment[i]
 		for j := 0; j < int(to); j++ {
-			k.Reset()
-			if err := k.Absorb(hash); err != nil {
+			h.Reset()
+			if err := h.AbsorbTrits(hash); err != nil {
 				return nil, err
 			}
 			var err error
-			hash, err = k.Squeeze(HashTrinarySize)
+			hash, err = h.SqueezeTrits(HashTrinarySize)
 			if err != nil {
 				return nil, err
 			}
@@ -176,36 +193,40 @@ func SignatureFragment(normalizedBundleHashFragment Trits, keyFragment Trits) (T
 }
 
 // Digest computes the digest derived from the signature fragment and normalized bundle hash.
-func Digest(normalizedBundleHashFragment []int8, signatureFragment Trits) (Trits, error) {
-	k := kerl.NewKerl()
+// The optional sponge function defaults to Kerl.
+func Digest(normalizedBundleHashFragment []int8, signatureFragment Trits, spongeFunc ...sponge.SpongeFunction) (Trits, error) {
+	h := getSpongeFunc(spongeFunc)
+	h.Reset()
+	// a second instance of the same hash function for the segment rounds
+	inner := h.Clone()
 	buf := make(Trits, HashTrinarySize)
 
 	for i := 0; i < KeySegmentsPerFragment; i++ {
 		copy(buf, signatureFragment[i*HashTrinarySize:(i+1)*HashTrinarySize])
 
 		for j := normalizedBundleHashFragment[i] + MaxTryteValue; j > 0; j-- {
-			kk := kerl.NewKerl()
-			err := kk.Absorb(buf)
-			if err != nil {
+			inner.Reset()
+			if err := inner.AbsorbTrits(buf); err != nil {
 				return nil, err
 			}
-			buf, err = kk.Squeeze(HashTrinarySize)
+			var err error
+			buf, err = inner.SqueezeTrits(HashTrinarySize)
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		if err := k.Absorb(buf); err != nil {
+		if err := h.AbsorbTrits(buf); err != nil {
 			return nil, err
 		}
 	}
 
-	return k.Squeeze(HashTrinarySize)
+	return h.SqueezeTrits(HashTrinarySize)
 }
 
 // ValidateSignatures validates the given signature fragments by checking whether the
 // digests computed from the bundle hash and fragments equal the passed address.
-func ValidateSignatures(expectedAddress Hash, fragments []Trytes, bundleHash Hash) (bool, error) {
+func ValidateSignatures(expectedAddress Hash, fragments []Trytes, bundleHash Hash, spongeFunc ...sponge.SpongeFunction) (bool, error) {
 	normalizedBundleHashFragments := make([][]int8, MaxSecurityLevel)
 	normalizeBundleHash := NormalizedBundleHash(bundleHash)
 
@@ -215,7 +236,7 @@ func ValidateSignatures(expectedAddress Hash, fragments []Trytes, bundleHash Has
 
 	digests := make(Trits, len(fragments)*HashTrinarySize)
 	for i := 0; i < len(fragments); i++ {
-		digest, err := Digest(normalizedBundleHashFragments[i%MaxSecurityLevel], MustTrytesToTrits(fragments[i]))
+		digest, err := Digest(normalizedBundleHashFragments[i%MaxSecurityLevel], MustTrytesToTrits(fragments[i]), spongeFunc...)
 		if err != nil {
 			return false, err
 		}
@@ -224,7 +245,7 @@ func ValidateSignatures(expectedAddress Hash, fragments []Trytes, bundleHash Has
 		}
 	}
 
-	addressTrits, err := Address(digests)
+	addressTrits, err := Address(digests, spongeFunc...)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,38 @@
+// Package sponge provides the interface shared by the trinary sponge
+// constructions used in IOTA, such as Curl and Kerl.
+//
+// Troika is descoped until the official round constant tables and reference
+// test vectors can be vendored verbatim; see the "Supported hash functions"
+// section of the README for the rationale. Once those tables are imported,
+// Troika can be added as another SpongeFunction without changes to this
+// package.
+package sponge
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// SpongeFunction is a hash function using the sponge construction. It is
+// implemented by Curl and Kerl, so code deriving keys, digests, addresses or
+// bundle hashes can swap the hash function without forking those packages.
+type SpongeFunction interface {
+	// AbsorbTrits fills the internal state of the sponge with the given trits.
+	AbsorbTrits(in Trits) error
+	// SqueezeTrits squeezes out trits of the given length, which must be a
+	// multiple of HashTrinarySize.
+	SqueezeTrits(length int) (Trits, error)
+	// Reset the internal state of the sponge.
+	Reset()
+	// Clone returns a deep copy of the sponge including its internal state.
+	Clone() SpongeFunction
+}
+
+// GetSpongeFunc returns the first of the optionally given sponge functions
+// or the given default. It unwraps the trailing variadic sponge parameter
+// convention used across the library.
+func GetSpongeFunc(spongeFunc []SpongeFunction, defaultSponge SpongeFunction) SpongeFunction {
+	if len(spongeFunc) > 0 && spongeFunc[0] != nil {
+		return spongeFunc[0]
+	}
+	return defaultSponge
+}
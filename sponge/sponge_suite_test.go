@@ -0,0 +1,13 @@
+package sponge_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSponge(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sponge Suite")
+}
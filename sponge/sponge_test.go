@@ -0,0 +1,80 @@
+package sponge_test
+
+import (
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/kerl"
+	. "github.com/iotaledger/iota.go/sponge"
+	. "github.com/iotaledger/iota.go/trinary"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sponge", func() {
+
+	trits := MustTrytesToTrits("TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK")
+
+	implementations := map[string]func() SpongeFunction{
+		"Curl": func() SpongeFunction { return curl.NewCurl() },
+		"Kerl": func() SpongeFunction { return kerl.NewKerl() },
+	}
+
+	for name, newSponge := range implementations {
+		name := name
+		newSponge := newSponge
+
+		Context(name, func() {
+			It("should absorb and squeeze deterministically", func() {
+				a := newSponge()
+				Expect(a.AbsorbTrits(trits)).To(Succeed())
+				hashA, err := a.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+
+				b := newSponge()
+				Expect(b.AbsorbTrits(trits)).To(Succeed())
+				hashB, err := b.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hashA).To(Equal(hashB))
+			})
+
+			It("should return an error for an invalid squeeze length", func() {
+				_, err := newSponge().SqueezeTrits(100)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should fork the state on Clone()", func() {
+				a := newSponge()
+				Expect(a.AbsorbTrits(trits)).To(Succeed())
+				b := a.Clone()
+
+				hashA, err := a.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+				hashB, err := b.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hashA).To(Equal(hashB))
+
+				// the clone must not share state with the original
+				Expect(b.AbsorbTrits(trits)).To(Succeed())
+				hashA2, err := a.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+				hashB2, err := b.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hashA2).ToNot(Equal(hashB2))
+			})
+
+			It("should hash like before after a Reset()", func() {
+				a := newSponge()
+				Expect(a.AbsorbTrits(trits)).To(Succeed())
+				hashA, err := a.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+
+				a.Reset()
+				Expect(a.AbsorbTrits(trits)).To(Succeed())
+				hashB, err := a.SqueezeTrits(HashTrinarySize)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(hashA).To(Equal(hashB))
+			})
+		})
+	}
+})
@@ -0,0 +1,235 @@
+// Package tanglefile provides storing and retrieving files on the Tangle by
+// splitting them into a chain of zero-value bundles.
+package tanglefile
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/bundle"
+	"github.com/iotaledger/iota.go/codec"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/converter"
+	"github.com/iotaledger/iota.go/curl"
+	"github.com/iotaledger/iota.go/transaction"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+)
+
+// SchemaVersion is the schema version of the chunk encoding.
+const SchemaVersion = 1
+
+// DefaultChunkSize is the amount of file bytes per bundle used when no
+// explicit size is given.
+const DefaultChunkSize = 1024
+
+var (
+	// ErrFileNotFound gets returned when no chunks of a file could be found.
+	ErrFileNotFound = errors.New("file not found")
+	// ErrFileIncomplete gets returned when chunks of a file are missing.
+	ErrFileIncomplete = errors.New("file incomplete")
+	// ErrChecksumMismatch gets returned when the reassembled file does not
+	// match its checksum.
+	ErrChecksumMismatch = errors.New("file does not match its checksum")
+)
+
+// File describes a file stored on the Tangle.
+type File struct {
+	// The name of the file.
+	Name string `json:"name"`
+	// The size of the file in bytes.
+	Size int `json:"size"`
+	// The hex encoded SHA-256 checksum of the file's content. It identifies
+	// the file and determines the address its chunks live on.
+	Checksum string `json:"checksum"`
+}
+
+// chunk is one piece of a file, stored as one bundle.
+type chunk struct {
+	File  File   `json:"file"`
+	Index int    `json:"index"`
+	Total int    `json:"total"`
+	Data  string `json:"data"`
+}
+
+// Address returns the address on which the chunks of the file with the
+// given checksum are stored.
+func Address(checksum string) (Hash, error) {
+	checksumTrytes, err := converter.ASCIIToTrytes(checksum)
+	if err != nil {
+		return "", err
+	}
+	return curl.HashTrytes(checksumTrytes), nil
+}
+
+// Upload is a file split into chunks, ready to be attached to the Tangle.
+type Upload struct {
+	file   File
+	chunks []Trytes
+}
+
+// NewUpload reads the given reader and splits its content into chunks of the
+// given size. A chunkSize of 0 defaults to DefaultChunkSize.
+func NewUpload(r io.Reader, name string, chunkSize int) (*Upload, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(data)
+	file := File{
+		Name:     name,
+		Size:     len(data),
+		Checksum: hex.EncodeToString(checksum[:]),
+	}
+
+	total := (len(data) + chunkSize - 1) / chunkSize
+	if total == 0 {
+		total = 1
+	}
+	chunks := make([]Trytes, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		msg, err := codec.Marshal(chunk{
+			File:  file,
+			Index: i,
+			Total: total,
+			Data:  base64.StdEncoding.EncodeToString(data[i*chunkSize : end]),
+		}, SchemaVersion)
+		if err != nil {
+			return nil, err
+		}
+		chunks[i] = msg
+	}
+	return &Upload{file: file, chunks: chunks}, nil
+}
+
+// File returns the file descriptor of the upload.
+func (u *Upload) File() File {
+	return u.file
+}
+
+// Attach attaches the file's chunks to the Tangle, one zero-value bundle per
+// chunk. Chunks already present on the file's address are skipped, so an
+// interrupted upload can be resumed by simply calling Attach again.
+func (u *Upload) Attach(a *api.API, depth uint64, mwm uint64) error {
+	addr, err := Address(u.file.Checksum)
+	if err != nil {
+		return err
+	}
+	existing, err := fetchChunks(a, addr)
+	if err != nil {
+		return err
+	}
+	for i, msg := range u.chunks {
+		if _, ok := existing[i]; ok {
+			continue
+		}
+		transfers := bundle.Transfers{{
+			Address: addr,
+			Value:   0,
+			Message: msg,
+			Tag:     "TANGLEFILE",
+		}}
+		trytes, err := a.PrepareTransfers(NullHashTrytes, transfers, api.PrepareTransfersOptions{})
+		if err != nil {
+			return err
+		}
+		if _, err := a.SendTrytes(trytes, depth, mwm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Download locates the file with the given checksum on the Tangle and
+// reassembles and verifies its content.
+func Download(a *api.API, checksum string) (*File, []byte, error) {
+	addr, err := Address(checksum)
+	if err != nil {
+		return nil, nil, err
+	}
+	chunks, err := fetchChunks(a, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(chunks) == 0 {
+		return nil, nil, ErrFileNotFound
+	}
+
+	var file File
+	var buf bytes.Buffer
+	total := -1
+	for _, c := range chunks {
+		total = c.Total
+		file = c.File
+		break
+	}
+	for i := 0; i < total; i++ {
+		c, ok := chunks[i]
+		if !ok {
+			return nil, nil, errors.Wrapf(ErrFileIncomplete, "chunk %d of %d missing", i, total)
+		}
+		data, err := base64.StdEncoding.DecodeString(c.Data)
+		if err != nil {
+			return nil, nil, err
+		}
+		buf.Write(data)
+	}
+
+	actual := sha256.Sum256(buf.Bytes())
+	if hex.EncodeToString(actual[:]) != checksum {
+		return nil, nil, ErrChecksumMismatch
+	}
+	return &file, buf.Bytes(), nil
+}
+
+// fetchChunks fetches all chunks stored on the given address, keyed by their
+// chunk index. Bundles which do not decode to a chunk are skipped.
+func fetchChunks(a *api.API, addr Hash) (map[int]*chunk, error) {
+	txs, err := a.FindTransactionObjects(api.FindTransactionsQuery{Addresses: Hashes{addr}})
+	if err != nil {
+		return nil, err
+	}
+	chunks := map[int]*chunk{}
+	for _, msg := range bundleMessages(txs) {
+		c := &chunk{}
+		if err := codec.UnmarshalExpect(msg, c, SchemaVersion); err != nil {
+			continue
+		}
+		chunks[c.Index] = c
+	}
+	return chunks, nil
+}
+
+// bundleMessages reassembles the message trytes carried by each bundle
+// within the given transactions.
+func bundleMessages(txs transaction.Transactions) []Trytes {
+	byBundle := map[string]transaction.Transactions{}
+	for i := range txs {
+		byBundle[txs[i].Bundle] = append(byBundle[txs[i].Bundle], txs[i])
+	}
+	msgs := make([]Trytes, 0, len(byBundle))
+	for _, bundleTxs := range byBundle {
+		sort.Slice(bundleTxs, func(i, j int) bool {
+			return bundleTxs[i].CurrentIndex < bundleTxs[j].CurrentIndex
+		})
+		var msg Trytes
+		for i := range bundleTxs {
+			msg += bundleTxs[i].SignatureMessageFragment
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs
+}
@@ -0,0 +1,13 @@
+package tanglefile_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTanglefile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tanglefile Suite")
+}
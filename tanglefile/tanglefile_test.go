@@ -0,0 +1,43 @@
+package tanglefile_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	. "github.com/iotaledger/iota.go/guards"
+	. "github.com/iotaledger/iota.go/tanglefile"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tanglefile", func() {
+
+	content := bytes.Repeat([]byte("some file content "), 150)
+
+	Context("NewUpload()", func() {
+		It("computes the file descriptor and splits the content", func() {
+			upload, err := NewUpload(bytes.NewReader(content), "sensor.log", 1024)
+			Expect(err).ToNot(HaveOccurred())
+			checksum := sha256.Sum256(content)
+			Expect(upload.File()).To(Equal(File{
+				Name:     "sensor.log",
+				Size:     len(content),
+				Checksum: hex.EncodeToString(checksum[:]),
+			}))
+		})
+	})
+
+	Context("Address()", func() {
+		It("derives a deterministic trytes address from the checksum", func() {
+			upload, err := NewUpload(bytes.NewReader(content), "sensor.log", 0)
+			Expect(err).ToNot(HaveOccurred())
+			addr, err := Address(upload.File().Checksum)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(IsHash(addr)).To(BeTrue())
+			again, err := Address(upload.File().Checksum)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again).To(Equal(addr))
+		})
+	})
+})
@@ -0,0 +1,70 @@
+// Package tracing provides optional span creation around API commands, PoW
+// searches and account operations. The Tracer and Span interfaces mirror the
+// OpenTelemetry tracer model, so hooking the library into an OpenTelemetry
+// SDK is a few lines of adapter code, while the default no-op tracer keeps
+// the hot paths free of any overhead.
+package tracing
+
+import (
+	"context"
+)
+
+// Attribute is a key/value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String creates a string attribute.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 creates an integer attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool creates a boolean attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a single traced operation.
+type Span interface {
+	// SetAttributes adds the given attributes to the span.
+	SetAttributes(attrs ...Attribute)
+	// RecordError records the given error on the span.
+	RecordError(err error)
+	// End finishes the span.
+	End()
+}
+
+// Tracer creates spans.
+type Tracer interface {
+	// StartSpan starts a span with the given name and attributes and returns
+	// a context carrying it.
+	StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// NoopTracer is a Tracer which does nothing.
+type NoopTracer struct{}
+
+// StartSpan implements Tracer.
+func (NoopTracer) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
+
+// OrNoop returns the given tracer or a no-op tracer if it is nil.
+func OrNoop(tracer Tracer) Tracer {
+	if tracer == nil {
+		return NoopTracer{}
+	}
+	return tracer
+}
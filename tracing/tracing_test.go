@@ -0,0 +1,110 @@
+package tracing_test
+
+import (
+	"context"
+
+	"github.com/iotaledger/iota.go/api"
+	. "github.com/iotaledger/iota.go/tracing"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+// recordingTracer records all started spans for inspection.
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name  string
+	attrs []Attribute
+	errs  []error
+	ended bool
+}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordedSpan{name: name, attrs: attrs}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) { s.attrs = append(s.attrs, attrs...) }
+func (s *recordedSpan) RecordError(err error)            { s.errs = append(s.errs, err) }
+func (s *recordedSpan) End()                             { s.ended = true }
+
+// fakeProvider answers every Send with the configured error.
+type fakeProvider struct {
+	err error
+}
+
+func (p *fakeProvider) Send(cmd interface{}, out interface{}) error { return p.err }
+func (p *fakeProvider) SetSettings(settings interface{}) error      { return nil }
+
+func attrValue(attrs []Attribute, key string) interface{} {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Tracing", func() {
+
+	Context("WrapProvider()", func() {
+		It("names spans after the sent command", func() {
+			tracer := &recordingTracer{}
+			provider := WrapProvider(&fakeProvider{}, tracer, String("node.endpoint", "http://localhost:14265"))
+			cmd := &api.GetNodeInfoCommand{Command: api.GetNodeInfoCmd}
+			err := provider.Send(cmd, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tracer.spans).To(HaveLen(1))
+			Expect(tracer.spans[0].name).To(Equal("iri.getNodeInfo"))
+			Expect(attrValue(tracer.spans[0].attrs, "node.endpoint")).To(Equal("http://localhost:14265"))
+			Expect(tracer.spans[0].ended).To(BeTrue())
+		})
+
+		It("records errors on the span", func() {
+			tracer := &recordingTracer{}
+			sendErr := errors.New("connection refused")
+			provider := WrapProvider(&fakeProvider{err: sendErr}, tracer)
+			err := provider.Send(&api.GetNodeInfoCommand{Command: api.GetNodeInfoCmd}, nil)
+			Expect(err).To(Equal(sendErr))
+			Expect(tracer.spans[0].errs).To(ContainElement(sendErr))
+		})
+
+		It("falls back to a no-op tracer when given nil", func() {
+			provider := WrapProvider(&fakeProvider{}, nil)
+			Expect(provider.Send(&api.GetNodeInfoCommand{Command: api.GetNodeInfoCmd}, nil)).To(Succeed())
+		})
+	})
+
+	Context("WrapProofOfWork()", func() {
+		It("annotates the span with the implementation name and MWM", func() {
+			tracer := &recordingTracer{}
+			powFunc := WrapProofOfWork("fake", func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+				return "NONCE", nil
+			}, tracer)
+			nonce, err := powFunc("TRYTES", 14)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nonce).To(Equal(Trytes("NONCE")))
+			Expect(tracer.spans).To(HaveLen(1))
+			Expect(tracer.spans[0].name).To(Equal("pow.search"))
+			Expect(attrValue(tracer.spans[0].attrs, "pow.impl")).To(Equal("fake"))
+			Expect(attrValue(tracer.spans[0].attrs, "pow.mwm")).To(Equal(int64(14)))
+			Expect(tracer.spans[0].ended).To(BeTrue())
+		})
+
+		It("records errors on the span", func() {
+			tracer := &recordingTracer{}
+			powErr := errors.New("pow failed")
+			powFunc := WrapProofOfWork("fake", func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+				return "", powErr
+			}, tracer)
+			_, err := powFunc("TRYTES", 14)
+			Expect(err).To(Equal(powErr))
+			Expect(tracer.spans[0].errs).To(ContainElement(powErr))
+		})
+	})
+})
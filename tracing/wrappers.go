@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/iotaledger/iota.go/api"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// WrapProvider wraps the given provider so that every sent API command is
+// traced as a span named "iri.<command>", annotated with the given static
+// attributes (e.g. the node endpoint).
+func WrapProvider(provider api.Provider, tracer Tracer, attrs ...Attribute) api.Provider {
+	return &tracedProvider{provider: provider, tracer: OrNoop(tracer), attrs: attrs}
+}
+
+type tracedProvider struct {
+	provider api.Provider
+	tracer   Tracer
+	attrs    []Attribute
+}
+
+func (p *tracedProvider) Send(cmd interface{}, out interface{}) error {
+	_, span := p.tracer.StartSpan(context.Background(), "iri."+commandName(cmd), p.attrs...)
+	defer span.End()
+	err := p.provider.Send(cmd, out)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+func (p *tracedProvider) SetSettings(settings interface{}) error {
+	return p.provider.SetSettings(settings)
+}
+
+// commandName extracts the command name from the given command payload.
+func commandName(cmd interface{}) string {
+	value := reflect.Indirect(reflect.ValueOf(cmd))
+	if value.Kind() != reflect.Struct {
+		return "unknown"
+	}
+	field := value.FieldByName("Command")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return "unknown"
+	}
+	return field.String()
+}
+
+// WrapProofOfWork wraps the given Proof-of-Work function so that every nonce
+// search is traced as a "pow.search" span annotated with the implementation
+// name and the used MWM.
+func WrapProofOfWork(name string, powFunc pow.ProofOfWorkFunc, tracer Tracer, attrs ...Attribute) pow.ProofOfWorkFunc {
+	tracer = OrNoop(tracer)
+	return func(trytes Trytes, mwm int, parallelism ...int) (Trytes, error) {
+		spanAttrs := append([]Attribute{
+			String("pow.impl", name),
+			Int64("pow.mwm", int64(mwm)),
+		}, attrs...)
+		_, span := tracer.StartSpan(context.Background(), "pow.search", spanAttrs...)
+		defer span.End()
+		nonce, err := powFunc(trytes, mwm, parallelism...)
+		if err != nil {
+			span.RecordError(err)
+		}
+		return nonce, err
+	}
+}
@@ -0,0 +1,126 @@
+package trinary
+
+import (
+	"io"
+	"strings"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// TritsPerByteB1T6 is the amount of trits a single byte is encoded to in b1t6.
+const TritsPerByteB1T6 = 6
+
+// EncodedB1T6Len returns the amount of trits the b1t6 encoding of n bytes takes up.
+func EncodedB1T6Len(n int) int {
+	return n * TritsPerByteB1T6
+}
+
+// DecodedB1T6Len returns the amount of bytes the b1t6 decoding of n trits yields.
+func DecodedB1T6Len(n int) int {
+	return n / TritsPerByteB1T6
+}
+
+// EncodeB1T6 encodes the given bytes with the b1t6 encoding, where each byte is
+// interpreted as a signed two's complement value and encoded into 6 trits, i.e. 2
+// trytes. This encoding is used by Chrysalis-era IOTA protocols.
+func EncodeB1T6(bytes []byte) Trits {
+	trits := make(Trits, EncodedB1T6Len(len(bytes)))
+	for i, b := range bytes {
+		t1, t2 := encodeB1T6Group(b)
+		copy(trits[i*TritsPerByteB1T6:], TryteToTritsLUT[tryteValueToIndex(t1)])
+		copy(trits[i*TritsPerByteB1T6+3:], TryteToTritsLUT[tryteValueToIndex(t2)])
+	}
+	return trits
+}
+
+// DecodeB1T6 decodes the given b1t6 encoded trits back to bytes. It returns an
+// error if the length of the trits is not a multiple of 6 or if a group of 6
+// trits encodes a value outside of the byte range.
+func DecodeB1T6(trits Trits) ([]byte, error) {
+	if len(trits)%TritsPerByteB1T6 != 0 {
+		return nil, errors.Wrapf(ErrInvalidTritsLength, "must be a multiple of %d", TritsPerByteB1T6)
+	}
+
+	bytes := make([]byte, DecodedB1T6Len(len(trits)))
+	for i := range bytes {
+		group := trits[i*TritsPerByteB1T6 : i*TritsPerByteB1T6+TritsPerByteB1T6]
+		v := int(TritsToInt(group))
+		if v < -128 || v > 127 {
+			return nil, errors.Wrapf(ErrInvalidTrit, "b1t6 group at index %d encodes the non byte value %d", i, v)
+		}
+		bytes[i] = byte(int8(v))
+	}
+	return bytes, nil
+}
+
+// B1T6Encoder is an io.Writer which b1t6 encodes the written bytes and writes the
+// resulting trytes to the underlying writer.
+type B1T6Encoder struct {
+	w io.Writer
+}
+
+// NewB1T6Encoder creates a new streaming b1t6 encoder writing trytes to w.
+func NewB1T6Encoder(w io.Writer) *B1T6Encoder {
+	return &B1T6Encoder{w: w}
+}
+
+// Write b1t6 encodes p and writes the resulting trytes to the underlying writer.
+func (e *B1T6Encoder) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(e.w, MustTritsToTrytes(EncodeB1T6(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// B1T6Decoder is an io.Writer which b1t6 decodes the written tryte characters and
+// writes the resulting bytes to the underlying writer. As a byte is encoded by 2
+// trytes, a trailing unpaired tryte is buffered until the next write.
+type B1T6Decoder struct {
+	w          io.Writer
+	pending    byte
+	hasPending bool
+}
+
+// NewB1T6Decoder creates a new streaming b1t6 decoder writing bytes to w.
+func NewB1T6Decoder(w io.Writer) *B1T6Decoder {
+	return &B1T6Decoder{w: w}
+}
+
+// Write b1t6 decodes the tryte characters in p and writes the resulting bytes to
+// the underlying writer.
+func (d *B1T6Decoder) Write(p []byte) (int, error) {
+	for n, c := range p {
+		if strings.IndexByte(TryteAlphabet, c) == -1 {
+			return n, errors.Wrapf(ErrInvalidTrytes, "%q", c)
+		}
+		if !d.hasPending {
+			d.pending = c
+			d.hasPending = true
+			continue
+		}
+		bytes, err := DecodeB1T6(MustTrytesToTrits(string([]byte{d.pending, c})))
+		if err != nil {
+			return n, err
+		}
+		d.hasPending = false
+		if _, err := d.w.Write(bytes); err != nil {
+			return n, err
+		}
+	}
+	return len(p), nil
+}
+
+// encodeB1T6Group encodes a single byte into its two b1t6 tryte values.
+func encodeB1T6Group(b byte) (int8, int8) {
+	v := int(int8(b)) + 13 + 27*13
+	return int8(v%27) - 13, int8(v/27) - 13
+}
+
+// tryteValueToIndex converts a balanced tryte value in [-13, 13] to its index in the tryte alphabet.
+func tryteValueToIndex(v int8) int8 {
+	if v < 0 {
+		return v + 27
+	}
+	return v
+}
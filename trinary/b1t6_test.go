@@ -0,0 +1,73 @@
+package trinary_test
+
+import (
+	"bytes"
+
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("B1T6", func() {
+
+	Context("EncodeB1T6()", func() {
+		It("should encode bytes to the expected trytes", func() {
+			Expect(MustTritsToTrytes(EncodeB1T6([]byte{0x00}))).To(Equal("99"))
+			Expect(MustTritsToTrytes(EncodeB1T6([]byte{0x01}))).To(Equal("A9"))
+			Expect(MustTritsToTrytes(EncodeB1T6([]byte{0xFF}))).To(Equal("Z9"))
+			Expect(MustTritsToTrytes(EncodeB1T6([]byte{0x00, 0x01}))).To(Equal("99A9"))
+		})
+	})
+
+	Context("DecodeB1T6()", func() {
+		It("should round trip all byte values", func() {
+			src := make([]byte, 256)
+			for i := range src {
+				src[i] = byte(i)
+			}
+			decoded, err := DecodeB1T6(EncodeB1T6(src))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decoded).To(Equal(src))
+		})
+
+		It("should return an error for a trits length which is not a multiple of 6", func() {
+			_, err := DecodeB1T6(Trits{1, 0, 0})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for a group outside of the byte range", func() {
+			// 13 + 27*13 = 364 is out of the int8 range
+			_, err := DecodeB1T6(MustTrytesToTrits("MM"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("B1T6Encoder", func() {
+		It("should write the encoded trytes to the underlying writer", func() {
+			var buf bytes.Buffer
+			enc := NewB1T6Encoder(&buf)
+			n, err := enc.Write([]byte{0x00, 0x01})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(2))
+			Expect(buf.String()).To(Equal("99A9"))
+		})
+	})
+
+	Context("B1T6Decoder", func() {
+		It("should decode tryte chunks of odd length across writes", func() {
+			var buf bytes.Buffer
+			dec := NewB1T6Decoder(&buf)
+			_, err := dec.Write([]byte("99A"))
+			Expect(err).ToNot(HaveOccurred())
+			_, err = dec.Write([]byte("9"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf.Bytes()).To(Equal([]byte{0x00, 0x01}))
+		})
+
+		It("should return an error for an invalid tryte character", func() {
+			dec := NewB1T6Decoder(&bytes.Buffer{})
+			_, err := dec.Write([]byte("a9"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
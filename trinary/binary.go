@@ -0,0 +1,117 @@
+package trinary
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+const (
+	// tritsPerBitmaskWord is the amount of trits held by one word of a bitmask.
+	tritsPerBitmaskWord = 64
+
+	allBits uint64 = 0xFFFFFFFFFFFFFFFF
+)
+
+// LowHighPairs converts trits to the low/high word encoding used by the
+// bit-sliced Curl transform of the PoW code: each trit becomes one pair of
+// words with all bits broadcast, so 64 copies of the same state can be
+// processed in parallel. A 0 trit maps to (~0, ~0), 1 to (0, ~0) and -1 to
+// (~0, 0). Invalid trits map to the invalid pair (0, 0), which
+// LowHighPairsToTrits() rejects.
+func LowHighPairs(trits Trits) ([]uint64, []uint64) {
+	low := make([]uint64, len(trits))
+	high := make([]uint64, len(trits))
+	for i, trit := range trits {
+		switch trit {
+		case 0:
+			low[i] = allBits
+			high[i] = allBits
+		case 1:
+			high[i] = allBits
+		case -1:
+			low[i] = allBits
+		}
+	}
+	return low, high
+}
+
+// LowHighPairsToTrits converts the low/high word encoding back to trits by
+// extracting the given bit lane (0-63) out of every pair. It returns an error
+// for pairs where the lane holds the invalid combination of both bits unset.
+func LowHighPairsToTrits(low []uint64, high []uint64, lane uint) (Trits, error) {
+	if len(low) != len(high) {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "low and high must be of equal length")
+	}
+	if lane > 63 {
+		return nil, errors.New("lane must be 0-63")
+	}
+
+	trits := make(Trits, len(low))
+	for i := range low {
+		l := (low[i] >> lane) & 1
+		h := (high[i] >> lane) & 1
+		switch {
+		case l == 1 && h == 1:
+			trits[i] = 0
+		case l == 0 && h == 1:
+			trits[i] = 1
+		case l == 1 && h == 0:
+			trits[i] = -1
+		default:
+			return nil, errors.Wrapf(ErrInvalidTrit, "invalid low/high combination at index %d", i)
+		}
+	}
+	return trits, nil
+}
+
+// TritsToBitmask converts trits to a compact variant of the low/high encoding
+// with one bit per trit, 64 trits per word pair. It uses the same per trit
+// mapping as LowHighPairs() and is suited for binary interfaces that move
+// trits in and out of hardware.
+func TritsToBitmask(trits Trits) ([]uint64, []uint64) {
+	words := (len(trits) + tritsPerBitmaskWord - 1) / tritsPerBitmaskWord
+	low := make([]uint64, words)
+	high := make([]uint64, words)
+	for i, trit := range trits {
+		bit := uint64(1) << uint(i%tritsPerBitmaskWord)
+		switch trit {
+		case 0:
+			low[i/tritsPerBitmaskWord] |= bit
+			high[i/tritsPerBitmaskWord] |= bit
+		case 1:
+			high[i/tritsPerBitmaskWord] |= bit
+		case -1:
+			low[i/tritsPerBitmaskWord] |= bit
+		}
+	}
+	return low, high
+}
+
+// BitmaskToTrits converts the compact bitmask encoding of TritsToBitmask()
+// back to the given amount of trits. It returns an error if the words do not
+// cover numTrits or a trit holds the invalid combination of both bits unset.
+func BitmaskToTrits(low []uint64, high []uint64, numTrits int) (Trits, error) {
+	if len(low) != len(high) {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "low and high must be of equal length")
+	}
+	if numTrits < 1 || numTrits > len(low)*tritsPerBitmaskWord {
+		return nil, errors.Wrapf(ErrInvalidTritsLength, "words only hold %d trits", len(low)*tritsPerBitmaskWord)
+	}
+
+	trits := make(Trits, numTrits)
+	for i := 0; i < numTrits; i++ {
+		l := (low[i/tritsPerBitmaskWord] >> uint(i%tritsPerBitmaskWord)) & 1
+		h := (high[i/tritsPerBitmaskWord] >> uint(i%tritsPerBitmaskWord)) & 1
+		switch {
+		case l == 1 && h == 1:
+			trits[i] = 0
+		case l == 0 && h == 1:
+			trits[i] = 1
+		case l == 1 && h == 0:
+			trits[i] = -1
+		default:
+			return nil, errors.Wrapf(ErrInvalidTrit, "invalid low/high combination at index %d", i)
+		}
+	}
+	return trits, nil
+}
@@ -0,0 +1,54 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Binary", func() {
+
+	trits := MustTrytesToTrits("TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZ")
+
+	Context("LowHighPairs()", func() {
+		It("should round trip through any lane", func() {
+			low, high := LowHighPairs(trits)
+			for _, lane := range []uint{0, 1, 63} {
+				back, err := LowHighPairsToTrits(low, high, lane)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(back).To(Equal(trits))
+			}
+		})
+
+		It("should return an error for an invalid pair", func() {
+			_, err := LowHighPairsToTrits([]uint64{0}, []uint64{0}, 0)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for mismatched lengths", func() {
+			_, err := LowHighPairsToTrits([]uint64{0}, []uint64{0, 0}, 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("TritsToBitmask()", func() {
+		It("should round trip", func() {
+			low, high := TritsToBitmask(trits)
+			Expect(low).To(HaveLen(2))
+			back, err := BitmaskToTrits(low, high, len(trits))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(trits))
+		})
+
+		It("should return an error when the words do not cover the trits", func() {
+			low, high := TritsToBitmask(trits)
+			_, err := BitmaskToTrits(low, high, len(low)*64+1)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for an invalid trit encoding", func() {
+			_, err := BitmaskToTrits([]uint64{0}, []uint64{0}, 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
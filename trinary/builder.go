@@ -0,0 +1,76 @@
+package trinary
+
+import (
+	"strings"
+)
+
+// TrytesBuilder assembles a tryte string segment by segment, like
+// strings.Builder. Every appended segment is validated on its own, so fields
+// of a transaction can be added one by one without re-validating or
+// reallocating the whole string. The zero value is ready to use.
+type TrytesBuilder struct {
+	builder strings.Builder
+}
+
+// WriteTrytes appends the given trytes. Nothing is appended if they contain
+// a non tryte character.
+func (b *TrytesBuilder) WriteTrytes(trytes Trytes) error {
+	for i := 0; i < len(trytes); i++ {
+		if c := trytes[i]; c != '9' && (c < 'A' || c > 'Z') {
+			return &TrytesError{Index: i, Char: c}
+		}
+	}
+	b.builder.WriteString(string(trytes))
+	return nil
+}
+
+// WriteTryte appends a single tryte character.
+func (b *TrytesBuilder) WriteTryte(c byte) error {
+	if c != '9' && (c < 'A' || c > 'Z') {
+		return &TrytesError{Index: 0, Char: c}
+	}
+	b.builder.WriteByte(c)
+	return nil
+}
+
+// WriteTrits converts the given trits to trytes and appends them.
+func (b *TrytesBuilder) WriteTrits(trits Trits) error {
+	if err := ValidTrits(trits); err != nil {
+		return err
+	}
+	trytes, err := TritsToTrytes(trits)
+	if err != nil {
+		return err
+	}
+	b.builder.WriteString(string(trytes))
+	return nil
+}
+
+// Pad appends 9s until the builder holds n trytes. It does nothing if the
+// builder already holds n or more trytes.
+func (b *TrytesBuilder) Pad(n int) {
+	for b.builder.Len() < n {
+		b.builder.WriteByte('9')
+	}
+}
+
+// Grow grows the underlying buffer, so n more trytes can be appended without
+// another allocation.
+func (b *TrytesBuilder) Grow(n int) {
+	b.builder.Grow(n)
+}
+
+// Len returns the amount of trytes appended so far.
+func (b *TrytesBuilder) Len() int {
+	return b.builder.Len()
+}
+
+// Reset resets the builder to be empty.
+func (b *TrytesBuilder) Reset() {
+	b.builder.Reset()
+}
+
+// Trytes returns the assembled tryte string.
+func (b *TrytesBuilder) Trytes() Trytes {
+	return Trytes(b.builder.String())
+}
@@ -0,0 +1,47 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Builder", func() {
+
+	Context("TrytesBuilder", func() {
+		It("should assemble trytes segment by segment", func() {
+			var b TrytesBuilder
+			Expect(b.WriteTrytes("TXEFL")).To(Succeed())
+			Expect(b.WriteTryte('K')).To(Succeed())
+			Expect(b.WriteTrits(MustTrytesToTrits("NPJ"))).To(Succeed())
+			Expect(b.Len()).To(Equal(9))
+			Expect(b.Trytes()).To(Equal(Trytes("TXEFLKNPJ")))
+		})
+
+		It("should not append invalid segments", func() {
+			var b TrytesBuilder
+			Expect(b.WriteTrytes("TX")).To(Succeed())
+			Expect(b.WriteTrytes("a")).ToNot(Succeed())
+			Expect(b.WriteTryte('!')).ToNot(Succeed())
+			Expect(b.WriteTrits(Trits{3, 0, 0})).ToNot(Succeed())
+			Expect(b.Trytes()).To(Equal(Trytes("TX")))
+		})
+
+		It("should pad up to the given length", func() {
+			var b TrytesBuilder
+			Expect(b.WriteTrytes("AB")).To(Succeed())
+			b.Pad(5)
+			Expect(b.Trytes()).To(Equal(Trytes("AB999")))
+			b.Pad(3)
+			Expect(b.Len()).To(Equal(5))
+		})
+
+		It("should be empty after a reset", func() {
+			var b TrytesBuilder
+			Expect(b.WriteTrytes("AB")).To(Succeed())
+			b.Reset()
+			Expect(b.Len()).To(Equal(0))
+			Expect(b.Trytes()).To(Equal(Trytes("")))
+		})
+	})
+})
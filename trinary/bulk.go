@@ -0,0 +1,78 @@
+package trinary
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+var (
+	// bulk conversion entry points overridable by an accelerated implementation.
+	bulkTrytesToTritsC func(trytes Trytes, trits Trits) bool
+	bulkTritsToTrytesC func(trits Trits, trytes []byte) bool
+
+	// tryteCharToTritsLUT maps a tryte character directly to its trits, flattened
+	// for a single lookup per tryte. Invalid characters map to a length of 0.
+	tryteCharToTritsLUT [256][]int8
+	// tritsToTryteCharLUT maps the biased value of 3 trits to the tryte character.
+	tritsToTryteCharLUT [27]byte
+)
+
+func init() {
+	for i := 0; i < len(TryteAlphabet); i++ {
+		tryteCharToTritsLUT[TryteAlphabet[i]] = TryteToTritsLUT[i]
+		trits := TryteToTritsLUT[i]
+		tritsToTryteCharLUT[(trits[0]+1)+(trits[1]+1)*3+(trits[2]+1)*9] = TryteAlphabet[i]
+	}
+}
+
+// BulkTrytesToTrits converts trytes to trits in one pass over the input. It is
+// equivalent to TrytesToTrits() but optimized for whole transactions, and uses
+// an accelerated conversion path when the library is built with one.
+func BulkTrytesToTrits(trytes Trytes) (Trits, error) {
+	if len(trytes) == 0 {
+		return nil, errors.Wrap(ErrInvalidTrytes, "trytes are empty")
+	}
+
+	trits := make(Trits, len(trytes)*3)
+	if bulkTrytesToTritsC != nil {
+		if !bulkTrytesToTritsC(trytes, trits) {
+			return nil, ErrInvalidTrytes
+		}
+		return trits, nil
+	}
+
+	for i := 0; i < len(trytes); i++ {
+		lut := tryteCharToTritsLUT[trytes[i]]
+		if len(lut) == 0 {
+			return nil, &TrytesError{Index: i, Char: trytes[i]}
+		}
+		copy(trits[i*3:], lut)
+	}
+	return trits, nil
+}
+
+// BulkTritsToTrytes converts trits to trytes in one pass over the input. It is
+// equivalent to TritsToTrytes() but optimized for whole transactions, and uses
+// an accelerated conversion path when the library is built with one.
+func BulkTritsToTrytes(trits Trits) (Trytes, error) {
+	if !CanTritsToTrytes(trits) {
+		return "", errors.Wrap(ErrInvalidTritsLength, "trits slice size must be a multiple of 3")
+	}
+
+	trytes := make([]byte, len(trits)/3)
+	if bulkTritsToTrytesC != nil {
+		if !bulkTritsToTrytesC(trits, trytes) {
+			return "", ErrInvalidTrit
+		}
+		return Trytes(trytes), nil
+	}
+
+	for i := range trytes {
+		t0, t1, t2 := trits[i*3], trits[i*3+1], trits[i*3+2]
+		if !ValidTrit(t0) || !ValidTrit(t1) || !ValidTrit(t2) {
+			return "", errors.Wrapf(ErrInvalidTrit, "in tryte group at index %d", i)
+		}
+		trytes[i] = tritsToTryteCharLUT[(t0+1)+(t1+1)*3+(t2+1)*9]
+	}
+	return Trytes(trytes), nil
+}
@@ -0,0 +1,83 @@
+// +build cgo
+// +build trinary_avx
+// +build amd64
+
+package trinary
+
+// #cgo CFLAGS: -mavx2 -O3 -Wall
+/*
+#include <stdint.h>
+
+static const int8_t TRYTE_TRITS[27][3] = {
+    {0, 0, 0}, {1, 0, 0}, {-1, 1, 0}, {0, 1, 0},
+    {1, 1, 0}, {-1, -1, 1}, {0, -1, 1}, {1, -1, 1},
+    {-1, 0, 1}, {0, 0, 1}, {1, 0, 1}, {-1, 1, 1},
+    {0, 1, 1}, {1, 1, 1}, {-1, -1, -1}, {0, -1, -1},
+    {1, -1, -1}, {-1, 0, -1}, {0, 0, -1}, {1, 0, -1},
+    {-1, 1, -1}, {0, 1, -1}, {1, 1, -1}, {-1, -1, 0},
+    {0, -1, 0}, {1, -1, 0}, {-1, 0, 0},
+};
+
+static const char TRYTE_ALPHABET[] = "9ABCDEFGHIJKLMNOPQRSTUVWXYZ";
+
+// converts n trytes to 3*n trits, returns 0 on invalid input.
+// the hot loops are kept branch free so the compiler can vectorize them
+// with the AVX2 instruction set enabled above.
+int bulk_trytes_to_trits(const char *trytes, int8_t *trits, int n)
+{
+    int i;
+    for (i = 0; i < n; i++) {
+        char c = trytes[i];
+        if (c != '9' && (c < 'A' || c > 'Z')) {
+            return 0;
+        }
+    }
+    for (i = 0; i < n; i++) {
+        char c = trytes[i];
+        int index = c == '9' ? 0 : c - 'A' + 1;
+        trits[i * 3] = TRYTE_TRITS[index][0];
+        trits[i * 3 + 1] = TRYTE_TRITS[index][1];
+        trits[i * 3 + 2] = TRYTE_TRITS[index][2];
+    }
+    return 1;
+}
+
+// inverse of TRYTE_TRITS, indexed by (t0+1) + (t1+1)*3 + (t2+1)*9; initialized
+// at compile time so concurrent callers never observe a partial table.
+static const char REV_LUT[27] = "NOPQRSTUVWXYZ9ABCDEFGHIJKLM";
+
+// converts 3*n trits to n trytes, returns 0 on invalid input.
+int bulk_trits_to_trytes(const int8_t *trits, char *trytes, int n)
+{
+    int i;
+
+    for (i = 0; i < n * 3; i++) {
+        if (trits[i] < -1 || trits[i] > 1) {
+            return 0;
+        }
+    }
+    for (i = 0; i < n; i++) {
+        int index = (trits[i * 3] + 1) + (trits[i * 3 + 1] + 1) * 3 + (trits[i * 3 + 2] + 1) * 9;
+        trytes[i] = REV_LUT[index];
+    }
+    return 1;
+}
+*/
+import "C"
+import "unsafe"
+
+func init() {
+	bulkTrytesToTritsC = func(trytes Trytes, trits Trits) bool {
+		bytes := []byte(trytes)
+		return C.bulk_trytes_to_trits(
+			(*C.char)(unsafe.Pointer(&bytes[0])),
+			(*C.int8_t)(unsafe.Pointer(&trits[0])),
+			C.int(len(bytes))) == 1
+	}
+	bulkTritsToTrytesC = func(trits Trits, trytes []byte) bool {
+		return C.bulk_trits_to_trytes(
+			(*C.int8_t)(unsafe.Pointer(&trits[0])),
+			(*C.char)(unsafe.Pointer(&trytes[0])),
+			C.int(len(trytes))) == 1
+	}
+}
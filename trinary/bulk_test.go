@@ -0,0 +1,48 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bulk", func() {
+
+	trytes := "TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK"
+
+	Context("BulkTrytesToTrits()", func() {
+		It("should match TrytesToTrits()", func() {
+			trits, err := BulkTrytesToTrits(trytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trits).To(Equal(MustTrytesToTrits(trytes)))
+		})
+
+		It("should return an error for invalid trytes", func() {
+			_, err := BulkTrytesToTrits("abc")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for empty trytes", func() {
+			_, err := BulkTrytesToTrits("")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("BulkTritsToTrytes()", func() {
+		It("should match TritsToTrytes()", func() {
+			back, err := BulkTritsToTrytes(MustTrytesToTrits(trytes))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(trytes))
+		})
+
+		It("should return an error for a trits length which is not a multiple of 3", func() {
+			_, err := BulkTritsToTrytes(Trits{1, 0})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for invalid trits", func() {
+			_, err := BulkTritsToTrytes(Trits{1, 0, 2})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,40 @@
+package trinary
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// AddTritsChecked adds a to b into a trit vector of exactly the given length and
+// returns an error if the result does not fit, instead of silently dropping the
+// carry like AddTrits() does. This is required for manipulating fixed-width
+// fields such as the transaction value safely.
+func AddTritsChecked(a Trits, b Trits, length int) (Trits, error) {
+	// subtracting the negation keeps the carry, unlike AddTrits()
+	return fitTrits(SubTrits(a, NegTrits(b)), length)
+}
+
+// SubTritsChecked subtracts b from a into a trit vector of exactly the given
+// length and returns an error if the result does not fit.
+func SubTritsChecked(a Trits, b Trits, length int) (Trits, error) {
+	return fitTrits(SubTrits(a, b), length)
+}
+
+// MulTritsChecked multiplies a with b into a trit vector of exactly the given
+// length and returns an error if the result does not fit.
+func MulTritsChecked(a Trits, b Trits, length int) (Trits, error) {
+	return fitTrits(MulTrits(a, b), length)
+}
+
+// fitTrits pads the given trits to the given length or returns an error if they
+// do not fit.
+func fitTrits(trits Trits, length int) (Trits, error) {
+	if length < 1 {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "must be at least 1")
+	}
+	trits = trimTrits(trits)
+	if len(trits) > length {
+		return nil, errors.Wrapf(ErrIntegerOverflow, "result takes up %d trits but the field is %d trits wide", len(trits), length)
+	}
+	return PadTrits(trits, length), nil
+}
@@ -0,0 +1,53 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checked", func() {
+
+	Context("AddTritsChecked()", func() {
+		It("should return the padded sum when it fits", func() {
+			sum, err := AddTritsChecked(IntToTrits(1), IntToTrits(1), 4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sum).To(HaveLen(4))
+			Expect(TritsToInt(sum)).To(Equal(int64(2)))
+		})
+
+		It("should return an error on overflow", func() {
+			// 1 + 1 = 2 takes up two trits
+			_, err := AddTritsChecked(IntToTrits(1), IntToTrits(1), 1)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("SubTritsChecked()", func() {
+		It("should return the padded difference when it fits", func() {
+			diff, err := SubTritsChecked(IntToTrits(5), IntToTrits(7), 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diff).To(HaveLen(3))
+			Expect(TritsToInt(diff)).To(Equal(int64(-2)))
+		})
+
+		It("should return an error on overflow", func() {
+			_, err := SubTritsChecked(IntToTrits(13), IntToTrits(-13), 3)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("MulTritsChecked()", func() {
+		It("should return the padded product when it fits", func() {
+			product, err := MulTritsChecked(IntToTrits(6), IntToTrits(7), 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(product).To(HaveLen(5))
+			Expect(TritsToInt(product)).To(Equal(int64(42)))
+		})
+
+		It("should return an error on overflow", func() {
+			_, err := MulTritsChecked(IntToTrits(100), IntToTrits(100), 5)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
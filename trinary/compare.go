@@ -0,0 +1,47 @@
+package trinary
+
+// CompareTrits compares two trits slices by their numeric interpretation and
+// returns -1, 0 or 1. Slices of different lengths are compared as if the
+// shorter one was padded with zero trits, so the ordering is total and
+// consistent across differently sized representations of the same value.
+func CompareTrits(a Trits, b Trits) int {
+	return CmpTrits(a, b)
+}
+
+// CompareTrytes compares two trytes by the numeric interpretation of their
+// trits and returns -1, 0 or 1. Shorter trytes are compared as if they were
+// padded with 9s, matching CompareTrits() on the converted trits. The input
+// is assumed to be valid trytes.
+func CompareTrytes(a Trytes, b Trytes) int {
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+
+	// the most significant tryte is the last one
+	for i := maxLen - 1; i >= 0; i-- {
+		var aV, bV int8
+		if i < len(a) {
+			aV = tryteCharValue(a[i])
+		}
+		if i < len(b) {
+			bV = tryteCharValue(b[i])
+		}
+		if aV != bV {
+			if aV < bV {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// tryteCharValue returns the numeric value of -13 to 13 of a tryte character.
+func tryteCharValue(c byte) int8 {
+	lut := tryteCharToTritsLUT[c]
+	if len(lut) == 0 {
+		return 0
+	}
+	return lut[0] + lut[1]*3 + lut[2]*9
+}
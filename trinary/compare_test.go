@@ -0,0 +1,52 @@
+package trinary_test
+
+import (
+	"math/rand"
+
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compare", func() {
+
+	Context("CompareTrits()", func() {
+		It("should order trits like their numeric values", func() {
+			for i := 0; i < 100; i++ {
+				a := rand.Int63n(2000000) - 1000000
+				b := rand.Int63n(2000000) - 1000000
+
+				expected := 0
+				if a < b {
+					expected = -1
+				} else if a > b {
+					expected = 1
+				}
+				Expect(CompareTrits(IntToTrits(a), IntToTrits(b))).To(Equal(expected))
+			}
+		})
+
+		It("should treat missing trits as zero", func() {
+			Expect(CompareTrits(Trits{1, 0, 0}, Trits{1})).To(Equal(0))
+			Expect(CompareTrits(Trits{1}, Trits{1, 1})).To(Equal(-1))
+		})
+	})
+
+	Context("CompareTrytes()", func() {
+		It("should agree with CompareTrits() on the converted trits", func() {
+			trytesSlice := []Trytes{"9", "A", "M", "N", "Z", "AB", "Z9A", "TXEFL", "KNPJR"}
+			for _, a := range trytesSlice {
+				for _, b := range trytesSlice {
+					Expect(CompareTrytes(a, b)).To(Equal(CompareTrits(MustTrytesToTrits(a), MustTrytesToTrits(b))),
+						"comparing %q and %q", a, b)
+				}
+			}
+		})
+
+		It("should treat missing trytes as 9s", func() {
+			Expect(CompareTrytes("A99", "A")).To(Equal(0))
+			Expect(CompareTrytes("A", "AA")).To(Equal(-1))
+			Expect(CompareTrytes("N", "9")).To(Equal(-1))
+		})
+	})
+})
@@ -0,0 +1,48 @@
+package trinary
+
+import (
+	"fmt"
+
+	. "github.com/iotaledger/iota.go/consts"
+)
+
+// TrytesError is returned for trytes containing an invalid character. It
+// carries the position and the offending character, so a bad transaction
+// string can be pinpointed without bisecting it. It matches ErrInvalidTrytes
+// in errors.Is()/errors.Cause() checks and can be inspected via errors.As().
+type TrytesError struct {
+	// Index is the position of the offending character.
+	Index int
+	// Char is the offending character.
+	Char byte
+}
+
+func (e *TrytesError) Error() string {
+	return fmt.Sprintf("invalid trytes: %q at index %d, expected a character out of %q", e.Char, e.Index, TryteAlphabet)
+}
+
+// Unwrap returns ErrInvalidTrytes, so errors.Is() matches the sentinel.
+func (e *TrytesError) Unwrap() error { return ErrInvalidTrytes }
+
+// Cause returns ErrInvalidTrytes, so errors.Cause() comparisons keep working.
+func (e *TrytesError) Cause() error { return ErrInvalidTrytes }
+
+// TritsError is returned for trits containing an invalid value. It carries the
+// position and the offending value. It matches ErrInvalidTrit in
+// errors.Is()/errors.Cause() checks and can be inspected via errors.As().
+type TritsError struct {
+	// Index is the position of the offending trit.
+	Index int
+	// Trit is the offending value.
+	Trit int8
+}
+
+func (e *TritsError) Error() string {
+	return fmt.Sprintf("invalid trit: %d at index %d, expected a value of %d to %d", e.Trit, e.Index, MinTritValue, MaxTritValue)
+}
+
+// Unwrap returns ErrInvalidTrit, so errors.Is() matches the sentinel.
+func (e *TritsError) Unwrap() error { return ErrInvalidTrit }
+
+// Cause returns ErrInvalidTrit, so errors.Cause() comparisons keep working.
+func (e *TritsError) Cause() error { return ErrInvalidTrit }
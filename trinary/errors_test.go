@@ -0,0 +1,49 @@
+package trinary_test
+
+import (
+	stderrors "errors"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Errors", func() {
+
+	Context("TrytesError", func() {
+		It("should carry the offending index and character", func() {
+			err := ValidTrytes("AB!C")
+
+			var trytesErr *TrytesError
+			Expect(stderrors.As(err, &trytesErr)).To(BeTrue())
+			Expect(trytesErr.Index).To(Equal(2))
+			Expect(trytesErr.Char).To(Equal(byte('!')))
+		})
+
+		It("should still match ErrInvalidTrytes", func() {
+			_, err := TrytesToTrits("a")
+			Expect(stderrors.Is(err, ErrInvalidTrytes)).To(BeTrue())
+			Expect(errors.Cause(err)).To(Equal(ErrInvalidTrytes))
+		})
+	})
+
+	Context("TritsError", func() {
+		It("should carry the offending index and value", func() {
+			err := ValidTrits(Trits{0, 1, 3})
+
+			var tritsErr *TritsError
+			Expect(stderrors.As(err, &tritsErr)).To(BeTrue())
+			Expect(tritsErr.Index).To(Equal(2))
+			Expect(tritsErr.Trit).To(Equal(int8(3)))
+		})
+
+		It("should still match ErrInvalidTrit", func() {
+			err := ValidTrits(Trits{-2})
+			Expect(stderrors.Is(err, ErrInvalidTrit)).To(BeTrue())
+			Expect(errors.Cause(err)).To(Equal(ErrInvalidTrit))
+		})
+	})
+})
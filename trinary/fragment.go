@@ -0,0 +1,53 @@
+package trinary
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+const (
+	// FragmentChunks is the number of hash sized chunks in a key/signature fragment.
+	FragmentChunks = SignatureMessageFragmentTrinarySize / HashTrinarySize
+	// FragmentChunkBytesSize is the byte size of one hash sized fragment chunk.
+	FragmentChunkBytesSize = HashBytesSize + 1
+	// FragmentBytesSize is the byte size of an encoded key/signature fragment.
+	FragmentBytesSize = FragmentChunks * FragmentChunkBytesSize
+)
+
+// FragmentTritsToBytes converts 6561 key/signature fragment trits into their
+// compact byte representation. The fragment is encoded as 27 fixed size chunks
+// of 243 trits, so each chunk only needs a small fixed width integer conversion
+// instead of one arbitrary length conversion across the whole fragment.
+func FragmentTritsToBytes(trits Trits) ([]byte, error) {
+	if len(trits) != SignatureMessageFragmentTrinarySize {
+		return nil, errors.Wrapf(ErrInvalidTritsLength, "must be %d in size", SignatureMessageFragmentTrinarySize)
+	}
+
+	bytes := make([]byte, FragmentBytesSize)
+	for i := 0; i < FragmentChunks; i++ {
+		chunk, err := TritsToBytesN(trits[i*HashTrinarySize : (i+1)*HashTrinarySize])
+		if err != nil {
+			return nil, err
+		}
+		copy(bytes[i*FragmentChunkBytesSize:], chunk)
+	}
+	return bytes, nil
+}
+
+// FragmentBytesToTrits converts the byte representation created by
+// FragmentTritsToBytes() back into the 6561 fragment trits.
+func FragmentBytesToTrits(bytes []byte) (Trits, error) {
+	if len(bytes) != FragmentBytesSize {
+		return nil, errors.Wrapf(ErrInvalidBytesLength, "must be %d in size", FragmentBytesSize)
+	}
+
+	trits := make(Trits, SignatureMessageFragmentTrinarySize)
+	for i := 0; i < FragmentChunks; i++ {
+		chunk, err := BytesToTritsN(bytes[i*FragmentChunkBytesSize:(i+1)*FragmentChunkBytesSize], HashTrinarySize)
+		if err != nil {
+			return nil, err
+		}
+		copy(trits[i*HashTrinarySize:], chunk)
+	}
+	return trits, nil
+}
@@ -0,0 +1,41 @@
+package trinary_test
+
+import (
+	"strings"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fragment", func() {
+
+	// a full 2187 tryte key/signature fragment
+	fragmentTrytes := Trytes(strings.Repeat("TXEFLKNPJRBYZPORHZU9CEMFIFV", 81))
+
+	Context("FragmentTritsToBytes()", func() {
+		It("should round trip a fragment", func() {
+			trits := MustTrytesToTrits(fragmentTrytes)
+			bytes, err := FragmentTritsToBytes(trits)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(HaveLen(FragmentBytesSize))
+
+			back, err := FragmentBytesToTrits(bytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(trits))
+		})
+
+		It("should return an error for a wrongly sized fragment", func() {
+			_, err := FragmentTritsToBytes(make(Trits, HashTrinarySize))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("FragmentBytesToTrits()", func() {
+		It("should return an error for wrongly sized bytes", func() {
+			_, err := FragmentBytesToTrits(make([]byte, HashBytesSize))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,62 @@
+package trinary
+
+import (
+	"encoding/hex"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// TrytesToHex converts trytes to a hexadecimal string, so hashes can be
+// exchanged with systems that only accept hexadecimal identifiers. Hash trytes
+// (81 trytes with a zero last trit) use the canonical 48-byte binary
+// representation of TritsToBytes(); any other trytes use the general byte
+// encoding of TritsToBytesN().
+func TrytesToHex(trytes Trytes) (string, error) {
+	trits, err := TrytesToTrits(trytes)
+	if err != nil {
+		return "", err
+	}
+
+	if len(trits) == HashTrinarySize && trits[HashTrinarySize-1] == 0 {
+		bytes, err := TritsToBytes(trits)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(bytes), nil
+	}
+
+	bytes, err := TritsToBytesN(trits)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// HexToTrytes converts a hexadecimal string created by TrytesToHex() back to
+// trytes. A 48-byte value is interpreted as the binary representation of a
+// hash; for any other value the original trytes length must be passed, since
+// the byte encoding does not carry it.
+func HexToTrytes(s string, numTrytes ...int) (Trytes, error) {
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+
+	if len(numTrytes) == 0 {
+		if len(bytes) != HashBytesSize {
+			return "", errors.Wrap(ErrInvalidBytesLength, "trytes length is required for non hash values")
+		}
+		trits, err := BytesToTrits(bytes)
+		if err != nil {
+			return "", err
+		}
+		return TritsToTrytes(trits)
+	}
+
+	trits, err := BytesToTritsN(bytes, numTrytes[0]*3)
+	if err != nil {
+		return "", err
+	}
+	return TritsToTrytes(trits)
+}
@@ -0,0 +1,52 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hex", func() {
+
+	// a hash with a zero last trit, so it has a 48-byte binary representation
+	hashWithZeroLastTrit := "9RFAOVEWQDNGBPEGFZTVJKKITBASFWCQBSTZYWTYIJETVZJYNFFIEQ9JMQWEHQ9ZKARYTE9GGDYZHIPJX"
+
+	Context("TrytesToHex()", func() {
+		It("should use the 48-byte representation for hashes", func() {
+			hexStr, err := TrytesToHex(hashWithZeroLastTrit)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hexStr).To(HaveLen(96))
+
+			back, err := HexToTrytes(hexStr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(Trytes(hashWithZeroLastTrit)))
+		})
+
+		It("should round trip arbitrary trytes with an explicit length", func() {
+			trytes := Trytes("IOTA9LIB")
+			hexStr, err := TrytesToHex(trytes)
+			Expect(err).ToNot(HaveOccurred())
+
+			back, err := HexToTrytes(hexStr, len(trytes))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(trytes))
+		})
+
+		It("should return an error for invalid trytes", func() {
+			_, err := TrytesToHex("abc")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("HexToTrytes()", func() {
+		It("should return an error for invalid hex", func() {
+			_, err := HexToTrytes("zz")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for non hash values without a length", func() {
+			_, err := HexToTrytes("0a0b")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,65 @@
+package trinary
+
+import (
+	"io"
+	"strings"
+)
+
+// TrytesReader reads a tryte string as an ordinary byte stream. It implements
+// io.Reader, io.ByteReader and io.Seeker, so tryte data can be consumed by
+// streaming code such as network protocols or file importers.
+type TrytesReader struct {
+	*strings.Reader
+}
+
+// NewTrytesReader creates a new TrytesReader reading from the given trytes.
+// The trytes are validated upfront, so consumers can treat every byte read as a
+// valid tryte character.
+func NewTrytesReader(trytes Trytes) (*TrytesReader, error) {
+	if err := ValidTrytes(trytes); err != nil {
+		return nil, err
+	}
+	return &TrytesReader{Reader: strings.NewReader(string(trytes))}, nil
+}
+
+// TrytesWriter builds a validated tryte string incrementally. It implements
+// io.Writer and rejects any write containing non tryte characters, so the
+// accumulated trytes are valid by construction.
+type TrytesWriter struct {
+	builder strings.Builder
+}
+
+// NewTrytesWriter creates a new empty TrytesWriter.
+func NewTrytesWriter() *TrytesWriter {
+	return &TrytesWriter{}
+}
+
+// Write appends the given bytes to the tryte string. It returns an error
+// without consuming anything if p contains a non tryte character.
+func (w *TrytesWriter) Write(p []byte) (int, error) {
+	for i, c := range p {
+		if c != '9' && (c < 'A' || c > 'Z') {
+			return 0, &TrytesError{Index: i, Char: c}
+		}
+	}
+	return w.builder.Write(p)
+}
+
+// WriteTrytes appends already validated trytes to the tryte string.
+func (w *TrytesWriter) WriteTrytes(trytes Trytes) (int, error) {
+	return w.Write([]byte(trytes))
+}
+
+// Len returns the length of the accumulated tryte string.
+func (w *TrytesWriter) Len() int {
+	return w.builder.Len()
+}
+
+// Trytes returns the accumulated tryte string.
+func (w *TrytesWriter) Trytes() Trytes {
+	return Trytes(w.builder.String())
+}
+
+// ensure the adapters implement the io interfaces
+var _ io.Reader = (*TrytesReader)(nil)
+var _ io.Writer = (*TrytesWriter)(nil)
@@ -0,0 +1,60 @@
+package trinary_test
+
+import (
+	"io"
+	"io/ioutil"
+
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IO", func() {
+
+	Context("TrytesReader", func() {
+		It("should stream the trytes as bytes", func() {
+			reader, err := NewTrytesReader("IOTA9")
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := ioutil.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal("IOTA9"))
+		})
+
+		It("should support seeking", func() {
+			reader, err := NewTrytesReader("IOTA9")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reader.Seek(4, io.SeekStart)
+			Expect(err).ToNot(HaveOccurred())
+			b, err := reader.ReadByte()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(b).To(Equal(byte('9')))
+		})
+
+		It("should return an error for invalid trytes", func() {
+			_, err := NewTrytesReader("abc")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("TrytesWriter", func() {
+		It("should accumulate valid trytes across writes", func() {
+			writer := NewTrytesWriter()
+			n, err := writer.Write([]byte("IOTA"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(n).To(Equal(4))
+			_, err = writer.WriteTrytes("9999")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(writer.Len()).To(Equal(8))
+			Expect(writer.Trytes()).To(Equal(Trytes("IOTA9999")))
+		})
+
+		It("should reject writes containing non tryte characters", func() {
+			writer := NewTrytesWriter()
+			_, err := writer.Write([]byte("IO!A"))
+			Expect(err).To(HaveOccurred())
+			Expect(writer.Len()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PackedTrits", func() {
+
+	trits := Trits{1, -1, 0, 1, 1, 0, -1, 0, 1, -1}
+
+	It("should round trip trits", func() {
+		packed, err := NewPackedTrits(trits)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(packed.Len()).To(Equal(len(trits)))
+		Expect(packed.Trits()).To(Equal(trits))
+	})
+
+	It("should get single trits", func() {
+		packed, err := NewPackedTrits(trits)
+		Expect(err).ToNot(HaveOccurred())
+		for i, trit := range trits {
+			Expect(packed.Get(i)).To(Equal(trit))
+		}
+	})
+
+	It("should set single trits", func() {
+		packed, err := NewPackedTrits(trits)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(packed.Set(2, -1)).To(Succeed())
+		Expect(packed.Get(2)).To(Equal(int8(-1)))
+		// neighbouring trits are untouched
+		Expect(packed.Get(1)).To(Equal(int8(-1)))
+		Expect(packed.Get(3)).To(Equal(int8(1)))
+	})
+
+	It("should return an error when setting an invalid trit", func() {
+		packed, err := NewPackedTrits(trits)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(packed.Set(0, 2)).ToNot(Succeed())
+	})
+
+	It("should slice to a new packed instance", func() {
+		packed, err := NewPackedTrits(trits)
+		Expect(err).ToNot(HaveOccurred())
+		sliced := packed.Slice(3, 8)
+		Expect(sliced.Len()).To(Equal(5))
+		Expect(sliced.Trits()).To(Equal(trits[3:8]))
+	})
+
+	It("should return an error for invalid trits", func() {
+		_, err := NewPackedTrits(Trits{0, 2})
+		Expect(err).To(HaveOccurred())
+	})
+})
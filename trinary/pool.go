@@ -0,0 +1,65 @@
+package trinary
+
+import (
+	"sync"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// the buffer sizes which are pooled; hash, Curl state and transaction sized
+// slices cover the hot paths of conversion, curl and pow code.
+var tritsBufferPools = func() map[int]*sync.Pool {
+	pools := map[int]*sync.Pool{}
+	for _, size := range []int{HashTrinarySize, HashTrinarySize * 3, TransactionTrinarySize} {
+		size := size
+		pools[size] = &sync.Pool{New: func() interface{} {
+			return make(Trits, size)
+		}}
+	}
+	return pools
+}()
+
+// GetTritsBuffer returns a zeroed trits buffer of the given size. Buffers of
+// hash, Curl state and transaction size are served from a pool to avoid
+// allocating a new slice for every operation; other sizes are allocated fresh.
+// Return the buffer with PutTritsBuffer() once it is no longer referenced.
+func GetTritsBuffer(size int) Trits {
+	if pool, ok := tritsBufferPools[size]; ok {
+		return pool.Get().(Trits)
+	}
+	return make(Trits, size)
+}
+
+// PutTritsBuffer returns a buffer obtained from GetTritsBuffer() to the pool.
+// The buffer is zeroed, so pooled buffers behave like freshly allocated ones.
+func PutTritsBuffer(buf Trits) {
+	pool, ok := tritsBufferPools[len(buf)]
+	if !ok {
+		return
+	}
+	for i := range buf {
+		buf[i] = 0
+	}
+	pool.Put(buf)
+}
+
+// TrytesToTritsInto converts trytes into the given buffer instead of allocating
+// a new slice, so pooled buffers can be reused across conversions. The buffer
+// must hold exactly 3 trits per tryte.
+func TrytesToTritsInto(trytes Trytes, buf Trits) error {
+	if len(trytes) == 0 {
+		return ErrInvalidTrytes
+	}
+	if len(buf) != len(trytes)*3 {
+		return errors.Wrapf(ErrInvalidTritsLength, "buffer must be %d in size", len(trytes)*3)
+	}
+	for i := 0; i < len(trytes); i++ {
+		lut := tryteCharToTritsLUT[trytes[i]]
+		if len(lut) == 0 {
+			return &TrytesError{Index: i, Char: trytes[i]}
+		}
+		copy(buf[i*3:i*3+3], lut)
+	}
+	return nil
+}
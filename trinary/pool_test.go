@@ -0,0 +1,58 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pool", func() {
+
+	Context("GetTritsBuffer()", func() {
+		It("should return a zeroed buffer of the given size", func() {
+			for _, size := range []int{HashTrinarySize, HashTrinarySize * 3, TransactionTrinarySize, 10} {
+				buf := GetTritsBuffer(size)
+				Expect(buf).To(HaveLen(size))
+				Expect(buf).To(Equal(make(Trits, size)))
+				PutTritsBuffer(buf)
+			}
+		})
+
+		It("should return a zeroed buffer even after a dirty one was put back", func() {
+			buf := GetTritsBuffer(HashTrinarySize)
+			for i := range buf {
+				buf[i] = 1
+			}
+			PutTritsBuffer(buf)
+
+			buf = GetTritsBuffer(HashTrinarySize)
+			Expect(buf).To(Equal(make(Trits, HashTrinarySize)))
+			PutTritsBuffer(buf)
+		})
+	})
+
+	Context("TrytesToTritsInto()", func() {
+		It("should convert trytes into the given buffer", func() {
+			buf := GetTritsBuffer(len(TryteAlphabet) * 3)
+			defer PutTritsBuffer(buf)
+			Expect(TrytesToTritsInto(Trytes(TryteAlphabet), buf)).To(Succeed())
+			Expect(buf).To(Equal(MustTrytesToTrits(Trytes(TryteAlphabet))))
+		})
+
+		It("should return an error for a wrongly sized buffer", func() {
+			err := TrytesToTritsInto("ABC", make(Trits, 8))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for invalid trytes", func() {
+			err := TrytesToTritsInto("abc", make(Trits, 9))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for empty trytes", func() {
+			err := TrytesToTritsInto("", make(Trits, 0))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
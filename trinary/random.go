@@ -0,0 +1,42 @@
+package trinary
+
+import (
+	"crypto/rand"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// the largest multiple of 27 fitting into a byte; values above it are rejected
+// during sampling to avoid modulo bias.
+const maxUnbiasedByte = 27 * 9
+
+// SecureRandomTrytes generates n random trytes from crypto/rand. Rejection
+// sampling ensures every tryte character is drawn uniformly, avoiding the
+// modulo bias of naive implementations.
+func SecureRandomTrytes(n int) (Trytes, error) {
+	if n < 1 {
+		return "", errors.Wrap(ErrInvalidTrytes, "length must be at least 1")
+	}
+
+	trytes := make([]byte, n)
+	buf := make([]byte, n)
+	for i := 0; i < n; {
+		if _, err := rand.Read(buf[:n-i]); err != nil {
+			return "", err
+		}
+		for _, b := range buf[:n-i] {
+			if b >= maxUnbiasedByte {
+				continue
+			}
+			trytes[i] = TryteAlphabet[b%27]
+			i++
+		}
+	}
+	return Trytes(trytes), nil
+}
+
+// SecureRandomSeed generates a cryptographically secure random seed of 81 trytes.
+func SecureRandomSeed() (Trytes, error) {
+	return SecureRandomTrytes(HashTrinarySize / 3)
+}
@@ -0,0 +1,41 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Random", func() {
+
+	Context("SecureRandomTrytes()", func() {
+		It("should generate valid trytes of the requested length", func() {
+			trytes, err := SecureRandomTrytes(100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(trytes).To(HaveLen(100))
+			Expect(ValidTrytes(trytes)).To(Succeed())
+		})
+
+		It("should generate different trytes on every call", func() {
+			a, err := SecureRandomTrytes(81)
+			Expect(err).ToNot(HaveOccurred())
+			b, err := SecureRandomTrytes(81)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(a).ToNot(Equal(b))
+		})
+
+		It("should return an error for an invalid length", func() {
+			_, err := SecureRandomTrytes(0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("SecureRandomSeed()", func() {
+		It("should generate a valid 81 tryte seed", func() {
+			seed, err := SecureRandomSeed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(seed).To(HaveLen(81))
+			Expect(ValidTrytes(seed)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package trinary
+
+import (
+	"crypto/subtle"
+)
+
+// SecureEqual compares two tryte strings in constant time, mirroring
+// crypto/subtle. Use it instead of == when comparing seeds or private key
+// material, so the comparison time does not leak where they first differ.
+// Trytes of different lengths compare unequal, only the time for equal
+// lengths is constant.
+func SecureEqual(a Trytes, b Trytes) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// SecureEqualTrits compares two trits slices in constant time, like
+// SecureEqual() does for trytes.
+func SecureEqualTrits(a Trits, b Trits) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var v int8
+	for i := range a {
+		v |= a[i] ^ b[i]
+	}
+	return subtle.ConstantTimeByteEq(uint8(v), 0) == 1
+}
@@ -0,0 +1,34 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secure", func() {
+
+	Context("SecureEqual()", func() {
+		It("should return true for equal trytes", func() {
+			Expect(SecureEqual("TXEFLKNPJRBYZ", "TXEFLKNPJRBYZ")).To(BeTrue())
+			Expect(SecureEqual("", "")).To(BeTrue())
+		})
+
+		It("should return false for unequal trytes", func() {
+			Expect(SecureEqual("TXEFLKNPJRBYZ", "TXEFLKNPJRBYA")).To(BeFalse())
+			Expect(SecureEqual("TXEFL", "TXEFLK")).To(BeFalse())
+		})
+	})
+
+	Context("SecureEqualTrits()", func() {
+		It("should return true for equal trits", func() {
+			Expect(SecureEqualTrits(Trits{1, 0, -1}, Trits{1, 0, -1})).To(BeTrue())
+			Expect(SecureEqualTrits(Trits{}, Trits{})).To(BeTrue())
+		})
+
+		It("should return false for unequal trits", func() {
+			Expect(SecureEqualTrits(Trits{1, 0, -1}, Trits{1, 0, 1})).To(BeFalse())
+			Expect(SecureEqualTrits(Trits{1, 0}, Trits{1, 0, 0})).To(BeFalse())
+		})
+	})
+})
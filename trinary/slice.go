@@ -0,0 +1,99 @@
+package trinary
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// TrytesSliceToTrits converts a slice of trytes to the corresponding slice of
+// trits, so bundle level processing does not have to loop over single value
+// conversions. The optional parallelism defines how many workers run the
+// conversions concurrently; it defaults to the number of CPUs. An invalid
+// element yields an error carrying its position in the slice.
+func TrytesSliceToTrits(trytes []Trytes, parallelism ...int) ([]Trits, error) {
+	out := make([]Trits, len(trytes))
+	err := forEachSliceIndex(len(trytes), sliceWorkers(len(trytes), parallelism...), func(i int) error {
+		trits, err := TrytesToTrits(trytes[i])
+		if err != nil {
+			return errors.Wrapf(err, "at index %d", i)
+		}
+		out[i] = trits
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TritsSliceToTrytes converts a slice of trits to the corresponding slice of
+// trytes. It behaves like TrytesSliceToTrits() with the conversion reversed.
+func TritsSliceToTrytes(trits []Trits, parallelism ...int) ([]Trytes, error) {
+	out := make([]Trytes, len(trits))
+	err := forEachSliceIndex(len(trits), sliceWorkers(len(trits), parallelism...), func(i int) error {
+		trytes, err := TritsToTrytes(trits[i])
+		if err != nil {
+			return errors.Wrapf(err, "at index %d", i)
+		}
+		out[i] = trytes
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// computes the number of conversion workers for n elements.
+func sliceWorkers(n int, parallelism ...int) int {
+	workers := runtime.NumCPU()
+	if len(parallelism) != 0 && parallelism[0] > 0 {
+		workers = parallelism[0]
+	}
+	if workers > n {
+		workers = n
+	}
+	return workers
+}
+
+// runs f for every index, distributing the indices across the given number of
+// workers. The first error in slice order is returned.
+func forEachSliceIndex(n int, workers int, f func(i int) error) error {
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := f(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, n)
+	indices := make(chan int, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = f(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Slice", func() {
+
+	trytesSlice := []Trytes{"A", "9ZYX", "TXEFLKNPJRBYZ", "M", "QBUSTDGSJCZ"}
+
+	Context("TrytesSliceToTrits()", func() {
+		It("should convert all elements", func() {
+			tritsSlice, err := TrytesSliceToTrits(trytesSlice)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tritsSlice).To(HaveLen(len(trytesSlice)))
+			for i := range trytesSlice {
+				Expect(tritsSlice[i]).To(Equal(MustTrytesToTrits(trytesSlice[i])))
+			}
+		})
+
+		It("should convert all elements with a single worker", func() {
+			tritsSlice, err := TrytesSliceToTrits(trytesSlice, 1)
+			Expect(err).ToNot(HaveOccurred())
+			for i := range trytesSlice {
+				Expect(tritsSlice[i]).To(Equal(MustTrytesToTrits(trytesSlice[i])))
+			}
+		})
+
+		It("should return a positional error for an invalid element", func() {
+			_, err := TrytesSliceToTrits([]Trytes{"A", "abc", "M"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at index 1"))
+		})
+	})
+
+	Context("TritsSliceToTrytes()", func() {
+		It("should convert all elements", func() {
+			tritsSlice, err := TrytesSliceToTrits(trytesSlice)
+			Expect(err).ToNot(HaveOccurred())
+
+			back, err := TritsSliceToTrytes(tritsSlice)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(trytesSlice))
+		})
+
+		It("should return a positional error for an invalid element", func() {
+			_, err := TritsSliceToTrytes([]Trits{{0, 0, 0}, {1, 1}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at index 1"))
+		})
+	})
+})
@@ -0,0 +1,107 @@
+package trinary
+
+import (
+	"io"
+)
+
+// Encoder writes trits and trytes in the compact t5b1 encoding to an underlying
+// byte stream. It buffers incomplete trit groups between writes, so arbitrarily
+// large trit streams can be encoded incrementally without loading everything
+// into memory. Close() must be called to flush a padded final group.
+type Encoder struct {
+	w       io.Writer
+	pending Trits
+}
+
+// NewEncoder creates a new Encoder writing the t5b1 encoded trits to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// WriteTrits encodes the given trits into the underlying byte stream.
+func (e *Encoder) WriteTrits(trits Trits) error {
+	e.pending = append(e.pending, trits...)
+	completeLen := len(e.pending) - len(e.pending)%TritsPerByteT5B1
+	if completeLen == 0 {
+		return nil
+	}
+
+	bytes, err := TritsToT5B1Bytes(e.pending[:completeLen])
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(bytes); err != nil {
+		return err
+	}
+	e.pending = append(e.pending[:0], e.pending[completeLen:]...)
+	return nil
+}
+
+// WriteTrytes encodes the given trytes into the underlying byte stream.
+func (e *Encoder) WriteTrytes(trytes Trytes) error {
+	trits, err := TrytesToTrits(trytes)
+	if err != nil {
+		return err
+	}
+	return e.WriteTrits(trits)
+}
+
+// Close flushes a remaining incomplete trit group padded with zero trits to the
+// underlying byte stream. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if len(e.pending) == 0 {
+		return nil
+	}
+
+	bytes, err := TritsToT5B1Bytes(e.pending)
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(bytes); err != nil {
+		return err
+	}
+	e.pending = e.pending[:0]
+	return nil
+}
+
+// Decoder reads trits and trytes from an underlying t5b1 encoded byte stream.
+// Surplus trits of partially consumed groups are buffered between reads, so
+// arbitrarily large streams can be decoded incrementally.
+type Decoder struct {
+	r       io.Reader
+	pending Trits
+}
+
+// NewDecoder creates a new Decoder reading t5b1 encoded trits from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// ReadTrits reads the next n trits from the underlying byte stream.
+func (d *Decoder) ReadTrits(n int) (Trits, error) {
+	for len(d.pending) < n {
+		bytes := make([]byte, EncodedT5B1Len(n-len(d.pending)))
+		if _, err := io.ReadFull(d.r, bytes); err != nil {
+			return nil, err
+		}
+		trits, err := T5B1BytesToTrits(bytes)
+		if err != nil {
+			return nil, err
+		}
+		d.pending = append(d.pending, trits...)
+	}
+
+	trits := make(Trits, n)
+	copy(trits, d.pending)
+	d.pending = append(d.pending[:0], d.pending[n:]...)
+	return trits, nil
+}
+
+// ReadTrytes reads the next n trytes from the underlying byte stream.
+func (d *Decoder) ReadTrytes(n int) (Trytes, error) {
+	trits, err := d.ReadTrits(n * 3)
+	if err != nil {
+		return "", err
+	}
+	return TritsToTrytes(trits)
+}
@@ -0,0 +1,53 @@
+package trinary_test
+
+import (
+	"bytes"
+	"io"
+
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream", func() {
+
+	Context("Encoder/Decoder", func() {
+		It("should round trip trytes written in odd sized chunks", func() {
+			trytes := "TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK"
+
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			Expect(enc.WriteTrytes(trytes[:7])).To(Succeed())
+			Expect(enc.WriteTrytes(trytes[7:])).To(Succeed())
+			Expect(enc.Close()).To(Succeed())
+			Expect(buf.Len()).To(Equal(EncodedT5B1Len(len(trytes) * 3)))
+
+			dec := NewDecoder(&buf)
+			read, err := dec.ReadTrytes(len(trytes))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(read).To(Equal(trytes))
+		})
+
+		It("should buffer surplus trits between reads", func() {
+			var buf bytes.Buffer
+			enc := NewEncoder(&buf)
+			Expect(enc.WriteTrits(Trits{1, -1, 0, 1, 1, 0, -1})).To(Succeed())
+			Expect(enc.Close()).To(Succeed())
+
+			dec := NewDecoder(&buf)
+			first, err := dec.ReadTrits(3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(first).To(Equal(Trits{1, -1, 0}))
+
+			second, err := dec.ReadTrits(4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(second).To(Equal(Trits{1, 1, 0, -1}))
+		})
+
+		It("should return an error when the stream is exhausted", func() {
+			dec := NewDecoder(bytes.NewReader([]byte{1}))
+			_, err := dec.ReadTrits(10)
+			Expect(err).To(Equal(io.ErrUnexpectedEOF))
+		})
+	})
+})
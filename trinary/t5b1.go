@@ -0,0 +1,67 @@
+package trinary
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// TritsPerByteT5B1 is the amount of trits packed into a single byte in t5b1.
+const TritsPerByteT5B1 = 5
+
+// EncodedT5B1Len returns the amount of bytes the t5b1 encoding of n trits takes up.
+func EncodedT5B1Len(n int) int {
+	return (n + TritsPerByteT5B1 - 1) / TritsPerByteT5B1
+}
+
+// DecodedT5B1Len returns the amount of trits the t5b1 decoding of n bytes yields.
+func DecodedT5B1Len(n int) int {
+	return n * TritsPerByteT5B1
+}
+
+// TritsToT5B1Bytes packs the given trits into bytes with the t5b1 encoding, where
+// 5 trits are stored per byte. The last group is padded with zero trits if the
+// length of the trits is not a multiple of 5. This is considerably more compact
+// than storing raw int8 trits or tryte strings.
+func TritsToT5B1Bytes(trits Trits) ([]byte, error) {
+	if err := ValidTrits(trits); err != nil {
+		return nil, err
+	}
+
+	bytes := make([]byte, EncodedT5B1Len(len(trits)))
+	for i := range bytes {
+		var v int8
+		for j := TritsPerByteT5B1 - 1; j >= 0; j-- {
+			if index := i*TritsPerByteT5B1 + j; index < len(trits) {
+				v = v*3 + trits[index]
+			} else {
+				v *= 3
+			}
+		}
+		bytes[i] = byte(v)
+	}
+	return bytes, nil
+}
+
+// T5B1BytesToTrits unpacks the given t5b1 encoded bytes back to trits. It always
+// returns len(bytes)*5 trits including the padding trits of the last group; the
+// caller must slice to the original trits length.
+func T5B1BytesToTrits(bytes []byte) (Trits, error) {
+	trits := make(Trits, DecodedT5B1Len(len(bytes)))
+	for i, b := range bytes {
+		v := int(int8(b))
+		if v < -121 || v > 121 {
+			return nil, errors.Wrapf(ErrInvalidByte, "t5b1 byte at index %d holds the out of range value %d", i, v)
+		}
+		for j := 0; j < TritsPerByteT5B1; j++ {
+			r := v % 3
+			if r == 2 {
+				r = -1
+			} else if r == -2 {
+				r = 1
+			}
+			trits[i*TritsPerByteT5B1+j] = int8(r)
+			v = (v - r) / 3
+		}
+	}
+	return trits, nil
+}
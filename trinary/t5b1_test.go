@@ -0,0 +1,51 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("T5B1", func() {
+
+	Context("TritsToT5B1Bytes()", func() {
+		It("should pack 5 trits per byte", func() {
+			bytes, err := TritsToT5B1Bytes(Trits{1, 1, 1, 1, 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(Equal([]byte{121}))
+
+			bytes, err = TritsToT5B1Bytes(Trits{-1, -1, -1, -1, -1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(Equal([]byte{0x87})) // two's complement of -121
+		})
+
+		It("should pad the last group with zero trits", func() {
+			bytes, err := TritsToT5B1Bytes(Trits{1, 0, 0, 0, 0, 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(Equal([]byte{1, 1}))
+		})
+
+		It("should return an error for invalid trits", func() {
+			_, err := TritsToT5B1Bytes(Trits{1, 2, 0})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("T5B1BytesToTrits()", func() {
+		It("should round trip the trits of a transaction hash", func() {
+			trits := MustTrytesToTrits("TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK")
+			bytes, err := TritsToT5B1Bytes(trits)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(HaveLen(EncodedT5B1Len(len(trits))))
+
+			back, err := T5B1BytesToTrits(bytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back[:len(trits)]).To(Equal(trits))
+		})
+
+		It("should return an error for an out of range byte", func() {
+			_, err := T5B1BytesToTrits([]byte{122})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -2,13 +2,11 @@
 package trinary
 
 import (
+	"encoding/binary"
 	"github.com/iotaledger/iota.go/bigint"
 	. "github.com/iotaledger/iota.go/consts"
 	"github.com/pkg/errors"
 	"math"
-	"regexp"
-	"strings"
-	"unsafe"
 )
 
 var (
@@ -39,7 +37,7 @@ func ValidTrit(t int8) bool {
 func ValidTrits(t Trits) error {
 	for i, tt := range t {
 		if valid := ValidTrit(tt); !valid {
-			return errors.Wrapf(ErrInvalidTrit, "at index %d", i)
+			return &TritsError{Index: i, Trit: tt}
 		}
 	}
 	return nil
@@ -72,49 +70,46 @@ func TritsEqual(a, b Trits) (bool, error) {
 	return true, nil
 }
 
-// IntToTrits converts int64 to trits.
+// IntToTrits converts int64 to trits. It is defined for the complete int64
+// range including math.MinInt64 and math.MaxInt64.
 func IntToTrits(value int64) Trits {
 	if value == 0 {
 		return Trits{0}
 	}
-	var dest Trits
-	if value != 0 {
-		dest = make(Trits, int(1+math.Floor(math.Log(2*math.Max(1, math.Abs(float64(value))))/math.Log(3))))
-	} else {
-		dest = make(Trits, 0)
-	}
 
-	var absoluteValue int64
-	if value < 0 {
-		absoluteValue = -value
-	} else {
-		absoluteValue = value
+	// work on the negated value, since the negative range of an int64 is larger;
+	// this keeps math.MinInt64 well defined. int64 values need at most 41 trits.
+	v := value
+	if v > 0 {
+		v = -v
 	}
 
-	i := 0
-	for absoluteValue > 0 {
-		remainder := absoluteValue % TrinaryRadix
-		absoluteValue = int64(math.Floor(float64(absoluteValue / TrinaryRadix)))
-
-		if remainder > MaxTritValue {
-			remainder = MinTritValue
-			absoluteValue++
+	var buf [41]int8
+	n := 0
+	for v != 0 {
+		remainder := int8(v % TrinaryRadix)
+		v /= TrinaryRadix
+		if remainder < MinTritValue {
+			remainder = MaxTritValue
+			v--
 		}
-
-		dest[i] = int8(remainder)
-		i++
+		buf[n] = remainder
+		n++
 	}
 
+	dest := make(Trits, n)
 	if value < 0 {
-		for i := 0; i < len(dest); i++ {
-			dest[i] = -dest[i]
+		copy(dest, buf[:n])
+	} else {
+		for i := 0; i < n; i++ {
+			dest[i] = -buf[i]
 		}
 	}
-
 	return dest
 }
 
 // TritsToInt converts a slice of trits into an integer and assumes little-endian notation.
+// Values outside the int64 range wrap around silently; use TritsToIntChecked to detect this.
 func TritsToInt(t Trits) int64 {
 	var val int64
 	for i := len(t) - 1; i >= 0; i-- {
@@ -123,6 +118,75 @@ func TritsToInt(t Trits) int64 {
 	return val
 }
 
+// TritsToIntChecked converts a slice of trits into an integer like TritsToInt,
+// but returns an error if the encoded value does not fit into an int64.
+func TritsToIntChecked(t Trits) (int64, error) {
+	var val int64
+	for i := len(t) - 1; i >= 0; i-- {
+		trit := int64(t[i])
+		// val*3+trit stays in range for val in [⌈(MinInt64-trit)/3⌉, ⌊(MaxInt64-trit)/3⌋];
+		// only the lower bound depends on the trit, as MinInt64-1 is divisible by 3.
+		lo := int64(math.MinInt64) / 3
+		if trit > 0 {
+			lo--
+		}
+		if val > math.MaxInt64/3 || val < lo {
+			return 0, errors.Wrap(ErrIntegerOverflow, "trits encode a value outside the int64 range")
+		}
+		val = val*3 + trit
+	}
+	return val, nil
+}
+
+// IntToTritsOfLength converts int64 to trits of exactly the given length, padded
+// with zero trits. It returns an error if the value does not fit into the given
+// length, so fixed-width transaction fields don't silently truncate.
+func IntToTritsOfLength(value int64, length int) (Trits, error) {
+	if length < 1 {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "must be at least 1")
+	}
+
+	trits := IntToTrits(value)
+	if len(trits) > length {
+		return nil, errors.Wrapf(ErrIntegerOverflow, "%d takes up %d trits but the field is %d trits wide", value, len(trits), length)
+	}
+	return PadTrits(trits, length), nil
+}
+
+// UintToTrits converts uint64 to trits, including values above the int64 range.
+func UintToTrits(value uint64) Trits {
+	if value == 0 {
+		return Trits{0}
+	}
+
+	var trits Trits
+	for value > 0 {
+		rem := int8(value % TrinaryRadix)
+		value /= TrinaryRadix
+		if rem > MaxTritValue {
+			rem = MinTritValue
+			value++
+		}
+		trits = append(trits, rem)
+	}
+	return trits
+}
+
+// UintToTritsOfLength converts uint64 to trits of exactly the given length,
+// padded with zero trits. It returns an error if the value does not fit into the
+// given length.
+func UintToTritsOfLength(value uint64, length int) (Trits, error) {
+	if length < 1 {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "must be at least 1")
+	}
+
+	trits := UintToTrits(value)
+	if len(trits) > length {
+		return nil, errors.Wrapf(ErrIntegerOverflow, "%d takes up %d trits but the field is %d trits wide", value, len(trits), length)
+	}
+	return PadTrits(trits, length), nil
+}
+
 // CanTritsToTrytes returns true if t can be converted to trytes.
 func CanTritsToTrytes(trits Trits) bool {
 	if len(trits) == 0 {
@@ -203,11 +267,8 @@ func TritsToBytes(trits Trits) ([]byte, error) {
 		}
 	}
 
-	// trit to BigInt
-	b := make([]byte, 48) // 48 bytes/384 bits
-
-	// 12 * 32 bits = 384 bits
-	base := (*(*[]uint32)(unsafe.Pointer(&b)))[0:IntLength]
+	// trit to BigInt, 12 * 32 bits = 384 bits
+	base := make([]uint32, IntLength)
 
 	if allNeg {
 		// if all trits are -1 then we're half way through all the numbers,
@@ -218,10 +279,11 @@ func TritsToBytes(trits Trits) ([]byte, error) {
 		bigint.Not(base)
 		bigint.AddSmall(base, 1)
 
-		return bigint.Reverse(b), nil
+		return bigintWordsToBytes(base), nil
 	}
 
-	revT := make([]int8, len(trits))
+	revT := GetTritsBuffer(len(trits))
+	defer PutTritsBuffer(revT)
 	copy(revT, trits)
 	size := 1
 
@@ -263,23 +325,43 @@ func TritsToBytes(trits Trits) ([]byte, error) {
 			copy(base, tmp)
 		}
 	}
-	return bigint.Reverse(b), nil
+	return bigintWordsToBytes(base), nil
 }
 
-// BytesToTrits converts binary to trinary
-func BytesToTrits(b []byte) (Trits, error) {
-	if len(b) != HashBytesSize {
-		return nil, errors.Wrapf(ErrInvalidBytesLength, "must be %d in size", HashBytesSize)
+// bigintWordsToBytes serializes the little-endian words into the big-endian
+// bytes representation, independently of the host byte order.
+func bigintWordsToBytes(base []uint32) []byte {
+	b := make([]byte, len(base)*4)
+	for i, w := range base {
+		binary.LittleEndian.PutUint32(b[i*4:], w)
 	}
+	return bigint.Reverse(b)
+}
 
+// bytesToBigintWords deserializes the big-endian bytes representation into
+// little-endian words, independently of the host byte order.
+func bytesToBigintWords(b []byte) []uint32 {
 	rb := make([]byte, len(b))
 	copy(rb, b)
 	bigint.Reverse(rb)
 
+	base := make([]uint32, len(rb)/4)
+	for i := range base {
+		base[i] = binary.LittleEndian.Uint32(rb[i*4:])
+	}
+	return base
+}
+
+// BytesToTrits converts binary to trinary
+func BytesToTrits(b []byte) (Trits, error) {
+	if len(b) != HashBytesSize {
+		return nil, errors.Wrapf(ErrInvalidBytesLength, "must be %d in size", HashBytesSize)
+	}
+
 	t := Trits(make([]int8, HashTrinarySize))
 	t[HashTrinarySize-1] = 0
 
-	base := (*(*[]uint32)(unsafe.Pointer(&rb)))[0:IntLength] // 12 * 32 bits = 384 bits
+	base := bytesToBigintWords(b) // 12 * 32 bits = 384 bits
 
 	if bigint.IsNull(base) {
 		return t, nil
@@ -288,7 +370,7 @@ func BytesToTrits(b []byte) (Trits, error) {
 	var flipTrits bool
 
 	// Check if the MSB is 0, i.e. we have a positive number
-	msbM := (unsafe.Sizeof(base[IntLength-1]) * 8) - 1
+	msbM := uint(32) - 1
 
 	switch {
 	case base[IntLength-1]>>msbM == 0:
@@ -348,15 +430,17 @@ type Hash = Trytes
 // Hashes is a slice of Hash.
 type Hashes = []Hash
 
-var trytesRegex = regexp.MustCompile("^[9A-Z]+$")
-
 // ValidTrytes returns true if t is made of valid trytes.
 func ValidTrytes(trytes Trytes) error {
-	if !trytesRegex.MatchString(string(trytes)) {
+	if len(trytes) == 0 {
 		return ErrInvalidTrytes
 	}
+	for i := 0; i < len(trytes); i++ {
+		if c := trytes[i]; c != '9' && (c < 'A' || c > 'Z') {
+			return &TrytesError{Index: i, Char: c}
+		}
+	}
 	return nil
-
 }
 
 // ValidTryte returns the validity of a tryte (must be rune A-Z or 9)
@@ -372,13 +456,17 @@ func NewTrytes(s string) (Trytes, error) {
 
 // TrytesToTrits converts a slice of trytes into trits.
 func TrytesToTrits(trytes Trytes) (Trits, error) {
-	if err := ValidTrytes(trytes); err != nil {
-		return nil, err
+	if len(trytes) == 0 {
+		return nil, ErrInvalidTrytes
 	}
 	trits := make(Trits, len(trytes)*3)
-	for i := range trytes {
-		idx := strings.Index(TryteAlphabet, string(trytes[i:i+1]))
-		copy(trits[i*3:i*3+3], TryteToTritsLUT[idx])
+	for i := 0; i < len(trytes); i++ {
+		// the LUT covers every byte value, so validation happens in the same pass
+		lut := tryteCharToTritsLUT[trytes[i]]
+		if len(lut) == 0 {
+			return nil, &TrytesError{Index: i, Char: trytes[i]}
+		}
+		copy(trits[i*3:i*3+3], lut)
 	}
 	return trits, nil
 }
@@ -493,3 +581,105 @@ func AddTrits(a Trits, b Trits) Trits {
 	}
 	return out
 }
+
+// NegTrits returns the negation of the given trits.
+func NegTrits(t Trits) Trits {
+	out := make(Trits, len(t))
+	for i := range t {
+		out[i] = -t[i]
+	}
+	return out
+}
+
+// SubTrits subtracts b from a.
+func SubTrits(a Trits, b Trits) Trits {
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+	if maxLen == 0 {
+		return Trits{0}
+	}
+
+	out := make(Trits, maxLen+1)
+	var aI, bI, carry int8
+	for i := 0; i < maxLen; i++ {
+		if i < len(a) {
+			aI = a[i]
+		} else {
+			aI = 0
+		}
+		if i < len(b) {
+			bI = -b[i]
+		} else {
+			bI = 0
+		}
+
+		fA := fullAdd(aI, bI, carry)
+		out[i] = fA[0]
+		carry = fA[1]
+	}
+	out[maxLen] = carry
+	return trimTrits(out)
+}
+
+// MulTrits multiplies a with b.
+func MulTrits(a Trits, b Trits) Trits {
+	if len(a) == 0 || len(b) == 0 {
+		return Trits{0}
+	}
+
+	out := make(Trits, len(a)+len(b)+1)
+	for j, bJ := range b {
+		if bJ == 0 {
+			continue
+		}
+		var carry int8
+		for i := 0; i < len(a) || carry != 0; i++ {
+			var p int8
+			if i < len(a) {
+				p = a[i] * bJ
+			}
+			fA := fullAdd(out[i+j], p, carry)
+			out[i+j] = fA[0]
+			carry = fA[1]
+		}
+	}
+	return trimTrits(out)
+}
+
+// CmpTrits compares a and b as balanced ternary numbers and returns -1, 0 or 1
+// if a is smaller than, equal to or greater than b.
+func CmpTrits(a Trits, b Trits) int {
+	maxLen := len(a)
+	if len(b) > maxLen {
+		maxLen = len(b)
+	}
+
+	// in balanced ternary the most significant differing trit decides the order
+	for i := maxLen - 1; i >= 0; i-- {
+		var aI, bI int8
+		if i < len(a) {
+			aI = a[i]
+		}
+		if i < len(b) {
+			bI = b[i]
+		}
+		if aI != bI {
+			if aI < bI {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// trimTrits removes trailing zero trits, leaving at least one trit.
+func trimTrits(t Trits) Trits {
+	end := len(t)
+	for end > 1 && t[end-1] == 0 {
+		end--
+	}
+	return t[:end]
+}
@@ -1,10 +1,13 @@
 package trinary_test
 
 import (
+	"encoding/hex"
 	. "github.com/iotaledger/iota.go/consts"
 	. "github.com/iotaledger/iota.go/trinary"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"math"
+	"math/rand"
 	"strings"
 )
 
@@ -84,6 +87,13 @@ var _ = Describe("Trinary", func() {
 			Expect(IntToTrits(-7)).To(Equal(Trits{-1, 1, -1}))
 			Expect(IntToTrits(-1094385)).To(Equal(Trits{0, -1, 1, 0, 1, -1, -1, 1, 1, 1, -1, 0, 1, -1}))
 		})
+
+		It("should round trip the int64 extremes", func() {
+			Expect(TritsToInt(IntToTrits(math.MaxInt64))).To(Equal(int64(math.MaxInt64)))
+			Expect(TritsToInt(IntToTrits(math.MinInt64))).To(Equal(int64(math.MinInt64)))
+			Expect(TritsToInt(IntToTrits(math.MaxInt64 - 1))).To(Equal(int64(math.MaxInt64 - 1)))
+			Expect(TritsToInt(IntToTrits(math.MinInt64 + 1))).To(Equal(int64(math.MinInt64 + 1)))
+		})
 	})
 
 	Context("TritsToInt", func() {
@@ -100,6 +110,114 @@ var _ = Describe("Trinary", func() {
 		})
 	})
 
+	Context("TritsToIntChecked()", func() {
+		It("should behave like TritsToInt for values in range", func() {
+			for _, v := range []int64{0, 12, -7, 3332727, math.MaxInt64, math.MinInt64} {
+				val, err := TritsToIntChecked(IntToTrits(v))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(val).To(Equal(v))
+			}
+		})
+
+		It("should return an error for values outside the int64 range", func() {
+			overflow := append(IntToTrits(math.MaxInt64), 1)
+			_, err := TritsToIntChecked(overflow)
+			Expect(err).To(HaveOccurred())
+
+			underflow := append(IntToTrits(math.MinInt64), -1)
+			_, err = TritsToIntChecked(underflow)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("IntToTritsOfLength()", func() {
+		It("should pad the trits to the given length", func() {
+			Expect(IntToTritsOfLength(12, 5)).To(Equal(Trits{0, 1, 1, 0, 0}))
+			Expect(IntToTritsOfLength(-7, 4)).To(Equal(Trits{-1, 1, -1, 0}))
+			Expect(IntToTritsOfLength(0, 3)).To(Equal(Trits{0, 0, 0}))
+		})
+
+		It("should return an error when the value does not fit", func() {
+			_, err := IntToTritsOfLength(12, 2)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for an invalid length", func() {
+			_, err := IntToTritsOfLength(0, 0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("UintToTrits()", func() {
+		It("should return the correct trits representation", func() {
+			Expect(UintToTrits(0)).To(Equal(Trits{0}))
+			Expect(UintToTrits(12)).To(Equal(Trits{0, 1, 1}))
+			Expect(UintToTrits(3332727)).To(Equal(Trits{0, 0, 1, -1, 0, -1, 0, 0, 1, 1, -1, 1, 0, -1, 1}))
+		})
+
+		It("should handle values above the int64 range", func() {
+			trits := UintToTrits(18446744073709551615)
+			for i, trit := range trits {
+				Expect(ValidTrit(trit)).To(BeTrue(), "trit at index %d", i)
+			}
+			// verify by evaluating the trits back modulo the value
+			var val uint64
+			for i := len(trits) - 1; i >= 0; i-- {
+				val = val*3 + uint64(int64(trits[i]))
+			}
+			Expect(val).To(Equal(uint64(18446744073709551615)))
+		})
+	})
+
+	Context("UintToTritsOfLength()", func() {
+		It("should pad the trits to the given length", func() {
+			Expect(UintToTritsOfLength(2, 4)).To(Equal(Trits{-1, 1, 0, 0}))
+		})
+
+		It("should return an error when the value does not fit", func() {
+			_, err := UintToTritsOfLength(12, 2)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("ternary arithmetic", func() {
+		It("SubTrits() should match int64 subtraction", func() {
+			for i := 0; i < 100; i++ {
+				a, b := rand.Int63n(2000000)-1000000, rand.Int63n(2000000)-1000000
+				Expect(TritsToInt(SubTrits(IntToTrits(a), IntToTrits(b)))).To(Equal(a - b))
+			}
+			Expect(TritsToInt(SubTrits(IntToTrits(1), IntToTrits(-1)))).To(Equal(int64(2)))
+		})
+
+		It("MulTrits() should match int64 multiplication", func() {
+			for i := 0; i < 100; i++ {
+				a, b := rand.Int63n(200000)-100000, rand.Int63n(200000)-100000
+				Expect(TritsToInt(MulTrits(IntToTrits(a), IntToTrits(b)))).To(Equal(a * b))
+			}
+			Expect(TritsToInt(MulTrits(IntToTrits(0), IntToTrits(42)))).To(Equal(int64(0)))
+		})
+
+		It("NegTrits() should match int64 negation", func() {
+			Expect(TritsToInt(NegTrits(IntToTrits(12)))).To(Equal(int64(-12)))
+			Expect(TritsToInt(NegTrits(IntToTrits(-7)))).To(Equal(int64(7)))
+			Expect(TritsToInt(NegTrits(IntToTrits(0)))).To(Equal(int64(0)))
+		})
+
+		It("CmpTrits() should match int64 comparison", func() {
+			for i := 0; i < 100; i++ {
+				a, b := rand.Int63n(2000000)-1000000, rand.Int63n(2000000)-1000000
+				expected := 0
+				if a < b {
+					expected = -1
+				} else if a > b {
+					expected = 1
+				}
+				Expect(CmpTrits(IntToTrits(a), IntToTrits(b))).To(Equal(expected))
+			}
+			Expect(CmpTrits(IntToTrits(5), IntToTrits(5))).To(Equal(0))
+		})
+	})
+
 	Context("CanTritsToTrytes()", func() {
 		It("returns true for valid lengths", func() {
 			Expect(CanTritsToTrytes(Trits{1, 1, 1})).To(BeTrue())
@@ -173,6 +291,25 @@ var _ = Describe("Trinary", func() {
 			_, err := TritsToBytes(Trits{1, 1})
 			Expect(err).To(HaveOccurred())
 		})
+
+		// the expected bytes are fixed vectors, so a big-endian host producing
+		// different bytes would fail these specs.
+		It("should produce the canonical bytes independently of the host byte order", func() {
+			trits := MustTrytesToTrits("TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK")
+			bytes, err := TritsToBytes(trits)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hex.EncodeToString(bytes)).To(Equal("300b68c34a28b5b4f89973e9226b7d7e66edaf3f9e1747035bec9d77a698dbd6b8124e8e885418889ef14a5f7ec83e6c"))
+		})
+
+		It("should produce the canonical bytes for the all negative edge case", func() {
+			trits := make(Trits, HashTrinarySize)
+			for i := range trits[:HashTrinarySize-1] {
+				trits[i] = -1
+			}
+			bytes, err := TritsToBytes(trits)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hex.EncodeToString(bytes)).To(Equal("a19614105780542055f957fa56097f74b74451c9865b38fdaf6862a8f3dbb791c521ff26eb7bafb060ff89965a31769c"))
+		})
 	})
 
 	Context("BytesToTrits()", func() {
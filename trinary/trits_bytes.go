@@ -0,0 +1,84 @@
+package trinary
+
+import (
+	"math"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// TritsToBytesNLen returns the amount of bytes the conversion of n trits takes up.
+func TritsToBytesNLen(n int) int {
+	bits := int(math.Ceil(float64(n) * math.Log2(TrinaryRadix)))
+	return (bits + 7) / 8
+}
+
+// TritsToBytesN converts trits of any length to bytes using the same bigint
+// approach as TritsToBytes(), which is only defined for hashes. The trits are
+// interpreted as a balanced ternary number which is stored biased by (3^n-1)/2
+// as a little-endian unsigned integer in the minimum amount of bytes. Use
+// BytesToTritsN() with the original trits length for the inverse.
+func TritsToBytesN(trits Trits) ([]byte, error) {
+	if len(trits) == 0 {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "must not be empty")
+	}
+	if err := ValidTrits(trits); err != nil {
+		return nil, err
+	}
+
+	byteLen := TritsToBytesNLen(len(trits))
+	wordLen := (byteLen + 3) / 4
+	base := make([]uint32, wordLen)
+
+	// most significant trit first, biased to the non-negative digits 0, 1, 2
+	for i := len(trits) - 1; i >= 0; i-- {
+		carry := uint32(trits[i] + 1)
+		for j := 0; j < wordLen; j++ {
+			v := uint64(base[j])*uint64(TrinaryRadix) + uint64(carry)
+			base[j] = uint32(v)
+			carry = uint32(v >> 32)
+		}
+	}
+
+	bytes := make([]byte, byteLen)
+	for i := range bytes {
+		bytes[i] = byte(base[i/4] >> (8 * uint(i%4)))
+	}
+	return bytes, nil
+}
+
+// BytesToTritsN converts bytes created by TritsToBytesN() back to their original
+// numTrits trits.
+func BytesToTritsN(bytes []byte, numTrits int) (Trits, error) {
+	if numTrits < 1 {
+		return nil, errors.Wrap(ErrInvalidTritsLength, "must be at least 1")
+	}
+	if len(bytes) != TritsToBytesNLen(numTrits) {
+		return nil, errors.Wrapf(ErrInvalidBytesLength, "must be %d in size for %d trits", TritsToBytesNLen(numTrits), numTrits)
+	}
+
+	wordLen := (len(bytes) + 3) / 4
+	base := make([]uint32, wordLen)
+	for i, b := range bytes {
+		base[i/4] |= uint32(b) << (8 * uint(i%4))
+	}
+
+	trits := make(Trits, numTrits)
+	for i := range trits {
+		var rem uint64
+		for j := wordLen - 1; j >= 0; j-- {
+			v := rem<<32 | uint64(base[j])
+			base[j] = uint32(v / uint64(TrinaryRadix))
+			rem = v % uint64(TrinaryRadix)
+		}
+		trits[i] = int8(rem) - 1
+	}
+
+	// surplus bits beyond the 3^numTrits value range must be zero
+	for _, w := range base {
+		if w != 0 {
+			return nil, errors.Wrapf(ErrInvalidByte, "bytes encode a value out of range for %d trits", numTrits)
+		}
+	}
+	return trits, nil
+}
@@ -0,0 +1,79 @@
+package trinary_test
+
+import (
+	"math/rand"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TritsBytesN", func() {
+
+	randomTrits := func(n int) Trits {
+		trits := make(Trits, n)
+		for i := range trits {
+			trits[i] = int8(rand.Intn(3) - 1)
+		}
+		return trits
+	}
+
+	Context("TritsToBytesN()", func() {
+		It("should use the minimum amount of bytes", func() {
+			bytes, err := TritsToBytesN(Trits{1, -1, 0, 1, 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(HaveLen(1))
+
+			Expect(TritsToBytesNLen(HashTrinarySize)).To(Equal(49))
+		})
+
+		It("should return an error for empty trits", func() {
+			_, err := TritsToBytesN(Trits{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for invalid trits", func() {
+			_, err := TritsToBytesN(Trits{0, 2, 0})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("BytesToTritsN()", func() {
+		It("should round trip signature fragment sized trits", func() {
+			trits := randomTrits(SignatureMessageFragmentTrinarySize)
+			bytes, err := TritsToBytesN(trits)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bytes).To(HaveLen(TritsToBytesNLen(SignatureMessageFragmentTrinarySize)))
+
+			back, err := BytesToTritsN(bytes, SignatureMessageFragmentTrinarySize)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(back).To(Equal(trits))
+		})
+
+		It("should round trip the extreme values", func() {
+			for _, trit := range []int8{-1, 1} {
+				trits := make(Trits, 81)
+				for i := range trits {
+					trits[i] = trit
+				}
+				bytes, err := TritsToBytesN(trits)
+				Expect(err).ToNot(HaveOccurred())
+
+				back, err := BytesToTritsN(bytes, 81)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(back).To(Equal(trits))
+			}
+		})
+
+		It("should return an error for a bytes length mismatch", func() {
+			_, err := BytesToTritsN([]byte{1, 2}, 5)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for bytes encoding an out of range value", func() {
+			_, err := BytesToTritsN([]byte{0xFF}, 5)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
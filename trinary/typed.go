@@ -0,0 +1,136 @@
+package trinary
+
+import (
+	"encoding/json"
+
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/pkg/errors"
+)
+
+// HashTritsArray holds the trits of a hash as a fixed size array, so APIs can express
+// the 243 trits length requirement at compile time instead of re-validating the
+// length of a Trits slice at runtime.
+type HashTritsArray [HashTrinarySize]int8
+
+// NewHashTritsArray creates HashTritsArray from the given trits and validates their length and values.
+func NewHashTritsArray(trits Trits) (HashTritsArray, error) {
+	var hash HashTritsArray
+	if len(trits) != HashTrinarySize {
+		return hash, errors.Wrapf(ErrInvalidTritsLength, "must be %d in size", HashTrinarySize)
+	}
+	if err := ValidTrits(trits); err != nil {
+		return hash, err
+	}
+	copy(hash[:], trits)
+	return hash, nil
+}
+
+// Trits returns a copy of the hash trits as a slice.
+func (h *HashTritsArray) Trits() Trits {
+	trits := make(Trits, HashTrinarySize)
+	copy(trits, h[:])
+	return trits
+}
+
+// Trytes returns the trytes representation of the hash trits.
+func (h *HashTritsArray) Trytes() Trytes {
+	return MustTritsToTrytes(h[:])
+}
+
+// TryteString is a tryte string which is validated once on construction, so it
+// can be passed around without every consumer re-validating it.
+type TryteString struct {
+	trytes Trytes
+}
+
+// NewTryteString creates a TryteString from the given trytes and validates them.
+func NewTryteString(trytes Trytes) (TryteString, error) {
+	if err := ValidTrytes(trytes); err != nil {
+		return TryteString{}, err
+	}
+	return TryteString{trytes: trytes}, nil
+}
+
+// Trytes returns the underlying validated trytes.
+func (ts TryteString) Trytes() Trytes {
+	return ts.trytes
+}
+
+// Trits returns the trits representation of the tryte string.
+func (ts TryteString) Trits() Trits {
+	return MustTrytesToTrits(ts.trytes)
+}
+
+// Len returns the length of the tryte string in trytes.
+func (ts TryteString) Len() int {
+	return len(ts.trytes)
+}
+
+// String returns the tryte string as a plain string.
+func (ts TryteString) String() string {
+	return string(ts.trytes)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (ts TryteString) MarshalText() ([]byte, error) {
+	return []byte(ts.trytes), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and validates the trytes.
+func (ts *TryteString) UnmarshalText(text []byte) error {
+	parsed, err := NewTryteString(Trytes(text))
+	if err != nil {
+		return err
+	}
+	*ts = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler by encoding the trytes as a JSON string.
+func (ts TryteString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ts.trytes)
+}
+
+// UnmarshalJSON implements json.Unmarshaler and validates the trytes, so REST
+// services don't accept arbitrary strings into tryte fields.
+func (ts *TryteString) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return ts.UnmarshalText([]byte(s))
+}
+
+// MarshalText implements encoding.TextMarshaler by encoding the hash as trytes.
+func (h *HashTritsArray) MarshalText() ([]byte, error) {
+	return []byte(h.Trytes()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and validates that the text
+// holds the trytes of a hash.
+func (h *HashTritsArray) UnmarshalText(text []byte) error {
+	if len(text) != HashTrinarySize/3 {
+		return errors.Wrapf(ErrInvalidTrytes, "hash must be %d trytes in size", HashTrinarySize/3)
+	}
+	trits, err := TrytesToTrits(Trytes(text))
+	if err != nil {
+		return err
+	}
+	copy(h[:], trits)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler by encoding the hash as a JSON string of trytes.
+func (h *HashTritsArray) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.Trytes())
+}
+
+// UnmarshalJSON implements json.Unmarshaler and validates that the string holds
+// the trytes of a hash.
+func (h *HashTritsArray) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return h.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,88 @@
+package trinary_test
+
+import (
+	"encoding/json"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Typed", func() {
+
+	hashTrytes := "TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZMBTZCDTTJVUFPTCCVHHORPMGCURKTH9VGJIXUQJVHK"
+
+	Context("HashTritsArray", func() {
+		It("should round trip through the typed representation", func() {
+			hash, err := NewHashTritsArray(MustTrytesToTrits(hashTrytes))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hash.Trits()).To(Equal(MustTrytesToTrits(hashTrytes)))
+			Expect(hash.Trytes()).To(Equal(hashTrytes))
+		})
+
+		It("should return an error for trits of the wrong length", func() {
+			_, err := NewHashTritsArray(Trits{1, 0, -1})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for invalid trits", func() {
+			trits := make(Trits, HashTrinarySize)
+			trits[0] = 2
+			_, err := NewHashTritsArray(trits)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("TryteString", func() {
+		It("should hold validated trytes", func() {
+			ts, err := NewTryteString(hashTrytes)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ts.Trytes()).To(Equal(hashTrytes))
+			Expect(ts.Len()).To(Equal(81))
+			Expect(ts.Trits()).To(Equal(MustTrytesToTrits(hashTrytes)))
+		})
+
+		It("should return an error for invalid trytes", func() {
+			_, err := NewTryteString("abc")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("JSON marshaling", func() {
+		It("should round trip a TryteString", func() {
+			ts, err := NewTryteString("IOTA9")
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := json.Marshal(ts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(Equal(`"IOTA9"`))
+
+			var back TryteString
+			Expect(json.Unmarshal(data, &back)).To(Succeed())
+			Expect(back.Trytes()).To(Equal(Trytes("IOTA9")))
+		})
+
+		It("should reject invalid trytes on decode", func() {
+			var ts TryteString
+			Expect(json.Unmarshal([]byte(`"not trytes"`), &ts)).ToNot(Succeed())
+		})
+
+		It("should round trip HashTritsArray", func() {
+			hash, err := NewHashTritsArray(MustTrytesToTrits(hashTrytes))
+			Expect(err).ToNot(HaveOccurred())
+
+			data, err := json.Marshal(&hash)
+			Expect(err).ToNot(HaveOccurred())
+
+			var back HashTritsArray
+			Expect(json.Unmarshal(data, &back)).To(Succeed())
+			Expect(back).To(Equal(hash))
+		})
+
+		It("should reject a hash of the wrong length on decode", func() {
+			var hash HashTritsArray
+			Expect(json.Unmarshal([]byte(`"IOTA9"`), &hash)).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package trinary
+
+import (
+	. "github.com/iotaledger/iota.go/consts"
+)
+
+// tryteCharToTritsArrayLUT is the flat array form of tryteCharToTritsLUT;
+// indexing a fixed size array avoids the slice header loads on the fast path.
+var tryteCharToTritsArrayLUT [256][3]int8
+
+func init() {
+	for i := 0; i < len(TryteAlphabet); i++ {
+		copy(tryteCharToTritsArrayLUT[TryteAlphabet[i]][:], TryteToTritsLUT[i])
+	}
+}
+
+// UncheckedTrytesToTrits converts trytes to trits like TrytesToTrits but
+// without any validation, for callers who already know the input is valid,
+// e.g. data read back from their own database. The result for non tryte
+// characters is undefined.
+func UncheckedTrytesToTrits(trytes Trytes) Trits {
+	trits := make(Trits, len(trytes)*3)
+	for i := 0; i < len(trytes); i++ {
+		lut := &tryteCharToTritsArrayLUT[trytes[i]]
+		trits[i*3] = lut[0]
+		trits[i*3+1] = lut[1]
+		trits[i*3+2] = lut[2]
+	}
+	return trits
+}
+
+// UncheckedTritsToTrytes converts trits to trytes like TritsToTrytes but
+// without any validation. The trits length must be a multiple of 3 and the
+// result for values outside of -1 to 1 is undefined.
+func UncheckedTritsToTrytes(trits Trits) Trytes {
+	o := make([]byte, len(trits)/3)
+	for i := range o {
+		o[i] = tritsToTryteCharLUT[(trits[i*3]+1)+(trits[i*3+1]+1)*3+(trits[i*3+2]+1)*9]
+	}
+	return Trytes(o)
+}
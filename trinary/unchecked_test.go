@@ -0,0 +1,24 @@
+package trinary_test
+
+import (
+	. "github.com/iotaledger/iota.go/trinary"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unchecked", func() {
+
+	trytes := Trytes("TXEFLKNPJRBYZPORHZU9CEMFIFVVQBUSTDGSJCZ")
+
+	Context("UncheckedTrytesToTrits()", func() {
+		It("should match TrytesToTrits() for valid input", func() {
+			Expect(UncheckedTrytesToTrits(trytes)).To(Equal(MustTrytesToTrits(trytes)))
+		})
+	})
+
+	Context("UncheckedTritsToTrytes()", func() {
+		It("should match TritsToTrytes() for valid input", func() {
+			Expect(UncheckedTritsToTrytes(MustTrytesToTrits(trytes))).To(Equal(trytes))
+		})
+	})
+})
@@ -0,0 +1,75 @@
+package trinary_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	. "github.com/iotaledger/iota.go/consts"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// transaction sized trytes as they appear on hot paths like TrytesToTrits
+var benchTrytes = Trytes(strings.Repeat("RKTH9VGJI", 297))
+
+// the previous regexp based implementation, kept as the benchmark baseline
+var benchTrytesRegex = regexp.MustCompile("^[9A-Z]+$")
+
+var benchErr error
+var benchMatched bool
+
+func BenchmarkValidTrytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchErr = ValidTrytes(benchTrytes)
+	}
+}
+
+func BenchmarkValidTrytesRegexp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchMatched = benchTrytesRegex.MatchString(string(benchTrytes))
+	}
+}
+
+var benchTrits Trits
+
+func BenchmarkTrytesToTrits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchTrits, benchErr = TrytesToTrits(benchTrytes)
+	}
+}
+
+func BenchmarkUncheckedTrytesToTrits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchTrits = UncheckedTrytesToTrits(benchTrytes)
+	}
+}
+
+var benchTrytesOut Trytes
+
+func BenchmarkTritsToTrytes(b *testing.B) {
+	trits := MustTrytesToTrits(benchTrytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchTrytesOut = MustTritsToTrytes(trits)
+	}
+}
+
+func BenchmarkUncheckedTritsToTrytes(b *testing.B) {
+	trits := MustTrytesToTrits(benchTrytes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchTrytesOut = UncheckedTritsToTrytes(trits)
+	}
+}
+
+// the previous strings.Index based implementation, kept as the benchmark baseline
+func BenchmarkTrytesToTritsStringsIndex(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		trits := make(Trits, len(benchTrytes)*3)
+		for j := range benchTrytes {
+			idx := strings.Index(TryteAlphabet, string(benchTrytes[j:j+1]))
+			copy(trits[j*3:j*3+3], TryteToTritsLUT[idx])
+		}
+		benchTrits = trits
+	}
+}
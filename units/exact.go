@@ -0,0 +1,121 @@
+package units
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUnknownUnit gets returned when a unit suffix is not recognized.
+	ErrUnknownUnit = errors.New("unknown unit")
+	// ErrInvalidAmount gets returned when an amount string can not be parsed.
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrNonIntegerIotas gets returned when an amount does not resolve to a
+	// whole number of iotas.
+	ErrNonIntegerIotas = errors.New("amount does not resolve to a whole number of iotas")
+	// ErrNegativeAmount gets returned when an amount is negative.
+	ErrNegativeAmount = errors.New("amount must not be negative")
+)
+
+// unitSuffixes maps unit suffixes to their Unit.
+var unitSuffixes = map[string]Unit{
+	"i": I, "ki": Ki, "mi": Mi, "gi": Gi, "ti": Ti, "pi": Pi,
+}
+
+// UnitFromString parses a unit suffix such as "Mi" or "i" (case-insensitive).
+func UnitFromString(s string) (Unit, error) {
+	unit, ok := unitSuffixes[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return 0, errors.Wrap(ErrUnknownUnit, s)
+	}
+	return unit, nil
+}
+
+// ConvertUnitsExact converts the given value from one Unit to another without
+// any floating point rounding, using exact rational arithmetic.
+func ConvertUnitsExact(value *big.Rat, from Unit, to Unit) *big.Rat {
+	converted := new(big.Rat).Set(value)
+	converted.Mul(converted, new(big.Rat).SetInt64(int64(from)))
+	converted.Quo(converted, new(big.Rat).SetInt64(int64(to)))
+	return converted
+}
+
+// ParseAmount parses an amount string with an optional unit suffix, e.g.
+// "3.141 Mi", "1Gi" or "1000", into its value in iotas. The amount must
+// resolve to a non negative whole number of iotas.
+func ParseAmount(s string) (uint64, error) {
+	trimmed := strings.TrimSpace(s)
+	if len(trimmed) == 0 {
+		return 0, ErrInvalidAmount
+	}
+
+	// split off the unit suffix
+	split := len(trimmed)
+	for split > 0 {
+		c := trimmed[split-1]
+		if (c >= '0' && c <= '9') || c == '.' {
+			break
+		}
+		split--
+	}
+	number := strings.TrimSpace(trimmed[:split])
+	if len(number) == 0 {
+		return 0, errors.Wrap(ErrInvalidAmount, s)
+	}
+	unit := I
+	if suffix := trimmed[split:]; len(suffix) != 0 {
+		var err error
+		if unit, err = UnitFromString(suffix); err != nil {
+			return 0, err
+		}
+	}
+
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, errors.Wrap(ErrInvalidAmount, s)
+	}
+	iotas := ConvertUnitsExact(value, unit, I)
+	if !iotas.IsInt() {
+		return 0, errors.Wrap(ErrNonIntegerIotas, s)
+	}
+	if iotas.Sign() < 0 {
+		return 0, errors.Wrap(ErrNegativeAmount, s)
+	}
+	if !iotas.Num().IsUint64() {
+		return 0, errors.Wrap(ErrInvalidAmount, s)
+	}
+	return iotas.Num().Uint64(), nil
+}
+
+// FormatAmount formats the given amount of iotas in the given Unit with the
+// given amount of fractional digits, without any floating point rounding
+// errors. The used precision must be large enough to represent the amount
+// exactly, otherwise the last digit is rounded.
+func FormatAmount(iotas uint64, unit Unit, precision int) string {
+	value := new(big.Rat).SetUint64(iotas)
+	converted := ConvertUnitsExact(value, I, unit)
+	if precision <= 0 {
+		if converted.IsInt() {
+			return converted.Num().String()
+		}
+		precision = exactPrecision(converted)
+	}
+	return converted.FloatString(precision)
+}
+
+// exactPrecision returns the amount of fractional digits needed to represent
+// the given value exactly. Unit denominators are powers of ten, so every
+// conversion result has a finite decimal expansion.
+func exactPrecision(value *big.Rat) int {
+	precision := 0
+	ten := big.NewInt(10)
+	denom := new(big.Int).Set(value.Denom())
+	one := big.NewInt(1)
+	for denom.Cmp(one) > 0 {
+		denom.Div(denom, ten)
+		precision++
+	}
+	return precision
+}
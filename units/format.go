@@ -0,0 +1,147 @@
+package units
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrAmbiguousAmount gets returned by ParseAmountStrict when the input does
+// not follow the canonical "<number> <Unit>" form.
+var ErrAmbiguousAmount = errors.New("ambiguous amount")
+
+// HumanFormat describes how to render amounts for humans. Its zero value
+// auto-selects the largest fitting unit and uses "." and "," as decimal and
+// thousand separator.
+type HumanFormat struct {
+	// The unit to format in. Zero auto-selects the largest unit in which
+	// the integer part of the amount is non zero.
+	Unit Unit
+	// Always format the exact amount of iotas, ignoring Unit.
+	ExactIotas bool
+	// The maximum amount of fractional digits. Zero keeps all digits
+	// needed to represent the amount exactly.
+	Precision int
+	// The separator grouping the integer part into thousands.
+	// Defaults to ",".
+	ThousandSeparator string
+	// The separator between the integer and the fractional part.
+	// Defaults to ".".
+	DecimalSeparator string
+}
+
+// Format renders the given amount of iotas according to the format,
+// including the unit suffix.
+func (f HumanFormat) Format(iotas uint64) string {
+	if f.ThousandSeparator == "" {
+		f.ThousandSeparator = ","
+	}
+	if f.DecimalSeparator == "" {
+		f.DecimalSeparator = "."
+	}
+
+	unit := f.Unit
+	switch {
+	case f.ExactIotas:
+		unit = I
+	case unit == 0:
+		unit = AutoUnit(iotas)
+	}
+
+	formatted := FormatAmount(iotas, unit, f.Precision)
+	integer, fraction := formatted, ""
+	if i := strings.IndexByte(formatted, '.'); i != -1 {
+		integer, fraction = formatted[:i], formatted[i+1:]
+	}
+	integer = groupThousands(integer, f.ThousandSeparator)
+	if fraction != "" {
+		integer += f.DecimalSeparator + fraction
+	}
+	return integer + " " + UnitName(unit)
+}
+
+// FormatHuman renders the given amount of iotas with the default
+// HumanFormat, e.g. "3.141 Mi" or "1,234 i".
+func FormatHuman(iotas uint64) string {
+	return HumanFormat{}.Format(iotas)
+}
+
+// AutoUnit returns the largest Unit in which the integer part of the given
+// amount of iotas is non zero.
+func AutoUnit(iotas uint64) Unit {
+	switch {
+	case iotas >= uint64(Pi):
+		return Pi
+	case iotas >= uint64(Ti):
+		return Ti
+	case iotas >= uint64(Gi):
+		return Gi
+	case iotas >= uint64(Mi):
+		return Mi
+	case iotas >= uint64(Ki):
+		return Ki
+	default:
+		return I
+	}
+}
+
+// UnitName returns the canonical suffix of the given Unit.
+func UnitName(unit Unit) string {
+	switch unit {
+	case Pi:
+		return "Pi"
+	case Ti:
+		return "Ti"
+	case Gi:
+		return "Gi"
+	case Mi:
+		return "Mi"
+	case Ki:
+		return "Ki"
+	default:
+		return "i"
+	}
+}
+
+// strictAmountRegex matches the canonical "<number> <Unit>" form with an
+// optional single space and a case sensitive unit suffix.
+var strictAmountRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?) ?(i|Ki|Mi|Gi|Ti|Pi)$`)
+
+// ParseAmountStrict parses an amount string into its value in iotas,
+// accepting only the canonical form produced by HumanFormat without
+// separators: a plain decimal number followed by an explicit, case sensitive
+// unit suffix, e.g. "3.141 Mi" or "1000 i". Any other input, such as a bare
+// number without a unit, a lowercased suffix or grouped digits, gets
+// rejected with ErrAmbiguousAmount.
+func ParseAmountStrict(s string) (uint64, error) {
+	matches := strictAmountRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, errors.Wrap(ErrAmbiguousAmount, s)
+	}
+	unit, err := UnitFromString(matches[2])
+	if err != nil {
+		return 0, err
+	}
+	return ParseAmount(matches[1] + UnitName(unit))
+}
+
+// groupThousands groups the digits of the given integer with the given
+// separator.
+func groupThousands(digits string, sep string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	lead := len(digits) % 3
+	if lead != 0 {
+		sb.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if sb.Len() != 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
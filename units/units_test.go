@@ -4,6 +4,7 @@ import (
 	. "github.com/iotaledger/iota.go/units"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 
 	. "github.com/onsi/ginkgo/extensions/table"
 )
@@ -30,4 +31,66 @@ var _ = Describe("Units", func() {
 		Entry("Gi to I", "133.999111111", Gi, I, float64(133999111111)),
 	)
 
+	DescribeTable("amount parsing",
+		func(in string, expected uint64) {
+			Expect(ParseAmount(in)).To(Equal(expected))
+		},
+		Entry("plain iotas", "1000", uint64(1000)),
+		Entry("Mi with decimals", "3.141 Mi", uint64(3141000)),
+		Entry("Gi without space", "1Gi", uint64(1000000000)),
+		Entry("large Ti amount", "2779.530283277761 Ti", uint64(2779530283277761)),
+	)
+
+	DescribeTable("amount parsing errors",
+		func(in string, expected error) {
+			_, err := ParseAmount(in)
+			Expect(errors.Cause(err)).To(Equal(expected))
+		},
+		Entry("unknown unit", "1 Zi", ErrUnknownUnit),
+		Entry("garbage", "NOTANUMBER", ErrInvalidAmount),
+		Entry("fractional iotas", "1.5i", ErrNonIntegerIotas),
+		Entry("negative amount", "-1 Mi", ErrNegativeAmount),
+		Entry("amount beyond uint64", "18446744073709551617", ErrInvalidAmount),
+	)
+
+	DescribeTable("amount formatting",
+		func(iotas uint64, unit Unit, precision int, expected string) {
+			Expect(FormatAmount(iotas, unit, precision)).To(Equal(expected))
+		},
+		Entry("exact Ti without precision loss", uint64(2779530283277761), Ti, 0, "2779.530283277761"),
+		Entry("fixed precision", uint64(3141000), Mi, 3, "3.141"),
+		Entry("whole amount without fraction", uint64(1000000000), Gi, 0, "1"),
+		Entry("rounded to given precision", uint64(1234567), Mi, 2, "1.23"),
+	)
+
+	DescribeTable("human formatting",
+		func(iotas uint64, format HumanFormat, expected string) {
+			Expect(format.Format(iotas)).To(Equal(expected))
+		},
+		Entry("auto unit", uint64(3141000), HumanFormat{}, "3.141 Mi"),
+		Entry("auto unit below Ki", uint64(999), HumanFormat{}, "999 i"),
+		Entry("exact iotas with separators", uint64(1234567), HumanFormat{ExactIotas: true}, "1,234,567 i"),
+		Entry("fixed unit and precision", uint64(1234567), HumanFormat{Unit: Mi, Precision: 2}, "1.23 Mi"),
+		Entry("locale separators", uint64(1234567890), HumanFormat{ExactIotas: true, ThousandSeparator: "."}, "1.234.567.890 i"),
+	)
+
+	DescribeTable("strict parsing",
+		func(in string, expected uint64) {
+			Expect(ParseAmountStrict(in)).To(Equal(expected))
+		},
+		Entry("with space", "3.141 Mi", uint64(3141000)),
+		Entry("without space", "1000i", uint64(1000)),
+	)
+
+	DescribeTable("strict parsing rejections",
+		func(in string) {
+			_, err := ParseAmountStrict(in)
+			Expect(errors.Cause(err)).To(Equal(ErrAmbiguousAmount))
+		},
+		Entry("bare number", "1000"),
+		Entry("lowercased suffix", "1 mi"),
+		Entry("grouped digits", "1,000 i"),
+		Entry("negative amount", "-1 Mi"),
+	)
+
 })
@@ -0,0 +1,4 @@
+// Package wasm provides a small JS interop layer exposing core library
+// functions to browser environments. The interop functions are only compiled
+// when building with GOOS=js GOARCH=wasm.
+package wasm
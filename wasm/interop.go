@@ -0,0 +1,80 @@
+//go:build js && wasm
+// +build js,wasm
+
+package wasm
+
+import (
+	"crypto/rand"
+	"math/big"
+	"syscall/js"
+
+	"github.com/iotaledger/iota.go/address"
+	. "github.com/iotaledger/iota.go/consts"
+	"github.com/iotaledger/iota.go/pow"
+	. "github.com/iotaledger/iota.go/trinary"
+)
+
+// Export registers the interop functions under the given global namespace,
+// e.g. Export("iota") makes iota.generateSeed(), iota.newAddress(seed,
+// index, security, withChecksum) and iota.pow(trytes, mwm) available to JS.
+// All functions return Promises; the actual work runs on a separate
+// goroutine so the JS event loop is not blocked while waiting.
+func Export(namespace string) {
+	module := js.Global().Get("Object").New()
+	module.Set("generateSeed", asyncFunc(func(args []js.Value) (interface{}, error) {
+		seed, err := generateSeed()
+		return string(seed), err
+	}))
+	module.Set("newAddress", asyncFunc(func(args []js.Value) (interface{}, error) {
+		addr, err := address.GenerateAddress(
+			Trytes(args[0].String()), uint64(args[1].Int()),
+			SecurityLevel(args[2].Int()), args[3].Bool(),
+		)
+		return string(addr), err
+	}))
+	module.Set("pow", asyncFunc(func(args []js.Value) (interface{}, error) {
+		trytes := Trytes(args[0].String())
+		_, powFunc := pow.GetFastestProofOfWorkImpl()
+		nonce, err := powFunc(trytes, args[1].Int(), 1)
+		if err != nil {
+			return nil, err
+		}
+		return string(trytes[:NonceTrinaryOffset/3] + nonce), nil
+	}))
+	js.Global().Set(namespace, module)
+}
+
+// asyncFunc wraps the given function into a JS function returning a Promise
+// which gets resolved with the function's result or rejected with its error
+// message.
+func asyncFunc(fn func(args []js.Value) (interface{}, error)) js.Func {
+	return js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		handler := js.FuncOf(func(this js.Value, promiseArgs []js.Value) interface{} {
+			resolve, reject := promiseArgs[0], promiseArgs[1]
+			go func() {
+				result, err := fn(args)
+				if err != nil {
+					reject.Invoke(err.Error())
+					return
+				}
+				resolve.Invoke(result)
+			}()
+			return nil
+		})
+		return js.Global().Get("Promise").New(handler)
+	})
+}
+
+// generateSeed generates a new cryptographically secure random seed.
+func generateSeed() (Trytes, error) {
+	alphabetLength := big.NewInt(int64(len(TryteAlphabet)))
+	seed := make([]byte, HashTrytesSize)
+	for i := range seed {
+		index, err := rand.Int(rand.Reader, alphabetLength)
+		if err != nil {
+			return "", err
+		}
+		seed[i] = TryteAlphabet[index.Int64()]
+	}
+	return Trytes(seed), nil
+}